@@ -1,12 +1,12 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -20,7 +20,7 @@ type Account struct {
 	Name string `json:"Name,omitempty" xml:"Name,omitempty"`
 
 	// See Account Types
-	Type string `json:"Type,omitempty" xml:"Type,omitempty"`
+	Type AccountType `json:"Type,omitempty" xml:"Type,omitempty"`
 
 	// For bank accounts only (Account Type BANK)
 	BankAccountNumber string `json:"BankAccountNumber,omitempty" xml:"BankAccountNumber,omitempty"`
@@ -38,7 +38,7 @@ type Account struct {
 	CurrencyCode string `json:"CurrencyCode,omitempty" xml:"CurrencyCode,omitempty"`
 
 	// See Tax Types
-	TaxType string `json:"TaxType,omitempty" xml:"TaxType,omitempty"`
+	TaxType TaxType `json:"TaxType,omitempty" xml:"TaxType,omitempty"`
 
 	// Boolean – describes whether account can have payments applied to it
 	EnablePaymentsToAccount bool `json:"EnablePaymentsToAccount,omitempty" xml:"EnablePaymentsToAccount,omitempty"`
@@ -65,7 +65,7 @@ type Account struct {
 	HasAttachments bool `json:"HasAttachments,omitempty" xml:"-"`
 
 	// Last modified date UTC format
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"-"`
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"-"`
 }
 
 //Accounts contains a collection of Accounts
@@ -73,20 +73,6 @@ type Accounts struct {
 	Accounts []Account `json:"Accounts,omitempty" xml:"Account,omitempty"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (a *Accounts) convertDates() error {
-	var err error
-	for n := len(a.Accounts) - 1; n >= 0; n-- {
-		a.Accounts[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(a.Accounts[n].UpdatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalAccount(accountResponseBytes []byte) (*Accounts, error) {
 	var accountResponse *Accounts
 	err := json.Unmarshal(accountResponseBytes, &accountResponse)
@@ -94,16 +80,42 @@ func unmarshalAccount(accountResponseBytes []byte) (*Accounts, error) {
 		return nil, err
 	}
 
-	err = accountResponse.convertDates()
-	if err != nil {
-		return nil, err
+	return accountResponse, err
+}
+
+//Validate checks the accounts against the rules Xero applies so mistakes are found before anything is sent
+func (a *Accounts) Validate() error {
+	v := &validator{}
+	for n := range a.Accounts {
+		a.Accounts[n].validate(v, index("Accounts", n))
 	}
+	return v.err()
+}
 
-	return accountResponse, err
+func (a *Account) validate(v *validator, field string) {
+	if a.AccountID == "" {
+		v.required(field+".Code", a.Code)
+		v.required(field+".Name", a.Name)
+		v.required(field+".Type", string(a.Type))
+	}
+	v.maxLength(field+".Code", a.Code, 10)
+	v.maxLength(field+".Name", a.Name, 150)
+	v.maxLength(field+".Description", a.Description, 4000)
+	v.code(field+".Type", string(a.Type), a.Type.IsValid())
 }
 
 //Create will create accounts given an Accounts struct
 func (a *Accounts) Create(provider *xerogolang.Provider, session goth.Session) (*Accounts, error) {
+	return a.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (a *Accounts) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Accounts, error) {
+	err := a.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -114,7 +126,7 @@ func (a *Accounts) Create(provider *xerogolang.Provider, session goth.Session) (
 		return nil, err
 	}
 
-	accountResponseBytes, err := provider.Create(session, "Accounts", additionalHeaders, body)
+	accountResponseBytes, err := provider.CreateCtx(ctx, session, "Accounts", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -125,6 +137,16 @@ func (a *Accounts) Create(provider *xerogolang.Provider, session goth.Session) (
 //Update will update an account given an Accounts struct
 //This will only handle single account - you cannot update multiple accounts in a single call
 func (a *Accounts) Update(provider *xerogolang.Provider, session goth.Session) (*Accounts, error) {
+	return a.UpdateCtx(context.Background(), provider, session)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (a *Accounts) UpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Accounts, error) {
+	err := a.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -135,7 +157,7 @@ func (a *Accounts) Update(provider *xerogolang.Provider, session goth.Session) (
 		return nil, err
 	}
 
-	accountResponseBytes, err := provider.Update(session, "Accounts/"+a.Accounts[0].AccountID, additionalHeaders, body)
+	accountResponseBytes, err := provider.UpdateCtx(ctx, session, "Accounts/"+a.Accounts[0].AccountID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -146,6 +168,11 @@ func (a *Accounts) Update(provider *xerogolang.Provider, session goth.Session) (
 //FindAccountsModifiedSince will get all accounts modified after a specified date.
 //additional querystringParameters such as where and order can be added as a map
 func FindAccountsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Accounts, error) {
+	return FindAccountsModifiedSinceCtx(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindAccountsModifiedSinceCtx is FindAccountsModifiedSince with a context that can cancel the request or set its deadline
+func FindAccountsModifiedSinceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Accounts, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -154,7 +181,7 @@ func FindAccountsModifiedSince(provider *xerogolang.Provider, session goth.Sessi
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	accountResponseBytes, err := provider.Find(session, "Accounts", additionalHeaders, querystringParameters)
+	accountResponseBytes, err := provider.FindCtx(ctx, session, "Accounts", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -165,16 +192,26 @@ func FindAccountsModifiedSince(provider *xerogolang.Provider, session goth.Sessi
 //FindAccounts will get all accounts. These account will not have details like line items.
 //additional querystringParameters such as where and order can be added as a map
 func FindAccounts(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Accounts, error) {
-	return FindAccountsModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindAccountsCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindAccountsCtx is FindAccounts with a context that can cancel the request or set its deadline
+func FindAccountsCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Accounts, error) {
+	return FindAccountsModifiedSinceCtx(ctx, provider, session, dayZero, querystringParameters)
 }
 
 //FindAccount will get a single account - accountID must be a GUID for an account
 func FindAccount(provider *xerogolang.Provider, session goth.Session, accountID string) (*Accounts, error) {
+	return FindAccountCtx(context.Background(), provider, session, accountID)
+}
+
+//FindAccountCtx is FindAccount with a context that can cancel the request or set its deadline
+func FindAccountCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, accountID string) (*Accounts, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	accountResponseBytes, err := provider.Find(session, "Accounts/"+accountID, additionalHeaders, nil)
+	accountResponseBytes, err := provider.FindCtx(ctx, session, "Accounts/"+accountID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -184,11 +221,16 @@ func FindAccount(provider *xerogolang.Provider, session goth.Session, accountID
 
 //RemoveAccount will get a single account - accountID must be a GUID for an account
 func RemoveAccount(provider *xerogolang.Provider, session goth.Session, accountID string) (*Accounts, error) {
+	return RemoveAccountCtx(context.Background(), provider, session, accountID)
+}
+
+//RemoveAccountCtx is RemoveAccount with a context that can cancel the request or set its deadline
+func RemoveAccountCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, accountID string) (*Accounts, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	accountResponseBytes, err := provider.Remove(session, "Accounts/"+accountID, additionalHeaders)
+	accountResponseBytes, err := provider.RemoveCtx(ctx, session, "Accounts/"+accountID, additionalHeaders)
 	if err != nil {
 		return nil, err
 	}
@@ -201,10 +243,10 @@ func GenerateExampleAccount() *Accounts {
 	account := Account{
 		Code:                    "9999",
 		Name:                    "Import/Exports",
-		Type:                    "SALES",
+		Type:                    AccountTypeSales,
 		Status:                  "ACTIVE",
 		Description:             "Proceeds from importing/exporting latex",
-		TaxType:                 "OUTPUT2",
+		TaxType:                 TaxTypeOutput2,
 		EnablePaymentsToAccount: false,
 		ShowInExpenseClaims:     false,
 	}
@@ -0,0 +1,56 @@
+package accounting
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/XeroAPI/xerogolang"
+	"golang.org/x/oauth2"
+)
+
+//withAccounting points a provider at handler in place of Xero's accounting API
+func withAccounting(handler http.HandlerFunc, f func(provider *xerogolang.Provider, session *xerogolang.Session)) {
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	provider := xerogolang.NewOAuth2("CLIENT", "SECRET", "/foo")
+	provider.Endpoints = xerogolang.Endpoints{Accounting: ts.URL + "/"}
+	session := &xerogolang.Session{OAuth2Token: &oauth2.Token{AccessToken: "ACCESSTOKEN", RefreshToken: "REFRESHTOKEN", TokenType: "Bearer"}}
+	f(provider, session)
+}
+
+//mockAccounting answers each request with the next of responses and records the method, path and body of each
+func mockAccounting(responses []string, f func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string)) {
+	var mutex sync.Mutex
+	var requests []string
+	handler := func(res http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		uri := req.URL.EscapedPath()
+		if req.URL.RawQuery != "" {
+			uri += "?" + req.URL.Query().Encode()
+		}
+		mutex.Lock()
+		requests = append(requests, fmt.Sprintf("%s %s %s", req.Method, uri, body))
+		response := responses[len(requests)-1]
+		mutex.Unlock()
+		fmt.Fprint(res, response)
+	}
+	withAccounting(handler, func(provider *xerogolang.Provider, session *xerogolang.Session) {
+		f(provider, session, &requests)
+	})
+}
+
+//fields returns the fields of the errors a Validate found
+func fields(err error) []string {
+	if err == nil {
+		return nil
+	}
+	var fields []string
+	for _, fieldError := range err.(ValidationErrors) {
+		fields = append(fields, fieldError.Field)
+	}
+	return fields
+}
@@ -31,3 +31,15 @@ type Address struct {
 	// max length = 255
 	AttentionTo string `json:"AttentionTo,omitempty" xml:"AttentionTo,omitempty"`
 }
+
+func (a *Address) validate(v *validator, field string) {
+	v.maxLength(field+".AddressLine1", a.AddressLine1, 500)
+	v.maxLength(field+".AddressLine2", a.AddressLine2, 500)
+	v.maxLength(field+".AddressLine3", a.AddressLine3, 500)
+	v.maxLength(field+".AddressLine4", a.AddressLine4, 500)
+	v.maxLength(field+".City", a.City, 255)
+	v.maxLength(field+".Region", a.Region, 255)
+	v.maxLength(field+".PostalCode", a.PostalCode, 50)
+	v.maxLength(field+".Country", a.Country, 50)
+	v.maxLength(field+".AttentionTo", a.AttentionTo, 255)
+}
@@ -1,13 +1,15 @@
 package accounting
 
+import "github.com/XeroAPI/xerogolang"
+
 //Allocation allocated an overpayment or Prepayment to an Invoice
 type Allocation struct {
 
 	// the amount being applied to the invoice
-	AppliedAmount float32 `json:"AppliedAmount,omitempty" xml:"AppliedAmount,omitempty"`
+	AppliedAmount xerogolang.Decimal `json:"AppliedAmount,omitzero" xml:"AppliedAmount,omitempty"`
 
 	// the date the prepayment is applied YYYY-MM-DD (read-only). This will be the latter of the invoice date and the prepayment date.
-	Date string `json:"Date,omitempty" xml:"-"`
+	Date xerogolang.Date `json:"Date,omitzero" xml:"-"`
 
 	//The Invoice that the allocation will be made to
 	Invoice InvoiceID `json:"Invoice,omitempty" xml:"Invoice>InvoiceID,omitempty"`
@@ -0,0 +1,179 @@
+package accounting
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+
+	"github.com/XeroAPI/xerogolang"
+	"github.com/markbates/goth"
+)
+
+//AttachmentEndpoint is an endpoint whose entities can have files attached
+type AttachmentEndpoint string
+
+const (
+	AccountAttachments          AttachmentEndpoint = "Accounts"
+	BankTransactionAttachments  AttachmentEndpoint = "BankTransactions"
+	BankTransferAttachments     AttachmentEndpoint = "BankTransfers"
+	ContactAttachments          AttachmentEndpoint = "Contacts"
+	CreditNoteAttachments       AttachmentEndpoint = "CreditNotes"
+	InvoiceAttachments          AttachmentEndpoint = "Invoices"
+	ManualJournalAttachments    AttachmentEndpoint = "ManualJournals"
+	PurchaseOrderAttachments    AttachmentEndpoint = "PurchaseOrders"
+	QuoteAttachments            AttachmentEndpoint = "Quotes"
+	ReceiptAttachments          AttachmentEndpoint = "Receipts"
+	RepeatingInvoiceAttachments AttachmentEndpoint = "RepeatingInvoices"
+)
+
+//Attachment is a file attached to an invoice, contact or other entity
+type Attachment struct {
+	// Xero generated identifier for the attachment
+	AttachmentID string `json:"AttachmentID,omitempty"`
+
+	// The name of the file e.g. receipt.pdf
+	FileName string `json:"FileName,omitempty"`
+
+	// The URL the file can be downloaded from
+	URL string `json:"Url,omitempty"`
+
+	// The content type of the file e.g. application/pdf or image/png
+	MimeType string `json:"MimeType,omitempty"`
+
+	// The size of the file in bytes
+	ContentLength int64 `json:"ContentLength,omitempty"`
+
+	// Whether the file is shown on the online invoice. Only for invoices and credit notes
+	IncludeOnline bool `json:"IncludeOnline,omitempty"`
+}
+
+//Attachments is a collection of Attachments
+type Attachments struct {
+	Attachments []Attachment `json:"Attachments"`
+}
+
+func unmarshalAttachment(attachmentResponseBytes []byte) (*Attachments, error) {
+	var attachmentResponse *Attachments
+	err := json.Unmarshal(attachmentResponseBytes, &attachmentResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return attachmentResponse, err
+}
+
+//attachmentsPath is the path to the attachments of an entity, or to one of them if name is set
+func attachmentsPath(endpoint AttachmentEndpoint, entityID string, name string) string {
+	path := string(endpoint) + "/" + url.PathEscape(entityID) + "/Attachments"
+	if name != "" {
+		path = path + "/" + url.PathEscape(name)
+	}
+	return path
+}
+
+//FindAttachments will get the details of every file attached to an entity e.g. the invoice with an InvoiceID of entityID
+func FindAttachments(provider *xerogolang.Provider, session goth.Session, endpoint AttachmentEndpoint, entityID string) (*Attachments, error) {
+	return FindAttachmentsCtx(context.Background(), provider, session, endpoint, entityID)
+}
+
+//FindAttachmentsCtx is FindAttachments with a context that can cancel the request or set its deadline
+func FindAttachmentsCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, endpoint AttachmentEndpoint, entityID string) (*Attachments, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	attachmentResponseBytes, err := provider.FindCtx(ctx, session, attachmentsPath(endpoint, entityID, ""), additionalHeaders, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalAttachment(attachmentResponseBytes)
+}
+
+//FindAttachment will get the details of a single file attached to an entity - attachmentIDOrFileName can be
+//the AttachmentID or the FileName of the attachment
+func FindAttachment(provider *xerogolang.Provider, session goth.Session, endpoint AttachmentEndpoint, entityID string, attachmentIDOrFileName string) (*Attachment, error) {
+	return FindAttachmentCtx(context.Background(), provider, session, endpoint, entityID, attachmentIDOrFileName)
+}
+
+//FindAttachmentCtx is FindAttachment with a context that can cancel the request or set its deadline
+func FindAttachmentCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, endpoint AttachmentEndpoint, entityID string, attachmentIDOrFileName string) (*Attachment, error) {
+	attachments, err := FindAttachmentsCtx(ctx, provider, session, endpoint, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range attachments.Attachments {
+		if attachment.AttachmentID == attachmentIDOrFileName || attachment.FileName == attachmentIDOrFileName {
+			return &attachment, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no attachment found for %s", attachmentIDOrFileName)
+}
+
+//DownloadAttachment will get the contents of a file attached to an entity. The file is streamed from Xero
+//as it is read so the caller must close it
+func DownloadAttachment(provider *xerogolang.Provider, session goth.Session, endpoint AttachmentEndpoint, entityID string, attachment *Attachment) (io.ReadCloser, error) {
+	return DownloadAttachmentCtx(context.Background(), provider, session, endpoint, entityID, attachment)
+}
+
+//DownloadAttachmentCtx is DownloadAttachment with a context that can cancel the request or set its deadline
+func DownloadAttachmentCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, endpoint AttachmentEndpoint, entityID string, attachment *Attachment) (io.ReadCloser, error) {
+	//Xero only returns the file when it is asked for by its content type
+	accept := attachment.MimeType
+	if accept == "" {
+		accept = "*/*"
+	}
+	additionalHeaders := map[string]string{
+		"Accept": accept,
+	}
+
+	name := attachment.AttachmentID
+	if name == "" {
+		name = attachment.FileName
+	}
+
+	return provider.DownloadCtx(ctx, session, attachmentsPath(endpoint, entityID, name), additionalHeaders, nil)
+}
+
+//UploadAttachment will attach a file to an entity, replacing any file with the same FileName. The FileName,
+//MimeType and IncludeOnline of attachment are used and the attachment Xero created is returned. A file
+//without a MimeType is sent as application/octet-stream
+func UploadAttachment(provider *xerogolang.Provider, session goth.Session, endpoint AttachmentEndpoint, entityID string, attachment *Attachment, content io.Reader) (*Attachment, error) {
+	return UploadAttachmentCtx(context.Background(), provider, session, endpoint, entityID, attachment, content)
+}
+
+//UploadAttachmentCtx is UploadAttachment with a context that can cancel the request or set its deadline
+func UploadAttachmentCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, endpoint AttachmentEndpoint, entityID string, attachment *Attachment, content io.Reader) (*Attachment, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	path := attachmentsPath(endpoint, entityID, attachment.FileName)
+	if attachment.IncludeOnline {
+		path = path + "?IncludeOnline=true"
+	}
+
+	contentType := attachment.MimeType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	attachmentResponseBytes, err := provider.UploadCtx(ctx, session, path, contentType, additionalHeaders, content)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := unmarshalAttachment(attachmentResponseBytes)
+	if err != nil {
+		return nil, err
+	}
+	if len(attachments.Attachments) == 0 {
+		return nil, fmt.Errorf("Xero did not return the attachment %s", attachment.FileName)
+	}
+
+	return &attachments.Attachments[0], nil
+}
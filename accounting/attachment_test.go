@@ -0,0 +1,140 @@
+package accounting
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/XeroAPI/xerogolang"
+	"github.com/stretchr/testify/assert"
+)
+
+const attachmentsResponse = `{"Attachments":[
+	{"AttachmentID":"a-1","FileName":"receipt.pdf","Url":"https://api.xero.com/api.xro/2.0/Invoices/i-1/Attachments/receipt.pdf","MimeType":"application/pdf","ContentLength":1024,"IncludeOnline":true},
+	{"AttachmentID":"a-2","FileName":"photo.png","MimeType":"image/png","ContentLength":2048}
+]}`
+
+func Test_FindAttachments(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockAccounting([]string{attachmentsResponse}, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		attachments, err := FindAttachments(provider, session, InvoiceAttachments, "i-1")
+		a.NoError(err)
+		a.Equal([]Attachment{
+			{
+				AttachmentID:  "a-1",
+				FileName:      "receipt.pdf",
+				URL:           "https://api.xero.com/api.xro/2.0/Invoices/i-1/Attachments/receipt.pdf",
+				MimeType:      "application/pdf",
+				ContentLength: 1024,
+				IncludeOnline: true,
+			},
+			{AttachmentID: "a-2", FileName: "photo.png", MimeType: "image/png", ContentLength: 2048},
+		}, attachments.Attachments)
+		a.Equal([]string{"GET /Invoices/i-1/Attachments "}, *requests)
+	})
+}
+
+func Test_FindAttachment(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	responses := []string{attachmentsResponse, attachmentsResponse, attachmentsResponse}
+	mockAccounting(responses, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		attachment, err := FindAttachment(provider, session, ContactAttachments, "c-1", "photo.png")
+		a.NoError(err)
+		a.Equal("a-2", attachment.AttachmentID)
+
+		attachment, err = FindAttachment(provider, session, ContactAttachments, "c-1", "a-1")
+		a.NoError(err)
+		a.Equal("receipt.pdf", attachment.FileName)
+
+		_, err = FindAttachment(provider, session, ContactAttachments, "c-1", "missing.pdf")
+		a.EqualError(err, "no attachment found for missing.pdf")
+		a.Len(*requests, 3)
+		a.Equal("GET /Contacts/c-1/Attachments ", (*requests)[0])
+	})
+}
+
+func Test_DownloadAttachment(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var requests []string
+	handler := func(res http.ResponseWriter, req *http.Request) {
+		requests = append(requests, fmt.Sprintf("%s %s Accept: %s", req.Method, req.URL.EscapedPath(), req.Header.Get("Accept")))
+		fmt.Fprint(res, "%PDF-1.4")
+	}
+	withAccounting(handler, func(provider *xerogolang.Provider, session *xerogolang.Session) {
+		//the file name is escaped so that spaces, # and / stay part of it
+		attachment := &Attachment{FileName: "Receipts/May #1.pdf", MimeType: "application/pdf"}
+		file, err := DownloadAttachment(provider, session, ReceiptAttachments, "r-1", attachment)
+		a.NoError(err)
+		contents, err := ioutil.ReadAll(file)
+		a.NoError(err)
+		a.NoError(file.Close())
+		a.Equal("%PDF-1.4", string(contents))
+
+		//the AttachmentID is used when there is one, and any type is accepted when the MimeType is not known
+		file, err = DownloadAttachment(provider, session, ReceiptAttachments, "r-1", &Attachment{AttachmentID: "a-1", FileName: "receipt.pdf"})
+		a.NoError(err)
+		a.NoError(file.Close())
+
+		a.Equal([]string{
+			"GET /Receipts/r-1/Attachments/Receipts%2FMay%20%231.pdf Accept: application/pdf",
+			"GET /Receipts/r-1/Attachments/a-1 Accept: */*",
+		}, requests)
+	})
+}
+
+func Test_UploadAttachment(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var requests []string
+	handler := func(res http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		uri := req.URL.EscapedPath()
+		if req.URL.RawQuery != "" {
+			uri += "?" + req.URL.RawQuery
+		}
+		requests = append(requests, fmt.Sprintf("%s %s Content-Type: %s %s", req.Method, uri, req.Header.Get("Content-Type"), body))
+		fmt.Fprint(res, `{"Attachments":[{"AttachmentID":"a-1","FileName":"May receipt.pdf","MimeType":"application/pdf","ContentLength":8,"IncludeOnline":true}]}`)
+	}
+	withAccounting(handler, func(provider *xerogolang.Provider, session *xerogolang.Session) {
+		attachment := &Attachment{FileName: "May receipt.pdf", MimeType: "application/pdf", IncludeOnline: true}
+		uploaded, err := UploadAttachment(provider, session, InvoiceAttachments, "i-1", attachment, strings.NewReader("%PDF-1.4"))
+		a.NoError(err)
+		a.Equal(&Attachment{AttachmentID: "a-1", FileName: "May receipt.pdf", MimeType: "application/pdf", ContentLength: 8, IncludeOnline: true}, uploaded)
+
+		//IncludeOnline is only sent when it is set
+		attachment = &Attachment{FileName: "photo.png", MimeType: "image/png"}
+		_, err = UploadAttachment(provider, session, ContactAttachments, "c-1", attachment, strings.NewReader("PNG"))
+		a.NoError(err)
+
+		//a file of unknown type is still sent with a Content-Type
+		attachment = &Attachment{FileName: "scan"}
+		_, err = UploadAttachment(provider, session, ReceiptAttachments, "r-1", attachment, strings.NewReader("SCAN"))
+		a.NoError(err)
+
+		a.Equal([]string{
+			"POST /Invoices/i-1/Attachments/May%20receipt.pdf?IncludeOnline=true Content-Type: application/pdf %PDF-1.4",
+			"POST /Contacts/c-1/Attachments/photo.png Content-Type: image/png PNG",
+			"POST /Receipts/r-1/Attachments/scan Content-Type: application/octet-stream SCAN",
+		}, requests)
+	})
+}
+
+func Test_UploadAttachment_NothingReturned(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockAccounting([]string{`{"Attachments":[]}`}, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		attachment := &Attachment{FileName: "receipt.pdf", MimeType: "application/pdf"}
+		_, err := UploadAttachment(provider, session, InvoiceAttachments, "i-1", attachment, strings.NewReader("%PDF-1.4"))
+		a.EqualError(err, "Xero did not return the attachment receipt.pdf")
+	})
+}
@@ -1,12 +1,12 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -14,7 +14,7 @@ import (
 type BankTransaction struct {
 
 	// See Bank Transaction Types
-	Type string `json:"Type" xml:"Type"`
+	Type BankTransactionType `json:"Type" xml:"Type"`
 
 	// See Contacts
 	Contact Contact `json:"Contact" xml:"Contact"`
@@ -26,7 +26,7 @@ type BankTransaction struct {
 	IsReconciled bool `json:"IsReconciled,omitempty" xml:"IsReconciled,omitempty"`
 
 	// Date of transaction – YYYY-MM-DD
-	Date string `json:"DateString,omitempty" xml:"Date,omitempty"`
+	Date xerogolang.Date `json:"DateString,omitzero" xml:"Date,omitempty"`
 
 	// Reference for the transaction. Only supported for SPEND and RECEIVE transactions.
 	Reference string `json:"Reference,omitempty" xml:"Reference,omitempty"`
@@ -35,7 +35,7 @@ type BankTransaction struct {
 	CurrencyCode string `json:"CurrencyCode,omitempty" xml:"CurrencyCode,omitempty"`
 
 	// Exchange rate to base currency when money is spent or received. e.g. 0.7500 Only used for bank transactions in non base currency. If this isn’t specified for non base currency accounts then either the user-defined rate (preference) or the XE.com day rate will be used. Setting currency is only supported on overpayments.
-	CurrencyRate float32 `json:"CurrencyRate,omitempty" xml:"CurrencyRate,omitempty"`
+	CurrencyRate xerogolang.Decimal `json:"CurrencyRate,omitzero" xml:"CurrencyRate,omitempty"`
 
 	// URL link to a source document – shown as “Go to App Name”
 	URL string `json:"Url,omitempty" xml:"Url,omitempty"`
@@ -44,16 +44,16 @@ type BankTransaction struct {
 	Status string `json:"Status,omitempty" xml:"Status,omitempty"`
 
 	// Line amounts are exclusive of tax by default if you don’t specify this element. See Line Amount Types
-	LineAmountTypes string `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
+	LineAmountTypes LineAmountType `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
 
 	// Total of bank transaction excluding taxes
-	SubTotal float32 `json:"SubTotal,omitempty" xml:"SubTotal,omitempty"`
+	SubTotal xerogolang.Decimal `json:"SubTotal,omitzero" xml:"SubTotal,omitempty"`
 
 	// Total tax on bank transaction
-	TotalTax float32 `json:"TotalTax,omitempty" xml:"TotalTax,omitempty"`
+	TotalTax xerogolang.Decimal `json:"TotalTax,omitzero" xml:"TotalTax,omitempty"`
 
 	// Total of bank transaction tax inclusive
-	Total float32 `json:"Total,omitempty" xml:"Total,omitempty"`
+	Total xerogolang.Decimal `json:"Total,omitzero" xml:"Total,omitempty"`
 
 	// Xero generated unique identifier for bank transaction
 	BankTransactionID string `json:"BankTransactionID,omitempty" xml:"BankTransactionID,omitempty"`
@@ -68,10 +68,16 @@ type BankTransaction struct {
 	OverpaymentID string `json:"OverpaymentID,omitempty" xml:"-"`
 
 	// Last modified date UTC format
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"-"`
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"-"`
 
 	// Boolean to indicate if a bank transaction has an attachment
 	HasAttachments bool `json:"HasAttachments,omitempty" xml:"-"`
+
+	// OK or ERROR when the bank transaction was part of a batch sent with summarizeErrors=false
+	StatusAttributeString string `json:"StatusAttributeString,omitempty" xml:"-"`
+
+	// Why the bank transaction could not be saved when StatusAttributeString is ERROR
+	ValidationErrors []xerogolang.ValidationError `json:"ValidationErrors,omitempty" xml:"-"`
 }
 
 //BankTransactions contains a collection of BankTransactions
@@ -79,20 +85,6 @@ type BankTransactions struct {
 	BankTransactions []BankTransaction `json:"BankTransactions" xml:"BankTransaction"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (b *BankTransactions) convertDates() error {
-	var err error
-	for n := len(b.BankTransactions) - 1; n >= 0; n-- {
-		b.BankTransactions[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(b.BankTransactions[n].UpdatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalBankTransaction(bankTransactionResponseBytes []byte) (*BankTransactions, error) {
 	var bankTransactionResponse *BankTransactions
 	err := json.Unmarshal(bankTransactionResponseBytes, &bankTransactionResponse)
@@ -100,16 +92,42 @@ func unmarshalBankTransaction(bankTransactionResponseBytes []byte) (*BankTransac
 		return nil, err
 	}
 
-	err = bankTransactionResponse.convertDates()
-	if err != nil {
-		return nil, err
+	return bankTransactionResponse, err
+}
+
+//Validate checks the bank transactions against the rules Xero applies so mistakes are found before anything is sent
+func (b *BankTransactions) Validate() error {
+	v := &validator{}
+	for n := range b.BankTransactions {
+		b.BankTransactions[n].validate(v, index("BankTransactions", n))
 	}
+	return v.err()
+}
 
-	return bankTransactionResponse, err
+func (b *BankTransaction) validate(v *validator, field string) {
+	if b.BankTransactionID == "" {
+		v.required(field+".Type", string(b.Type))
+	}
+	v.code(field+".Type", string(b.Type), b.Type.IsValid())
+	v.code(field+".LineAmountTypes", string(b.LineAmountTypes), b.LineAmountTypes.IsValid())
+	b.Contact.validate(v, field+".Contact", b.BankTransactionID != "")
+	for n := range b.LineItems {
+		b.LineItems[n].validate(v, index(field+".LineItems", n), false)
+	}
 }
 
 //Create will create BankTransactions given an BankTransactions struct
 func (b *BankTransactions) Create(provider *xerogolang.Provider, session goth.Session) (*BankTransactions, error) {
+	return b.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (b *BankTransactions) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*BankTransactions, error) {
+	err := b.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -120,7 +138,7 @@ func (b *BankTransactions) Create(provider *xerogolang.Provider, session goth.Se
 		return nil, err
 	}
 
-	bankTransactionResponseBytes, err := provider.Create(session, "BankTransactions", additionalHeaders, body)
+	bankTransactionResponseBytes, err := provider.CreateCtx(ctx, session, "BankTransactions", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -131,6 +149,16 @@ func (b *BankTransactions) Create(provider *xerogolang.Provider, session goth.Se
 //Update will update a BankTransaction given a BankTransactions struct
 //This will only handle single BankTransaction - you cannot update multiple BankTransactions in a single call
 func (b *BankTransactions) Update(provider *xerogolang.Provider, session goth.Session) (*BankTransactions, error) {
+	return b.UpdateCtx(context.Background(), provider, session)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (b *BankTransactions) UpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*BankTransactions, error) {
+	err := b.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -141,7 +169,7 @@ func (b *BankTransactions) Update(provider *xerogolang.Provider, session goth.Se
 		return nil, err
 	}
 
-	bankTransactionResponseBytes, err := provider.Update(session, "BankTransactions/"+b.BankTransactions[0].BankTransactionID, additionalHeaders, body)
+	bankTransactionResponseBytes, err := provider.UpdateCtx(ctx, session, "BankTransactions/"+b.BankTransactions[0].BankTransactionID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -149,11 +177,83 @@ func (b *BankTransactions) Update(provider *xerogolang.Provider, session goth.Se
 	return unmarshalBankTransaction(bankTransactionResponseBytes)
 }
 
+//BankTransactionResult is what happened to one bank transaction in a batch
+type BankTransactionResult struct {
+	BatchResult
+
+	// The bank transaction as Xero saved it, or as it was sent if it was not saved
+	BankTransaction BankTransaction
+}
+
+//BatchCreate creates bank transactions in as many requests as needed, with summarizeErrors=false so that
+//every valid bank transaction is saved even if others are not. There is a result for each bank transaction, in the same order
+func (b *BankTransactions) BatchCreate(provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]BankTransactionResult, error) {
+	return b.BatchCreateCtx(context.Background(), provider, session, options)
+}
+
+//BatchCreateCtx is BatchCreate with a context that can cancel the requests or set their deadline
+func (b *BankTransactions) BatchCreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]BankTransactionResult, error) {
+	return b.batch(ctx, provider, session, false, options)
+}
+
+//BatchUpdate updates any number of bank transactions, unlike Update, in the same way as BatchCreate
+func (b *BankTransactions) BatchUpdate(provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]BankTransactionResult, error) {
+	return b.BatchUpdateCtx(context.Background(), provider, session, options)
+}
+
+//BatchUpdateCtx is BatchUpdate with a context that can cancel the requests or set their deadline
+func (b *BankTransactions) BatchUpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]BankTransactionResult, error) {
+	return b.batch(ctx, provider, session, true, options)
+}
+
+func (b *BankTransactions) batch(ctx context.Context, provider *xerogolang.Provider, session goth.Session, update bool, options BatchOptions) ([]BankTransactionResult, error) {
+	results := make([]BankTransactionResult, len(b.BankTransactions))
+	for n := range b.BankTransactions {
+		results[n].BankTransaction = b.BankTransactions[n]
+	}
+
+	sender := &batchSender{
+		collection: "BankTransactions",
+		count:      len(b.BankTransactions),
+		validate: func(v *validator, n int) {
+			b.BankTransactions[n].validate(v, index("BankTransactions", n))
+		},
+		marshal: func(n int) ([]byte, error) {
+			return xml.Marshal(b.BankTransactions[n])
+		},
+		receive: func(response []byte, indexes []int) ([]batchStatus, error) {
+			saved, err := unmarshalBankTransaction(response)
+			if err != nil {
+				return nil, err
+			}
+			statuses := make([]batchStatus, len(saved.BankTransactions))
+			for k, bankTransaction := range saved.BankTransactions {
+				if k < len(indexes) {
+					results[indexes[k]].BankTransaction = bankTransaction
+				}
+				statuses[k] = batchStatus{StatusAttributeString: bankTransaction.StatusAttributeString, ValidationErrors: bankTransaction.ValidationErrors}
+			}
+			return statuses, nil
+		},
+	}
+
+	batchResults, err := sender.send(ctx, provider, session, update, options)
+	for n := range results {
+		results[n].BatchResult = batchResults[n]
+	}
+	return results, err
+}
+
 //FindBankTransactionsModifiedSince will get all BankTransactions modified after a specified date.
 //These BankTransactions will not have details like default account codes and tracking categories by default.
 //If you need details then then add a 'page' querystringParameter and get 100 BankTransactions at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindBankTransactionsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*BankTransactions, error) {
+	return FindBankTransactionsModifiedSinceCtx(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindBankTransactionsModifiedSinceCtx is FindBankTransactionsModifiedSince with a context that can cancel the request or set its deadline
+func FindBankTransactionsModifiedSinceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*BankTransactions, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -162,7 +262,7 @@ func FindBankTransactionsModifiedSince(provider *xerogolang.Provider, session go
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	bankTransactionResponseBytes, err := provider.Find(session, "BankTransactions", additionalHeaders, querystringParameters)
+	bankTransactionResponseBytes, err := provider.FindCtx(ctx, session, "BankTransactions", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -174,16 +274,45 @@ func FindBankTransactionsModifiedSince(provider *xerogolang.Provider, session go
 //If you need details then then add a 'page' querystringParameter and get 100 BankTransactions at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindBankTransactions(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*BankTransactions, error) {
-	return FindBankTransactionsModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindBankTransactionsCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindBankTransactionsCtx is FindBankTransactions with a context that can cancel the request or set its deadline
+func FindBankTransactionsCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*BankTransactions, error) {
+	return FindBankTransactionsModifiedSinceCtx(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//EachBankTransaction calls fn with every bank transaction modified since modifiedSince, fetching them 100 at a time
+//until Xero returns an empty page. Pass a zero time.Time to get all bank transactions. where and order
+//querystringParameters are sent with every page. Return xerogolang.ErrStop from fn to stop early
+func EachBankTransaction(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string, fn func(bankTransaction BankTransaction) error) error {
+	return xerogolang.EachPage(ctx, querystringParameters, func(pageParameters map[string]string) (int, error) {
+		bankTransactions, err := FindBankTransactionsModifiedSinceCtx(ctx, provider, session, modifiedSince, pageParameters)
+		if err != nil {
+			return 0, err
+		}
+		for _, bankTransaction := range bankTransactions.BankTransactions {
+			err = fn(bankTransaction)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return len(bankTransactions.BankTransactions), nil
+	})
 }
 
 //FindBankTransaction will get a single BankTransaction - BankTransactionID can be a GUID for an BankTransaction or an BankTransaction number
 func FindBankTransaction(provider *xerogolang.Provider, session goth.Session, bankTransactionID string) (*BankTransactions, error) {
+	return FindBankTransactionCtx(context.Background(), provider, session, bankTransactionID)
+}
+
+//FindBankTransactionCtx is FindBankTransaction with a context that can cancel the request or set its deadline
+func FindBankTransactionCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, bankTransactionID string) (*BankTransactions, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	bankTransactionResponseBytes, err := provider.Find(session, "BankTransactions/"+bankTransactionID, additionalHeaders, nil)
+	bankTransactionResponseBytes, err := provider.FindCtx(ctx, session, "BankTransactions/"+bankTransactionID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -195,8 +324,8 @@ func FindBankTransaction(provider *xerogolang.Provider, session goth.Session, ba
 func GenerateExampleBankTransaction() *BankTransactions {
 	lineItem := LineItem{
 		Description: "Importing & Exporting Services",
-		Quantity:    1.00,
-		UnitAmount:  395.00,
+		Quantity:    xerogolang.MustParseDecimal("1.00"),
+		UnitAmount:  xerogolang.MustParseDecimal("395.00"),
 		AccountCode: "200",
 	}
 
@@ -205,11 +334,11 @@ func GenerateExampleBankTransaction() *BankTransactions {
 	}
 
 	bankTransaction := BankTransaction{
-		Type: "RECEIVE",
+		Type: BankTransactionTypeReceive,
 		Contact: Contact{
 			Name: "George Costanza",
 		},
-		Date:        helpers.TodayRFC3339(),
+		Date:        xerogolang.Today(),
 		LineItems:   []LineItem{},
 		BankAccount: bankAccount,
 	}
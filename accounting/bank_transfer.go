@@ -1,12 +1,12 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -14,16 +14,16 @@ import (
 type BankTransfer struct {
 
 	//
-	Amount float32 `json:"Amount" xml:"Amount"`
+	Amount xerogolang.Decimal `json:"Amount" xml:"Amount"`
 
 	// The date of the Transfer YYYY-MM-DD
-	Date string `json:"Date,omitempty" xml:"Date,omitempty"`
+	Date xerogolang.Date `json:"Date,omitzero" xml:"Date,omitempty"`
 
 	// The identifier of the Bank Transfer
 	BankTransferID string `json:"BankTransferID,omitempty" xml:"BankTransferID,omitempty"`
 
 	// The currency rate
-	CurrencyRate float32 `json:"CurrencyRate,omitempty" xml:"CurrencyRate,omitempty"`
+	CurrencyRate xerogolang.Decimal `json:"CurrencyRate,omitzero" xml:"CurrencyRate,omitempty"`
 
 	// The Bank Transaction ID for the source account
 	FromBankTransactionID string `json:"FromBankTransactionID,omitempty" xml:"FromBankTransactionID,omitempty"`
@@ -35,7 +35,7 @@ type BankTransfer struct {
 	HasAttachments bool `json:"HasAttachments,omitempty" xml:"HasAttachments,omitempty"`
 
 	// UTC timestamp of creation date of bank transfer
-	CreatedDateUTC string `json:"CreatedDateUTC,omitempty" xml:"CreatedDateUTC,omitempty"`
+	CreatedDateUTC xerogolang.DateTime `json:"CreatedDateUTC,omitzero" xml:"CreatedDateUTC,omitempty"`
 
 	// The source BankAccount
 	FromBankAccount BankAccount `json:"FromBankAccount,omitempty" xml:"FromBankAccount,omitempty"`
@@ -49,24 +49,6 @@ type BankTransfers struct {
 	BankTransfers []BankTransfer `json:"BankTransfers" xml:"BankTransfer"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (b *BankTransfers) convertDates() error {
-	var err error
-	for n := len(b.BankTransfers) - 1; n >= 0; n-- {
-		b.BankTransfers[n].Date, err = helpers.DotNetJSONTimeToRFC3339(b.BankTransfers[n].Date, false)
-		if err != nil {
-			return err
-		}
-		b.BankTransfers[n].CreatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(b.BankTransfers[n].CreatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalBankTransfer(bankTransferResponseBytes []byte) (*BankTransfers, error) {
 	var bankTransferResponse *BankTransfers
 	err := json.Unmarshal(bankTransferResponseBytes, &bankTransferResponse)
@@ -74,16 +56,16 @@ func unmarshalBankTransfer(bankTransferResponseBytes []byte) (*BankTransfers, er
 		return nil, err
 	}
 
-	err = bankTransferResponse.convertDates()
-	if err != nil {
-		return nil, err
-	}
-
 	return bankTransferResponse, err
 }
 
 //Create will create bankTransfers given a BankTransfers struct
 func (b *BankTransfers) Create(provider *xerogolang.Provider, session goth.Session) (*BankTransfers, error) {
+	return b.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (b *BankTransfers) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*BankTransfers, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -94,7 +76,7 @@ func (b *BankTransfers) Create(provider *xerogolang.Provider, session goth.Sessi
 		return nil, err
 	}
 
-	bankTransferResponseBytes, err := provider.Create(session, "BankTransfers", additionalHeaders, body)
+	bankTransferResponseBytes, err := provider.CreateCtx(ctx, session, "BankTransfers", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -107,6 +89,11 @@ func (b *BankTransfers) Create(provider *xerogolang.Provider, session goth.Sessi
 //If you need details then add a 'page' querystringParameter and get 100 BankTransfers at a time
 //additional querystringParameters such as where and order can be added as a map
 func FindBankTransfersModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*BankTransfers, error) {
+	return FindBankTransfersModifiedSinceCtx(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindBankTransfersModifiedSinceCtx is FindBankTransfersModifiedSince with a context that can cancel the request or set its deadline
+func FindBankTransfersModifiedSinceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*BankTransfers, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -115,7 +102,7 @@ func FindBankTransfersModifiedSince(provider *xerogolang.Provider, session goth.
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	bankTransferResponseBytes, err := provider.Find(session, "BankTransfers", additionalHeaders, querystringParameters)
+	bankTransferResponseBytes, err := provider.FindCtx(ctx, session, "BankTransfers", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -127,16 +114,26 @@ func FindBankTransfersModifiedSince(provider *xerogolang.Provider, session goth.
 //If you need details then add a 'page' querystringParameter and get 100 BankTransfers at a time
 //additional querystringParameters such as where and order can be added as a map
 func FindBankTransfers(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*BankTransfers, error) {
-	return FindBankTransfersModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindBankTransfersCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindBankTransfersCtx is FindBankTransfers with a context that can cancel the request or set its deadline
+func FindBankTransfersCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*BankTransfers, error) {
+	return FindBankTransfersModifiedSinceCtx(ctx, provider, session, dayZero, querystringParameters)
 }
 
 //FindBankTransfer will get a single bankTransfer - bankTransferID can be a GUID for an bankTransfer or an bankTransfer number
 func FindBankTransfer(provider *xerogolang.Provider, session goth.Session, bankTransferID string) (*BankTransfers, error) {
+	return FindBankTransferCtx(context.Background(), provider, session, bankTransferID)
+}
+
+//FindBankTransferCtx is FindBankTransfer with a context that can cancel the request or set its deadline
+func FindBankTransferCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, bankTransferID string) (*BankTransfers, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	bankTransferResponseBytes, err := provider.Find(session, "BankTransfers/"+bankTransferID, additionalHeaders, nil)
+	bankTransferResponseBytes, err := provider.FindCtx(ctx, session, "BankTransfers/"+bankTransferID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -153,7 +150,7 @@ func GenerateExampleBankTransfer() *BankTransfers {
 		ToBankAccount: BankAccount{
 			Code: "091",
 		},
-		Amount: 100.00,
+		Amount: xerogolang.MustParseDecimal("100.00"),
 	}
 
 	bankTransferCollection := &BankTransfers{
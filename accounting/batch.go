@@ -0,0 +1,175 @@
+package accounting
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/XeroAPI/xerogolang"
+	"github.com/markbates/goth"
+)
+
+const (
+	//defaultBatchElements is how many elements Xero suggests sending in one request
+	defaultBatchElements = 50
+
+	//defaultBatchBytes keeps each request under Xero's 3.5MB limit on the size of a request
+	defaultBatchBytes = 3500000
+
+	//statusAttributeError is the StatusAttributeString Xero gives an element it could not save
+	statusAttributeError = "ERROR"
+)
+
+//BatchOptions limits how much of a batch is sent in each request
+type BatchOptions struct {
+	// The most elements sent in one request. Defaults to 50
+	MaxElements int
+
+	// The largest request body in bytes. Defaults to just under Xero's 3.5MB limit
+	MaxBytes int
+}
+
+//BatchResult is what happened to one element of a batch
+type BatchResult struct {
+	// The position of the element in the collection
+	Index int
+
+	// The problems found by Validate before sending, or reported by Xero. Empty if the element was saved
+	ValidationErrors []xerogolang.ValidationError
+
+	// Set if the request carrying the element failed as a whole, for example because the connection dropped
+	Err error
+}
+
+//OK reports whether the element was saved
+func (r BatchResult) OK() bool {
+	return r.Err == nil && len(r.ValidationErrors) == 0
+}
+
+//batchStatus is how Xero reports on each element when summarizeErrors is false
+type batchStatus struct {
+	StatusAttributeString string
+	ValidationErrors      []xerogolang.ValidationError
+}
+
+//batchSender validates, chunks and sends the elements of a collection with summarizeErrors=false
+//so that Xero saves every valid element and reports on each of them
+type batchSender struct {
+	// The endpoint and root XML element e.g. Invoices
+	collection string
+
+	// The number of elements in the collection
+	count int
+
+	// Checks element n before it is sent
+	validate func(v *validator, n int)
+
+	// Returns element n as XML
+	marshal func(n int) ([]byte, error)
+
+	// Unmarshals a response to a chunk made of the elements at indexes and reports on each of them
+	receive func(response []byte, indexes []int) ([]batchStatus, error)
+}
+
+//send returns a result for every element along with the first error that stopped a chunk being sent
+func (b *batchSender) send(ctx context.Context, provider *xerogolang.Provider, session goth.Session, update bool, options BatchOptions) ([]BatchResult, error) {
+	maxElements := options.MaxElements
+	if maxElements <= 0 {
+		maxElements = defaultBatchElements
+	}
+	maxBytes := options.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultBatchBytes
+	}
+
+	results := make([]BatchResult, b.count)
+	var firstErr error
+	fail := func(indexes []int, err error) {
+		for _, n := range indexes {
+			results[n].Err = err
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	var indexes []int
+	var body bytes.Buffer
+	flush := func() {
+		if len(indexes) == 0 {
+			return
+		}
+		defer func() {
+			indexes = nil
+			body.Reset()
+		}()
+		if err := ctx.Err(); err != nil {
+			fail(indexes, err)
+			return
+		}
+
+		payload := []byte("<" + b.collection + ">" + body.String() + "</" + b.collection + ">")
+		additionalHeaders := map[string]string{
+			"Accept":       "application/json",
+			"Content-Type": "application/xml",
+		}
+		endpoint := b.collection + "?summarizeErrors=false"
+
+		var response []byte
+		var err error
+		if update {
+			response, err = provider.UpdateCtx(ctx, session, endpoint, additionalHeaders, payload)
+		} else {
+			response, err = provider.CreateCtx(ctx, session, endpoint, additionalHeaders, payload)
+		}
+		if err != nil {
+			fail(indexes, err)
+			return
+		}
+
+		statuses, err := b.receive(response, indexes)
+		if err != nil {
+			fail(indexes, err)
+			return
+		}
+		//Xero returns the elements in the order they were sent
+		for k, n := range indexes {
+			if k >= len(statuses) {
+				break
+			}
+			for _, validationError := range statuses[k].ValidationErrors {
+				validationError.Element = n
+				results[n].ValidationErrors = append(results[n].ValidationErrors, validationError)
+			}
+			if statuses[k].StatusAttributeString == statusAttributeError && len(results[n].ValidationErrors) == 0 {
+				results[n].ValidationErrors = []xerogolang.ValidationError{{Element: n, Message: "Xero could not save the element"}}
+			}
+		}
+	}
+
+	for n := 0; n < b.count; n++ {
+		results[n].Index = n
+
+		v := &validator{}
+		b.validate(v, n)
+		if len(v.errors) > 0 {
+			for _, fieldError := range v.errors {
+				results[n].ValidationErrors = append(results[n].ValidationErrors, xerogolang.ValidationError{Element: n, Message: fieldError.Error()})
+			}
+			continue
+		}
+
+		element, err := b.marshal(n)
+		if err != nil {
+			results[n].Err = err
+			continue
+		}
+		if len(indexes) == maxElements || (len(indexes) > 0 && body.Len()+len(element)+2*len(b.collection)+5 > maxBytes) {
+			flush()
+		}
+		indexes = append(indexes, n)
+		body.Write(element)
+	}
+	flush()
+
+	return results, firstErr
+}
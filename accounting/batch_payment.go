@@ -1,5 +1,6 @@
 package accounting
 
+//BatchPayment holds the bank details a contact is paid with in a batch payment (see PaymentBatch)
 type BatchPayment struct {
 
 	// A user defined bank account number.
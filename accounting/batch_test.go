@@ -0,0 +1,101 @@
+package accounting
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/XeroAPI/xerogolang"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Invoices_BatchCreate(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	invoice := func(reference string) Invoice {
+		return Invoice{Type: InvoiceTypeAccRec, Contact: Contact{ContactID: "c-1"}, Reference: reference}
+	}
+	invoices := &Invoices{Invoices: []Invoice{
+		invoice("A"),
+		{Contact: Contact{ContactID: "c-1"}, Reference: "invalid"},
+		invoice("B"),
+		invoice("C"),
+		invoice("D"),
+	}}
+
+	responses := []string{
+		`{"Invoices":[
+			{"InvoiceID":"i-a","Reference":"A","StatusAttributeString":"OK"},
+			{"Reference":"B","StatusAttributeString":"ERROR","ValidationErrors":[{"Message":"Contact is archived"}]}
+		]}`,
+		`{"Invoices":[
+			{"InvoiceID":"i-c","Reference":"C","StatusAttributeString":"OK"},
+			{"Reference":"D","StatusAttributeString":"ERROR"}
+		]}`,
+	}
+	mockAccounting(responses, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		results, err := invoices.BatchCreate(provider, session, BatchOptions{MaxElements: 2})
+		a.NoError(err)
+		a.Len(results, 5)
+
+		//the invalid invoice is left out so each chunk holds two of the others, in order
+		a.Len(*requests, 2)
+		a.True(strings.HasPrefix((*requests)[0], "PUT /Invoices?summarizeErrors=false <Invoices><Invoice>"), (*requests)[0])
+		a.Contains((*requests)[0], "<Reference>A</Reference>")
+		a.Contains((*requests)[0], "<Reference>B</Reference>")
+		a.Contains((*requests)[1], "<Reference>C</Reference>")
+		a.Contains((*requests)[1], "<Reference>D</Reference>")
+		a.NotContains(strings.Join(*requests, ""), "invalid")
+
+		//each status is reported against the invoice it came from, not its place in the chunk
+		for n, result := range results {
+			a.Equal(n, result.Index)
+			a.NoError(result.Err)
+		}
+		a.True(results[0].OK())
+		a.Equal("i-a", results[0].Invoice.InvoiceID)
+
+		a.False(results[1].OK())
+		a.Equal("invalid", results[1].Invoice.Reference)
+		a.Len(results[1].ValidationErrors, 1)
+		a.Equal(1, results[1].ValidationErrors[0].Element)
+		a.Contains(results[1].ValidationErrors[0].Message, "Invoices[1].Type")
+
+		a.False(results[2].OK())
+		a.Equal("B", results[2].Invoice.Reference)
+		a.Equal([]xerogolang.ValidationError{{Element: 2, Message: "Contact is archived"}}, results[2].ValidationErrors)
+
+		a.True(results[3].OK())
+		a.Equal("i-c", results[3].Invoice.InvoiceID)
+
+		a.False(results[4].OK())
+		a.Equal([]xerogolang.ValidationError{{Element: 4, Message: "Xero could not save the element"}}, results[4].ValidationErrors)
+	})
+}
+
+func Test_Invoices_BatchUpdate_MaxBytes(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	invoices := &Invoices{Invoices: []Invoice{
+		{InvoiceID: "i-1", Contact: Contact{ContactID: "c-1"}, Reference: strings.Repeat("x", 200)},
+		{InvoiceID: "i-2", Contact: Contact{ContactID: "c-1"}, Reference: strings.Repeat("y", 200)},
+	}}
+
+	responses := []string{
+		`{"Invoices":[{"InvoiceID":"i-1","StatusAttributeString":"OK"}]}`,
+		`{"Invoices":[{"InvoiceID":"i-2","StatusAttributeString":"OK"}]}`,
+	}
+	mockAccounting(responses, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		//both invoices do not fit in one request so each is sent on its own
+		results, err := invoices.BatchUpdate(provider, session, BatchOptions{MaxBytes: 500})
+		a.NoError(err)
+		a.True(results[0].OK())
+		a.True(results[1].OK())
+
+		a.Len(*requests, 2)
+		a.True(strings.HasPrefix((*requests)[0], "POST /Invoices?summarizeErrors=false <Invoices><Invoice>"), (*requests)[0])
+		a.Contains((*requests)[0], "<InvoiceID>i-1</InvoiceID>")
+		a.Contains((*requests)[1], "<InvoiceID>i-2</InvoiceID>")
+	})
+}
@@ -1,12 +1,12 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -92,7 +92,7 @@ type Contact struct {
 	TrackingCategoryOption string `json:"TrackingCategoryOption,omitempty" xml:"TrackingCategoryOption,omitempty"`
 
 	// UTC timestamp of last update to contact
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"-"`
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"-"`
 
 	// Displays which contact groups a contact is included in
 	ContactGroups *[]ContactGroup `json:"ContactGroups,omitempty" xml:"ContactGroups>ContactGroup,omitempty"`
@@ -104,13 +104,19 @@ type Contact struct {
 	BatchPayments BatchPayment `json:"BatchPayments,omitempty" xml:"-"`
 
 	// The default discount rate for the contact (read only)
-	Discount float32 `json:"Discount,omitempty" xml:"-"`
+	Discount xerogolang.Decimal `json:"Discount,omitzero" xml:"-"`
 
 	// The raw AccountsReceivable(sales Contacts) and AccountsPayable(bills) outstanding and overdue amounts, not converted to base currency (read only)
 	Balances Balances `json:"Balances,omitempty" xml:"-"`
 
 	// A boolean to indicate if a contact has an attachment
 	HasAttachments bool `json:"HasAttachments,omitempty" xml:"HasAttachments,omitempty"`
+
+	// OK or ERROR when the contact was part of a batch sent with summarizeErrors=false
+	StatusAttributeString string `json:"StatusAttributeString,omitempty" xml:"-"`
+
+	// Why the contact could not be saved when StatusAttributeString is ERROR
+	ValidationErrors []xerogolang.ValidationError `json:"ValidationErrors,omitempty" xml:"-"`
 }
 
 //Contacts contains a collection of Contacts
@@ -128,22 +134,8 @@ type Balances struct {
 //Balance is the raw AccountsReceivable(sales invoices) and AccountsPayable(bills)
 //outstanding and overdue amounts, not converted to base currency
 type Balance struct {
-	Outstanding float32 `json:"Oustanding,omitempty" xml:"Oustanding,omitempty"`
-	Overdue     float32 `json:"Overdue,omitempty" xml:"Overdue,omitempty"`
-}
-
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (c *Contacts) convertDates() error {
-	var err error
-	for n := len(c.Contacts) - 1; n >= 0; n-- {
-		c.Contacts[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(c.Contacts[n].UpdatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
+	Outstanding xerogolang.Decimal `json:"Oustanding,omitzero" xml:"Oustanding,omitempty"`
+	Overdue     xerogolang.Decimal `json:"Overdue,omitzero" xml:"Overdue,omitempty"`
 }
 
 func unmarshalContact(contactResponseBytes []byte) (*Contacts, error) {
@@ -153,16 +145,56 @@ func unmarshalContact(contactResponseBytes []byte) (*Contacts, error) {
 		return nil, err
 	}
 
-	err = contactResponse.convertDates()
-	if err != nil {
-		return nil, err
+	return contactResponse, err
+}
+
+//Validate checks the contacts against the rules Xero applies so mistakes are found before anything is sent
+func (c *Contacts) Validate() error {
+	v := &validator{}
+	for n := range c.Contacts {
+		c.Contacts[n].validate(v, index("Contacts", n), false)
 	}
+	return v.err()
+}
 
-	return contactResponse, err
+//validate checks a contact, which may be a new contact or a reference to an existing one by ContactID
+//or ContactNumber. Only a new contact needs a Name, and the contact of a document that is already in
+//Xero, which existing reports, can be left out of an update altogether
+func (c *Contact) validate(v *validator, field string, existing bool) {
+	if !existing && c.ContactID == "" && c.ContactNumber == "" {
+		v.required(field+".Name", c.Name)
+	}
+	v.maxLength(field+".ContactNumber", c.ContactNumber, 50)
+	v.maxLength(field+".AccountNumber", c.AccountNumber, 50)
+	v.maxLength(field+".Name", c.Name, 255)
+	v.maxLength(field+".FirstName", c.FirstName, 255)
+	v.maxLength(field+".LastName", c.LastName, 255)
+	v.maxLength(field+".EmailAddress", c.EmailAddress, 255)
+	v.maxLength(field+".TaxNumber", c.TaxNumber, 50)
+	if c.Addresses != nil {
+		for n := range *c.Addresses {
+			(*c.Addresses)[n].validate(v, index(field+".Addresses", n))
+		}
+	}
+	if c.Phones != nil {
+		for n := range *c.Phones {
+			(*c.Phones)[n].validate(v, index(field+".Phones", n))
+		}
+	}
 }
 
 //Create will create Contacts given an Contacts struct
 func (c *Contacts) Create(provider *xerogolang.Provider, session goth.Session) (*Contacts, error) {
+	return c.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (c *Contacts) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Contacts, error) {
+	err := c.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -173,7 +205,7 @@ func (c *Contacts) Create(provider *xerogolang.Provider, session goth.Session) (
 		return nil, err
 	}
 
-	contactResponseBytes, err := provider.Create(session, "Contacts", additionalHeaders, body)
+	contactResponseBytes, err := provider.CreateCtx(ctx, session, "Contacts", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -184,6 +216,16 @@ func (c *Contacts) Create(provider *xerogolang.Provider, session goth.Session) (
 //Update will update a Contact given a Contacts struct
 //This will only handle single Contact - you cannot update multiple Contacts in a single call
 func (c *Contacts) Update(provider *xerogolang.Provider, session goth.Session) (*Contacts, error) {
+	return c.UpdateCtx(context.Background(), provider, session)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (c *Contacts) UpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Contacts, error) {
+	err := c.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -194,7 +236,7 @@ func (c *Contacts) Update(provider *xerogolang.Provider, session goth.Session) (
 		return nil, err
 	}
 
-	contactResponseBytes, err := provider.Update(session, "Contacts/"+c.Contacts[0].ContactID, additionalHeaders, body)
+	contactResponseBytes, err := provider.UpdateCtx(ctx, session, "Contacts/"+c.Contacts[0].ContactID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -202,11 +244,83 @@ func (c *Contacts) Update(provider *xerogolang.Provider, session goth.Session) (
 	return unmarshalContact(contactResponseBytes)
 }
 
+//ContactResult is what happened to one contact in a batch
+type ContactResult struct {
+	BatchResult
+
+	// The contact as Xero saved it, or as it was sent if it was not saved
+	Contact Contact
+}
+
+//BatchCreate creates contacts in as many requests as needed, with summarizeErrors=false so that
+//every valid contact is saved even if others are not. There is a result for each contact, in the same order
+func (c *Contacts) BatchCreate(provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]ContactResult, error) {
+	return c.BatchCreateCtx(context.Background(), provider, session, options)
+}
+
+//BatchCreateCtx is BatchCreate with a context that can cancel the requests or set their deadline
+func (c *Contacts) BatchCreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]ContactResult, error) {
+	return c.batch(ctx, provider, session, false, options)
+}
+
+//BatchUpdate updates any number of contacts, unlike Update, in the same way as BatchCreate
+func (c *Contacts) BatchUpdate(provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]ContactResult, error) {
+	return c.BatchUpdateCtx(context.Background(), provider, session, options)
+}
+
+//BatchUpdateCtx is BatchUpdate with a context that can cancel the requests or set their deadline
+func (c *Contacts) BatchUpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]ContactResult, error) {
+	return c.batch(ctx, provider, session, true, options)
+}
+
+func (c *Contacts) batch(ctx context.Context, provider *xerogolang.Provider, session goth.Session, update bool, options BatchOptions) ([]ContactResult, error) {
+	results := make([]ContactResult, len(c.Contacts))
+	for n := range c.Contacts {
+		results[n].Contact = c.Contacts[n]
+	}
+
+	sender := &batchSender{
+		collection: "Contacts",
+		count:      len(c.Contacts),
+		validate: func(v *validator, n int) {
+			c.Contacts[n].validate(v, index("Contacts", n), false)
+		},
+		marshal: func(n int) ([]byte, error) {
+			return xml.Marshal(c.Contacts[n])
+		},
+		receive: func(response []byte, indexes []int) ([]batchStatus, error) {
+			saved, err := unmarshalContact(response)
+			if err != nil {
+				return nil, err
+			}
+			statuses := make([]batchStatus, len(saved.Contacts))
+			for k, contact := range saved.Contacts {
+				if k < len(indexes) {
+					results[indexes[k]].Contact = contact
+				}
+				statuses[k] = batchStatus{StatusAttributeString: contact.StatusAttributeString, ValidationErrors: contact.ValidationErrors}
+			}
+			return statuses, nil
+		},
+	}
+
+	batchResults, err := sender.send(ctx, provider, session, update, options)
+	for n := range results {
+		results[n].BatchResult = batchResults[n]
+	}
+	return results, err
+}
+
 //FindContactsModifiedSince will get all Contacts modified after a specified date.
 //These Contacts will not have details like default account codes and tracking categories.
 //If you need details then then add a 'page' querystringParameter and get 100 Contacts at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindContactsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Contacts, error) {
+	return FindContactsModifiedSinceCtx(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindContactsModifiedSinceCtx is FindContactsModifiedSince with a context that can cancel the request or set its deadline
+func FindContactsModifiedSinceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Contacts, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -215,7 +329,7 @@ func FindContactsModifiedSince(provider *xerogolang.Provider, session goth.Sessi
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	contactResponseBytes, err := provider.Find(session, "Contacts", additionalHeaders, querystringParameters)
+	contactResponseBytes, err := provider.FindCtx(ctx, session, "Contacts", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -227,16 +341,45 @@ func FindContactsModifiedSince(provider *xerogolang.Provider, session goth.Sessi
 //If you need details then then add a 'page' querystringParameter and get 100 Contacts at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindContacts(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Contacts, error) {
-	return FindContactsModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindContactsCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindContactsCtx is FindContacts with a context that can cancel the request or set its deadline
+func FindContactsCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Contacts, error) {
+	return FindContactsModifiedSinceCtx(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//EachContact calls fn with every contact modified since modifiedSince, fetching them 100 at a time
+//until Xero returns an empty page. Pass a zero time.Time to get all contacts. where and order
+//querystringParameters are sent with every page. Return xerogolang.ErrStop from fn to stop early
+func EachContact(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string, fn func(contact Contact) error) error {
+	return xerogolang.EachPage(ctx, querystringParameters, func(pageParameters map[string]string) (int, error) {
+		contacts, err := FindContactsModifiedSinceCtx(ctx, provider, session, modifiedSince, pageParameters)
+		if err != nil {
+			return 0, err
+		}
+		for _, contact := range contacts.Contacts {
+			err = fn(contact)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return len(contacts.Contacts), nil
+	})
 }
 
 //FindContact will get a single Contact - ContactID can be a GUID for an Contact or an Contact number
 func FindContact(provider *xerogolang.Provider, session goth.Session, contactID string) (*Contacts, error) {
+	return FindContactCtx(context.Background(), provider, session, contactID)
+}
+
+//FindContactCtx is FindContact with a context that can cancel the request or set its deadline
+func FindContactCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, contactID string) (*Contacts, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	contactResponseBytes, err := provider.Find(session, "Contacts/"+contactID, additionalHeaders, nil)
+	contactResponseBytes, err := provider.FindCtx(ctx, session, "Contacts/"+contactID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -246,6 +389,11 @@ func FindContact(provider *xerogolang.Provider, session goth.Session, contactID
 
 //AddToContactGroup will add a collection of Contacts to a supplied contactGroupID
 func (c *Contacts) AddToContactGroup(provider *xerogolang.Provider, session goth.Session, contactGroupID string) (*Contacts, error) {
+	return c.AddToContactGroupCtx(context.Background(), provider, session, contactGroupID)
+}
+
+//AddToContactGroupCtx is AddToContactGroup with a context that can cancel the request or set its deadline
+func (c *Contacts) AddToContactGroupCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, contactGroupID string) (*Contacts, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -265,7 +413,7 @@ func (c *Contacts) AddToContactGroup(provider *xerogolang.Provider, session goth
 		return nil, err
 	}
 
-	contactResponseBytes, err := provider.Update(session, "ContactGroups/"+contactGroupID+"/Contacts", additionalHeaders, body)
+	contactResponseBytes, err := provider.UpdateCtx(ctx, session, "ContactGroups/"+contactGroupID+"/Contacts", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -275,11 +423,16 @@ func (c *Contacts) AddToContactGroup(provider *xerogolang.Provider, session goth
 
 //RemoveFromContactGroup will remove a Contact from a supplied contactGroupID - must be done one at a time.
 func (c *Contacts) RemoveFromContactGroup(provider *xerogolang.Provider, session goth.Session, contactGroupID string) (*Contacts, error) {
+	return c.RemoveFromContactGroupCtx(context.Background(), provider, session, contactGroupID)
+}
+
+//RemoveFromContactGroupCtx is RemoveFromContactGroup with a context that can cancel the request or set its deadline
+func (c *Contacts) RemoveFromContactGroupCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, contactGroupID string) (*Contacts, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	contactResponseBytes, err := provider.Remove(session, "ContactGroups/"+contactGroupID+"/Contacts/"+c.Contacts[0].ContactID, additionalHeaders)
+	contactResponseBytes, err := provider.RemoveCtx(ctx, session, "ContactGroups/"+contactGroupID+"/Contacts/"+c.Contacts[0].ContactID, additionalHeaders)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 
@@ -41,6 +42,11 @@ func unmarshalContactGroup(contactGroupResponseBytes []byte) (*ContactGroups, er
 
 //Create will create contactGroups given an ContactGroups struct
 func (c *ContactGroups) Create(provider *xerogolang.Provider, session goth.Session) (*ContactGroups, error) {
+	return c.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (c *ContactGroups) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*ContactGroups, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -51,7 +57,7 @@ func (c *ContactGroups) Create(provider *xerogolang.Provider, session goth.Sessi
 		return nil, err
 	}
 
-	contactGroupResponseBytes, err := provider.Create(session, "ContactGroups", additionalHeaders, body)
+	contactGroupResponseBytes, err := provider.CreateCtx(ctx, session, "ContactGroups", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -62,6 +68,11 @@ func (c *ContactGroups) Create(provider *xerogolang.Provider, session goth.Sessi
 //Update will update an contactGroup given an ContactGroups struct
 //This will only handle single contactGroup - you cannot update multiple contactGroups in a single call
 func (c *ContactGroups) Update(provider *xerogolang.Provider, session goth.Session) (*ContactGroups, error) {
+	return c.UpdateCtx(context.Background(), provider, session)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (c *ContactGroups) UpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*ContactGroups, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -72,7 +83,7 @@ func (c *ContactGroups) Update(provider *xerogolang.Provider, session goth.Sessi
 		return nil, err
 	}
 
-	contactGroupResponseBytes, err := provider.Update(session, "ContactGroups/"+c.ContactGroups[0].ContactGroupID, additionalHeaders, body)
+	contactGroupResponseBytes, err := provider.UpdateCtx(ctx, session, "ContactGroups/"+c.ContactGroups[0].ContactGroupID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -82,11 +93,16 @@ func (c *ContactGroups) Update(provider *xerogolang.Provider, session goth.Sessi
 
 //FindContactGroups will get all contactGroups
 func FindContactGroups(provider *xerogolang.Provider, session goth.Session) (*ContactGroups, error) {
+	return FindContactGroupsCtx(context.Background(), provider, session)
+}
+
+//FindContactGroupsCtx is FindContactGroups with a context that can cancel the request or set its deadline
+func FindContactGroupsCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*ContactGroups, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	contactGroupResponseBytes, err := provider.Find(session, "ContactGroups", additionalHeaders, nil)
+	contactGroupResponseBytes, err := provider.FindCtx(ctx, session, "ContactGroups", additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -96,11 +112,16 @@ func FindContactGroups(provider *xerogolang.Provider, session goth.Session) (*Co
 
 //FindContactGroup will get a single contactGroup - contactGroupID must be a GUID for an contactGroup
 func FindContactGroup(provider *xerogolang.Provider, session goth.Session, contactGroupID string) (*ContactGroups, error) {
+	return FindContactGroupCtx(context.Background(), provider, session, contactGroupID)
+}
+
+//FindContactGroupCtx is FindContactGroup with a context that can cancel the request or set its deadline
+func FindContactGroupCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, contactGroupID string) (*ContactGroups, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	contactGroupResponseBytes, err := provider.Find(session, "ContactGroups/"+contactGroupID, additionalHeaders, nil)
+	contactGroupResponseBytes, err := provider.FindCtx(ctx, session, "ContactGroups/"+contactGroupID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -110,11 +131,16 @@ func FindContactGroup(provider *xerogolang.Provider, session goth.Session, conta
 
 //RemoveContactGroup will get a single contactGroup - contactGroupID must be a GUID for an contactGroup
 func RemoveContactGroup(provider *xerogolang.Provider, session goth.Session, contactGroupID string) (*ContactGroups, error) {
+	return RemoveContactGroupCtx(context.Background(), provider, session, contactGroupID)
+}
+
+//RemoveContactGroupCtx is RemoveContactGroup with a context that can cancel the request or set its deadline
+func RemoveContactGroupCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, contactGroupID string) (*ContactGroups, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	contactGroupResponseBytes, err := provider.Remove(session, "ContactGroups/"+contactGroupID, additionalHeaders)
+	contactGroupResponseBytes, err := provider.RemoveCtx(ctx, session, "ContactGroups/"+contactGroupID, additionalHeaders)
 	if err != nil {
 		return nil, err
 	}
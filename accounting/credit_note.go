@@ -1,12 +1,13 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
+	"io"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -23,34 +24,34 @@ type CreditNote struct {
 	// The date the credit note is issued YYYY-MM-DD.
 	// If the Date element is not specified then it will default
 	// to the current date based on the timezone setting of the organisation
-	Date string `json:"DateString,omitempty" xml:"Date,omitempty"`
+	Date xerogolang.Date `json:"DateString,omitzero" xml:"Date,omitempty"`
 
 	// See Credit Note Status Codes
 	Status string `json:"Status,omitempty" xml:"Status,omitempty"`
 
 	// See Invoice Line Amount Types
-	LineAmountTypes string `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
+	LineAmountTypes LineAmountType `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
 
 	// See Invoice Line Items
 	LineItems []LineItem `json:"LineItems,omitempty" xml:"LineItems>LineItem,omitempty"`
 
 	// The subtotal of the credit note excluding taxes
-	SubTotal float32 `json:"SubTotal,omitempty" xml:"SubTotal,omitempty"`
+	SubTotal xerogolang.Decimal `json:"SubTotal,omitzero" xml:"SubTotal,omitempty"`
 
 	// The total tax on the credit note
-	TotalTax float32 `json:"TotalTax,omitempty" xml:"TotalTax,omitempty"`
+	TotalTax xerogolang.Decimal `json:"TotalTax,omitzero" xml:"TotalTax,omitempty"`
 
 	// The total of the Credit Note(subtotal + total tax)
-	Total float32 `json:"Total,omitempty" xml:"Total,omitempty"`
+	Total xerogolang.Decimal `json:"Total,omitzero" xml:"Total,omitempty"`
 
 	// UTC timestamp of last update to the credit note
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"-"`
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"-"`
 
 	// Currency used for the Credit Note
 	CurrencyCode string `json:"CurrencyCode,omitempty" xml:"CurrencyCode,omitempty"`
 
 	// Date when credit note was fully paid(UTC format)
-	FullyPaidOnDate string `json:"FullyPaidOnDate,omitempty" xml:"-"`
+	FullyPaidOnDate xerogolang.Date `json:"FullyPaidOnDate,omitzero" xml:"-"`
 
 	// Xero generated unique identifier
 	CreditNoteID string `json:"CreditNoteID,omitempty" xml:"CreditNoteID,omitempty"`
@@ -65,10 +66,10 @@ type CreditNote struct {
 	SentToContact bool `json:"SentToContact,omitempty" xml:"SentToContact,omitempty"`
 
 	// The currency rate for a multicurrency invoice. If no rate is specified, the XE.com day rate is used
-	CurrencyRate float32 `json:"CurrencyRate,omitempty" xml:"CurrencyRate,omitempty"`
+	CurrencyRate xerogolang.Decimal `json:"CurrencyRate,omitzero" xml:"CurrencyRate,omitempty"`
 
 	// The remaining credit balance on the Credit Note
-	RemainingCredit float32 `json:"RemainingCredit,omitempty" xml:"-"`
+	RemainingCredit xerogolang.Decimal `json:"RemainingCredit,omitzero" xml:"-"`
 
 	// See Allocations
 	Allocations *[]Allocation `json:"Allocations,omitempty" xml:"-"`
@@ -78,6 +79,12 @@ type CreditNote struct {
 
 	// boolean to indicate if a credit note has an attachment
 	HasAttachments bool `json:"HasAttachments,omitempty" xml:"-"`
+
+	// OK or ERROR when the credit note was part of a batch sent with summarizeErrors=false
+	StatusAttributeString string `json:"StatusAttributeString,omitempty" xml:"-"`
+
+	// Why the credit note could not be saved when StatusAttributeString is ERROR
+	ValidationErrors []xerogolang.ValidationError `json:"ValidationErrors,omitempty" xml:"-"`
 }
 
 //CreditNotes is a collection of CreditNote
@@ -85,20 +92,6 @@ type CreditNotes struct {
 	CreditNotes []CreditNote `json:"CreditNotes" xml:"CreditNote"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (c *CreditNotes) convertDates() error {
-	var err error
-	for n := len(c.CreditNotes) - 1; n >= 0; n-- {
-		c.CreditNotes[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(c.CreditNotes[n].UpdatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalCreditNote(creditNoteResponseBytes []byte) (*CreditNotes, error) {
 	var creditNoteResponse *CreditNotes
 	err := json.Unmarshal(creditNoteResponseBytes, &creditNoteResponse)
@@ -106,16 +99,38 @@ func unmarshalCreditNote(creditNoteResponseBytes []byte) (*CreditNotes, error) {
 		return nil, err
 	}
 
-	err = creditNoteResponse.convertDates()
-	if err != nil {
-		return nil, err
+	return creditNoteResponse, err
+}
+
+//Validate checks the credit notes against the rules Xero applies so mistakes are found before anything is sent
+func (c *CreditNotes) Validate() error {
+	v := &validator{}
+	for n := range c.CreditNotes {
+		c.CreditNotes[n].validate(v, index("CreditNotes", n))
 	}
+	return v.err()
+}
 
-	return creditNoteResponse, err
+func (c *CreditNote) validate(v *validator, field string) {
+	v.code(field+".LineAmountTypes", string(c.LineAmountTypes), c.LineAmountTypes.IsValid())
+	c.Contact.validate(v, field+".Contact", c.CreditNoteID != "")
+	for n := range c.LineItems {
+		c.LineItems[n].validate(v, index(field+".LineItems", n), false)
+	}
 }
 
 //Create will create creditNotes given an CreditNotes struct
 func (c *CreditNotes) Create(provider *xerogolang.Provider, session goth.Session) (*CreditNotes, error) {
+	return c.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (c *CreditNotes) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*CreditNotes, error) {
+	err := c.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -126,7 +141,7 @@ func (c *CreditNotes) Create(provider *xerogolang.Provider, session goth.Session
 		return nil, err
 	}
 
-	creditNoteResponseBytes, err := provider.Create(session, "CreditNotes", additionalHeaders, body)
+	creditNoteResponseBytes, err := provider.CreateCtx(ctx, session, "CreditNotes", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -137,6 +152,16 @@ func (c *CreditNotes) Create(provider *xerogolang.Provider, session goth.Session
 //Update will update an creditNote given an CreditNotes struct
 //This will only handle single creditNote - you cannot update multiple creditNotes in a single call
 func (c *CreditNotes) Update(provider *xerogolang.Provider, session goth.Session) (*CreditNotes, error) {
+	return c.UpdateCtx(context.Background(), provider, session)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (c *CreditNotes) UpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*CreditNotes, error) {
+	err := c.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -147,7 +172,7 @@ func (c *CreditNotes) Update(provider *xerogolang.Provider, session goth.Session
 		return nil, err
 	}
 
-	creditNoteResponseBytes, err := provider.Update(session, "CreditNotes/"+c.CreditNotes[0].CreditNoteID, additionalHeaders, body)
+	creditNoteResponseBytes, err := provider.UpdateCtx(ctx, session, "CreditNotes/"+c.CreditNotes[0].CreditNoteID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -155,11 +180,83 @@ func (c *CreditNotes) Update(provider *xerogolang.Provider, session goth.Session
 	return unmarshalCreditNote(creditNoteResponseBytes)
 }
 
+//CreditNoteResult is what happened to one credit note in a batch
+type CreditNoteResult struct {
+	BatchResult
+
+	// The credit note as Xero saved it, or as it was sent if it was not saved
+	CreditNote CreditNote
+}
+
+//BatchCreate creates credit notes in as many requests as needed, with summarizeErrors=false so that
+//every valid credit note is saved even if others are not. There is a result for each credit note, in the same order
+func (c *CreditNotes) BatchCreate(provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]CreditNoteResult, error) {
+	return c.BatchCreateCtx(context.Background(), provider, session, options)
+}
+
+//BatchCreateCtx is BatchCreate with a context that can cancel the requests or set their deadline
+func (c *CreditNotes) BatchCreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]CreditNoteResult, error) {
+	return c.batch(ctx, provider, session, false, options)
+}
+
+//BatchUpdate updates any number of credit notes, unlike Update, in the same way as BatchCreate
+func (c *CreditNotes) BatchUpdate(provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]CreditNoteResult, error) {
+	return c.BatchUpdateCtx(context.Background(), provider, session, options)
+}
+
+//BatchUpdateCtx is BatchUpdate with a context that can cancel the requests or set their deadline
+func (c *CreditNotes) BatchUpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]CreditNoteResult, error) {
+	return c.batch(ctx, provider, session, true, options)
+}
+
+func (c *CreditNotes) batch(ctx context.Context, provider *xerogolang.Provider, session goth.Session, update bool, options BatchOptions) ([]CreditNoteResult, error) {
+	results := make([]CreditNoteResult, len(c.CreditNotes))
+	for n := range c.CreditNotes {
+		results[n].CreditNote = c.CreditNotes[n]
+	}
+
+	sender := &batchSender{
+		collection: "CreditNotes",
+		count:      len(c.CreditNotes),
+		validate: func(v *validator, n int) {
+			c.CreditNotes[n].validate(v, index("CreditNotes", n))
+		},
+		marshal: func(n int) ([]byte, error) {
+			return xml.Marshal(c.CreditNotes[n])
+		},
+		receive: func(response []byte, indexes []int) ([]batchStatus, error) {
+			saved, err := unmarshalCreditNote(response)
+			if err != nil {
+				return nil, err
+			}
+			statuses := make([]batchStatus, len(saved.CreditNotes))
+			for k, creditNote := range saved.CreditNotes {
+				if k < len(indexes) {
+					results[indexes[k]].CreditNote = creditNote
+				}
+				statuses[k] = batchStatus{StatusAttributeString: creditNote.StatusAttributeString, ValidationErrors: creditNote.ValidationErrors}
+			}
+			return statuses, nil
+		},
+	}
+
+	batchResults, err := sender.send(ctx, provider, session, update, options)
+	for n := range results {
+		results[n].BatchResult = batchResults[n]
+	}
+	return results, err
+}
+
 //FindCreditNotesModifiedSince will get all Credit Notes modified after a specified date.
 //These Credit Notes will not have details like line items by default.
 //If you need details then then add a 'page' querystringParameter and get 100 Credit Notes at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindCreditNotesModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*CreditNotes, error) {
+	return FindCreditNotesModifiedSinceCtx(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindCreditNotesModifiedSinceCtx is FindCreditNotesModifiedSince with a context that can cancel the request or set its deadline
+func FindCreditNotesModifiedSinceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*CreditNotes, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -168,7 +265,7 @@ func FindCreditNotesModifiedSince(provider *xerogolang.Provider, session goth.Se
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	creditNoteResponseBytes, err := provider.Find(session, "CreditNotes", additionalHeaders, querystringParameters)
+	creditNoteResponseBytes, err := provider.FindCtx(ctx, session, "CreditNotes", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -180,16 +277,45 @@ func FindCreditNotesModifiedSince(provider *xerogolang.Provider, session goth.Se
 //If you need details then then add a 'page' querystringParameter and get 100 Credit Notes at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindCreditNotes(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*CreditNotes, error) {
-	return FindCreditNotesModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindCreditNotesCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindCreditNotesCtx is FindCreditNotes with a context that can cancel the request or set its deadline
+func FindCreditNotesCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*CreditNotes, error) {
+	return FindCreditNotesModifiedSinceCtx(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//EachCreditNote calls fn with every credit note modified since modifiedSince, fetching them 100 at a time
+//until Xero returns an empty page. Pass a zero time.Time to get all credit notes. where and order
+//querystringParameters are sent with every page. Return xerogolang.ErrStop from fn to stop early
+func EachCreditNote(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string, fn func(creditNote CreditNote) error) error {
+	return xerogolang.EachPage(ctx, querystringParameters, func(pageParameters map[string]string) (int, error) {
+		creditNotes, err := FindCreditNotesModifiedSinceCtx(ctx, provider, session, modifiedSince, pageParameters)
+		if err != nil {
+			return 0, err
+		}
+		for _, creditNote := range creditNotes.CreditNotes {
+			err = fn(creditNote)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return len(creditNotes.CreditNotes), nil
+	})
 }
 
 //FindCreditNote will get a single creditNote - creditNoteID can be a GUID for a creditNote or a creditNote number
 func FindCreditNote(provider *xerogolang.Provider, session goth.Session, creditNoteID string) (*CreditNotes, error) {
+	return FindCreditNoteCtx(context.Background(), provider, session, creditNoteID)
+}
+
+//FindCreditNoteCtx is FindCreditNote with a context that can cancel the request or set its deadline
+func FindCreditNoteCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, creditNoteID string) (*CreditNotes, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	creditNoteResponseBytes, err := provider.Find(session, "CreditNotes/"+creditNoteID, additionalHeaders, nil)
+	creditNoteResponseBytes, err := provider.FindCtx(ctx, session, "CreditNotes/"+creditNoteID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -197,12 +323,27 @@ func FindCreditNote(provider *xerogolang.Provider, session goth.Session, creditN
 	return unmarshalCreditNote(creditNoteResponseBytes)
 }
 
+//FindCreditNotePDF will get a credit note rendered as a PDF, as Xero would print or email it. The PDF is
+//streamed from Xero as it is read so the caller must close it
+func FindCreditNotePDF(provider *xerogolang.Provider, session goth.Session, creditNoteID string) (io.ReadCloser, error) {
+	return FindCreditNotePDFCtx(context.Background(), provider, session, creditNoteID)
+}
+
+//FindCreditNotePDFCtx is FindCreditNotePDF with a context that can cancel the request or set its deadline
+func FindCreditNotePDFCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, creditNoteID string) (io.ReadCloser, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/pdf",
+	}
+
+	return provider.DownloadCtx(ctx, session, "CreditNotes/"+creditNoteID, additionalHeaders, nil)
+}
+
 //GenerateExampleCreditNote Creates an Example creditNote
 func GenerateExampleCreditNote() *CreditNotes {
 	lineItem := LineItem{
 		Description: "Refund Importing & Exporting Services",
-		Quantity:    1.00,
-		UnitAmount:  395.00,
+		Quantity:    xerogolang.MustParseDecimal("1.00"),
+		UnitAmount:  xerogolang.MustParseDecimal("395.00"),
 		AccountCode: "200",
 	}
 
@@ -211,8 +352,8 @@ func GenerateExampleCreditNote() *CreditNotes {
 		Contact: Contact{
 			Name: "George Costanza",
 		},
-		Date:            helpers.TodayRFC3339(),
-		LineAmountTypes: "Exclusive",
+		Date:            xerogolang.Today(),
+		LineAmountTypes: LineAmountTypeExclusive,
 		LineItems:       []LineItem{},
 	}
 
@@ -0,0 +1,330 @@
+package accounting
+
+import (
+	"fmt"
+	"regexp"
+)
+
+//The types below are the fixed sets of codes Xero accepts for fields such as Invoice.Type.
+//Encoding a value that isn't one of the constants fails before the request is sent, but any
+//value is kept when decoding so codes added to the API later don't break existing code.
+//An empty value means the field has not been set and is always allowed. TaxType is the exception:
+//new tax rates are added by region, so any code is sent and IsValid is only a hint
+
+//InvoiceType is the Type of an Invoice or RepeatingInvoice
+type InvoiceType string
+
+const (
+	//InvoiceTypeAccPay is a bill - an invoice from a supplier
+	InvoiceTypeAccPay InvoiceType = "ACCPAY"
+	//InvoiceTypeAccRec is a sales invoice
+	InvoiceTypeAccRec InvoiceType = "ACCREC"
+)
+
+//IsValid reports whether t is one of the InvoiceType constants
+func (t InvoiceType) IsValid() bool {
+	switch t {
+	case InvoiceTypeAccPay, InvoiceTypeAccRec:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown invoice types
+func (t InvoiceType) MarshalText() ([]byte, error) {
+	return marshalEnum("InvoiceType", string(t), t.IsValid())
+}
+
+//InvoiceStatus is the Status of an Invoice
+type InvoiceStatus string
+
+const (
+	InvoiceStatusDraft      InvoiceStatus = "DRAFT"
+	InvoiceStatusSubmitted  InvoiceStatus = "SUBMITTED"
+	InvoiceStatusDeleted    InvoiceStatus = "DELETED"
+	InvoiceStatusAuthorised InvoiceStatus = "AUTHORISED"
+	InvoiceStatusPaid       InvoiceStatus = "PAID"
+	InvoiceStatusVoided     InvoiceStatus = "VOIDED"
+)
+
+//IsValid reports whether s is one of the InvoiceStatus constants
+func (s InvoiceStatus) IsValid() bool {
+	switch s {
+	case InvoiceStatusDraft, InvoiceStatusSubmitted, InvoiceStatusDeleted, InvoiceStatusAuthorised, InvoiceStatusPaid, InvoiceStatusVoided:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown invoice statuses
+func (s InvoiceStatus) MarshalText() ([]byte, error) {
+	return marshalEnum("InvoiceStatus", string(s), s.IsValid())
+}
+
+//BankTransactionType is the Type of a BankTransaction
+type BankTransactionType string
+
+const (
+	BankTransactionTypeReceive            BankTransactionType = "RECEIVE"
+	BankTransactionTypeReceiveOverpayment BankTransactionType = "RECEIVE-OVERPAYMENT"
+	BankTransactionTypeReceivePrepayment  BankTransactionType = "RECEIVE-PREPAYMENT"
+	BankTransactionTypeReceiveTransfer    BankTransactionType = "RECEIVE-TRANSFER"
+	BankTransactionTypeSpend              BankTransactionType = "SPEND"
+	BankTransactionTypeSpendOverpayment   BankTransactionType = "SPEND-OVERPAYMENT"
+	BankTransactionTypeSpendPrepayment    BankTransactionType = "SPEND-PREPAYMENT"
+	BankTransactionTypeSpendTransfer      BankTransactionType = "SPEND-TRANSFER"
+)
+
+//IsValid reports whether t is one of the BankTransactionType constants
+func (t BankTransactionType) IsValid() bool {
+	switch t {
+	case BankTransactionTypeReceive, BankTransactionTypeReceiveOverpayment, BankTransactionTypeReceivePrepayment, BankTransactionTypeReceiveTransfer,
+		BankTransactionTypeSpend, BankTransactionTypeSpendOverpayment, BankTransactionTypeSpendPrepayment, BankTransactionTypeSpendTransfer:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown bank transaction types
+func (t BankTransactionType) MarshalText() ([]byte, error) {
+	return marshalEnum("BankTransactionType", string(t), t.IsValid())
+}
+
+//AccountType is the Type of an Account in the chart of accounts
+type AccountType string
+
+const (
+	AccountTypeBank                    AccountType = "BANK"
+	AccountTypeCurrent                 AccountType = "CURRENT"
+	AccountTypeCurrentLiability        AccountType = "CURRLIAB"
+	AccountTypeDepreciation            AccountType = "DEPRECIATN"
+	AccountTypeDirectCosts             AccountType = "DIRECTCOSTS"
+	AccountTypeEquity                  AccountType = "EQUITY"
+	AccountTypeExpense                 AccountType = "EXPENSE"
+	AccountTypeFixed                   AccountType = "FIXED"
+	AccountTypeInventory               AccountType = "INVENTORY"
+	AccountTypeLiability               AccountType = "LIABILITY"
+	AccountTypeNonCurrent              AccountType = "NONCURRENT"
+	AccountTypeOtherIncome             AccountType = "OTHERINCOME"
+	AccountTypeOverheads               AccountType = "OVERHEADS"
+	AccountTypePrepayment              AccountType = "PREPAYMENT"
+	AccountTypeRevenue                 AccountType = "REVENUE"
+	AccountTypeSales                   AccountType = "SALES"
+	AccountTypeTermLiability           AccountType = "TERMLIAB"
+	AccountTypePAYGLiability           AccountType = "PAYGLIABILITY"
+	AccountTypeSuperannuationExpense   AccountType = "SUPERANNUATIONEXPENSE"
+	AccountTypeSuperannuationLiability AccountType = "SUPERANNUATIONLIABILITY"
+	AccountTypeWagesExpense            AccountType = "WAGESEXPENSE"
+	AccountTypeWagesPayableLiability   AccountType = "WAGESPAYABLELIABILITY"
+)
+
+//IsValid reports whether t is one of the AccountType constants
+func (t AccountType) IsValid() bool {
+	switch t {
+	case AccountTypeBank, AccountTypeCurrent, AccountTypeCurrentLiability, AccountTypeDepreciation, AccountTypeDirectCosts,
+		AccountTypeEquity, AccountTypeExpense, AccountTypeFixed, AccountTypeInventory, AccountTypeLiability,
+		AccountTypeNonCurrent, AccountTypeOtherIncome, AccountTypeOverheads, AccountTypePrepayment, AccountTypeRevenue,
+		AccountTypeSales, AccountTypeTermLiability, AccountTypePAYGLiability, AccountTypeSuperannuationExpense,
+		AccountTypeSuperannuationLiability, AccountTypeWagesExpense, AccountTypeWagesPayableLiability:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown account types
+func (t AccountType) MarshalText() ([]byte, error) {
+	return marshalEnum("AccountType", string(t), t.IsValid())
+}
+
+//LineAmountType says whether the line amounts of a transaction include tax
+type LineAmountType string
+
+const (
+	LineAmountTypeExclusive LineAmountType = "Exclusive"
+	LineAmountTypeInclusive LineAmountType = "Inclusive"
+	LineAmountTypeNoTax     LineAmountType = "NoTax"
+)
+
+//IsValid reports whether t is one of the LineAmountType constants
+func (t LineAmountType) IsValid() bool {
+	switch t {
+	case LineAmountTypeExclusive, LineAmountTypeInclusive, LineAmountTypeNoTax:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown line amount types
+func (t LineAmountType) MarshalText() ([]byte, error) {
+	return marshalEnum("LineAmountType", string(t), t.IsValid())
+}
+
+//TaxType is the code of a tax rate. The system tax rates differ by region and the tax rates an
+//organisation adds itself are given codes TAX001, TAX002 and so on
+type TaxType string
+
+const (
+	TaxTypeNone               TaxType = "NONE"
+	TaxTypeInput              TaxType = "INPUT"
+	TaxTypeOutput             TaxType = "OUTPUT"
+	TaxTypeGSTOnImports       TaxType = "GSTONIMPORTS"
+	TaxTypeGSTOnCapImports    TaxType = "GSTONCAPIMPORTS"
+	TaxTypeCapexInput         TaxType = "CAPEXINPUT"
+	TaxTypeExemptExport       TaxType = "EXEMPTEXPORT"
+	TaxTypeExemptExpenses     TaxType = "EXEMPTEXPENSES"
+	TaxTypeExemptCapital      TaxType = "EXEMPTCAPITAL"
+	TaxTypeExemptInput        TaxType = "EXEMPTINPUT"
+	TaxTypeExemptOutput       TaxType = "EXEMPTOUTPUT"
+	TaxTypeInputTaxed         TaxType = "INPUTTAXED"
+	TaxTypeBASExcluded        TaxType = "BASEXCLUDED"
+	TaxTypeInput2             TaxType = "INPUT2"
+	TaxTypeOutput2            TaxType = "OUTPUT2"
+	TaxTypeZeroRated          TaxType = "ZERORATED"
+	TaxTypeZeroRatedInput     TaxType = "ZERORATEDINPUT"
+	TaxTypeZeroRatedOutput    TaxType = "ZERORATEDOUTPUT"
+	TaxTypeCapexInput2        TaxType = "CAPEXINPUT2"
+	TaxTypeCapexOutput        TaxType = "CAPEXOUTPUT"
+	TaxTypeCapexOutput2       TaxType = "CAPEXOUTPUT2"
+	TaxTypeCapexSRInput       TaxType = "CAPEXSRINPUT"
+	TaxTypeCapexSROutput      TaxType = "CAPEXSROUTPUT"
+	TaxTypeECAcquisitions     TaxType = "ECACQUISITIONS"
+	TaxTypeECZRInput          TaxType = "ECZRINPUT"
+	TaxTypeECZROutput         TaxType = "ECZROUTPUT"
+	TaxTypeECZROutputServices TaxType = "ECZROUTPUTSERVICES"
+	TaxTypeRRInput            TaxType = "RRINPUT"
+	TaxTypeRROutput           TaxType = "RROUTPUT"
+	TaxTypeSRInput            TaxType = "SRINPUT"
+	TaxTypeSROutput           TaxType = "SROUTPUT"
+	TaxTypeDRChargeSupply20   TaxType = "DRCHARGESUPPLY20"
+	TaxTypeDRCharge20         TaxType = "DRCHARGE20"
+	TaxTypeDRChargeSupply5    TaxType = "DRCHARGESUPPLY5"
+	TaxTypeDRCharge5          TaxType = "DRCHARGE5"
+)
+
+var (
+	//customTaxType matches the codes of tax rates added by an organisation
+	customTaxType = regexp.MustCompile(`^TAX\d{3}$`)
+)
+
+//IsValid reports whether t is one of the TaxType constants or the code of a tax rate added by an organisation.
+//The constants only cover some regions so a code that isn't valid may still be one Xero accepts
+func (t TaxType) IsValid() bool {
+	switch t {
+	case TaxTypeNone, TaxTypeInput, TaxTypeOutput, TaxTypeGSTOnImports, TaxTypeGSTOnCapImports, TaxTypeCapexInput,
+		TaxTypeExemptExport, TaxTypeExemptExpenses, TaxTypeExemptCapital, TaxTypeExemptInput, TaxTypeExemptOutput,
+		TaxTypeInputTaxed, TaxTypeBASExcluded, TaxTypeInput2, TaxTypeOutput2, TaxTypeZeroRated, TaxTypeZeroRatedInput,
+		TaxTypeZeroRatedOutput, TaxTypeCapexInput2, TaxTypeCapexOutput, TaxTypeCapexOutput2, TaxTypeCapexSRInput,
+		TaxTypeCapexSROutput, TaxTypeECAcquisitions, TaxTypeECZRInput, TaxTypeECZROutput, TaxTypeECZROutputServices,
+		TaxTypeRRInput, TaxTypeRROutput, TaxTypeSRInput, TaxTypeSROutput, TaxTypeDRChargeSupply20, TaxTypeDRCharge20,
+		TaxTypeDRChargeSupply5, TaxTypeDRCharge5:
+		return true
+	}
+	return customTaxType.MatchString(string(t))
+}
+
+//MarshalText encodes any tax type, leaving it to Xero to reject codes it doesn't have
+func (t TaxType) MarshalText() ([]byte, error) {
+	return []byte(t), nil
+}
+
+//ScheduleUnit is the Unit of a repeating invoice Schedule
+type ScheduleUnit string
+
+const (
+	ScheduleUnitWeekly  ScheduleUnit = "WEEKLY"
+	ScheduleUnitMonthly ScheduleUnit = "MONTHLY"
+)
+
+//IsValid reports whether u is one of the ScheduleUnit constants
+func (u ScheduleUnit) IsValid() bool {
+	switch u {
+	case ScheduleUnitWeekly, ScheduleUnitMonthly:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown schedule units
+func (u ScheduleUnit) MarshalText() ([]byte, error) {
+	return marshalEnum("ScheduleUnit", string(u), u.IsValid())
+}
+
+//QuoteStatus is the Status of a Quote
+type QuoteStatus string
+
+const (
+	QuoteStatusDraft    QuoteStatus = "DRAFT"
+	QuoteStatusSent     QuoteStatus = "SENT"
+	QuoteStatusAccepted QuoteStatus = "ACCEPTED"
+	QuoteStatusDeclined QuoteStatus = "DECLINED"
+	QuoteStatusInvoiced QuoteStatus = "INVOICED"
+	QuoteStatusDeleted  QuoteStatus = "DELETED"
+)
+
+//IsValid reports whether s is one of the QuoteStatus constants
+func (s QuoteStatus) IsValid() bool {
+	switch s {
+	case QuoteStatusDraft, QuoteStatusSent, QuoteStatusAccepted, QuoteStatusDeclined, QuoteStatusInvoiced, QuoteStatusDeleted:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown quote statuses
+func (s QuoteStatus) MarshalText() ([]byte, error) {
+	return marshalEnum("QuoteStatus", string(s), s.IsValid())
+}
+
+//PaymentBatchType is the Type of a PaymentBatch, set by Xero from the invoices that are paid
+type PaymentBatchType string
+
+const (
+	//PaymentBatchTypePayBatch pays bills
+	PaymentBatchTypePayBatch PaymentBatchType = "PAYBATCH"
+	//PaymentBatchTypeRecBatch receives payment of sales invoices
+	PaymentBatchTypeRecBatch PaymentBatchType = "RECBATCH"
+)
+
+//IsValid reports whether t is one of the PaymentBatchType constants
+func (t PaymentBatchType) IsValid() bool {
+	switch t {
+	case PaymentBatchTypePayBatch, PaymentBatchTypeRecBatch:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown batch payment types
+func (t PaymentBatchType) MarshalText() ([]byte, error) {
+	return marshalEnum("PaymentBatchType", string(t), t.IsValid())
+}
+
+//PaymentBatchStatus is the Status of a PaymentBatch
+type PaymentBatchStatus string
+
+const (
+	PaymentBatchStatusAuthorised PaymentBatchStatus = "AUTHORISED"
+	PaymentBatchStatusDeleted    PaymentBatchStatus = "DELETED"
+)
+
+//IsValid reports whether s is one of the PaymentBatchStatus constants
+func (s PaymentBatchStatus) IsValid() bool {
+	switch s {
+	case PaymentBatchStatusAuthorised, PaymentBatchStatusDeleted:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown batch payment statuses
+func (s PaymentBatchStatus) MarshalText() ([]byte, error) {
+	return marshalEnum("PaymentBatchStatus", string(s), s.IsValid())
+}
+
+//marshalEnum returns the value to encode, or an error if it is set but not valid
+func marshalEnum(typeName string, value string, valid bool) ([]byte, error) {
+	if value != "" && !valid {
+		return nil, fmt.Errorf("%q is not a valid %s", value, typeName)
+	}
+	return []byte(value), nil
+}
@@ -0,0 +1,72 @@
+package accounting
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TaxType(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	testCases := []struct {
+		taxType TaxType
+		valid   bool
+	}{
+		{TaxTypeOutput, true},
+		{TaxTypeDRCharge5, true},
+		{"TAX001", true},
+		{"TAX01", false},
+		//tax types of regions without constants, such as Singapore's standard-rated supplies
+		{"OUTPUTY24", false},
+		{"", false},
+	}
+
+	for _, testCase := range testCases {
+		a.Equal(testCase.valid, testCase.taxType.IsValid(), string(testCase.taxType))
+
+		//IsValid is only a hint, every tax type is sent as it is
+		text, err := testCase.taxType.MarshalText()
+		a.NoError(err, string(testCase.taxType))
+		a.Equal(string(testCase.taxType), string(text))
+	}
+
+	body, err := json.Marshal(LineItem{Description: "Consulting", TaxType: "OUTPUTY24"})
+	a.NoError(err)
+	a.Contains(string(body), `"TaxType":"OUTPUTY24"`)
+
+	body, err = xml.Marshal(LineItem{Description: "Consulting", TaxType: "OUTPUTY24"})
+	a.NoError(err)
+	a.Contains(string(body), `<TaxType>OUTPUTY24</TaxType>`)
+
+	invoices := &Invoices{Invoices: []Invoice{{
+		Type:      InvoiceTypeAccRec,
+		Contact:   Contact{Name: "Vanderlay Industries"},
+		LineItems: []LineItem{{Description: "Consulting", TaxType: "OUTPUTY24"}},
+	}}}
+	a.NoError(invoices.Validate())
+}
+
+func Test_Codes_RejectUnknown(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	_, err := InvoiceType("ACCREQ").MarshalText()
+	a.EqualError(err, `"ACCREQ" is not a valid InvoiceType`)
+
+	_, err = json.Marshal(Invoice{Type: InvoiceTypeAccPay, Status: "PAID-ISH"})
+	a.Error(err)
+
+	//unknown codes Xero sends back are kept
+	var invoice Invoice
+	a.NoError(json.Unmarshal([]byte(`{"Type":"ACCREC","Status":"SCHEDULED"}`), &invoice))
+	a.Equal(InvoiceStatus("SCHEDULED"), invoice.Status)
+	a.False(invoice.Status.IsValid())
+
+	text, err := InvoiceStatus("").MarshalText()
+	a.NoError(err)
+	a.Empty(text)
+}
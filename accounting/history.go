@@ -0,0 +1,123 @@
+package accounting
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"net/url"
+
+	"github.com/XeroAPI/xerogolang"
+	"github.com/markbates/goth"
+)
+
+//HistoryEndpoint is an endpoint whose entities keep a history of changes and notes
+type HistoryEndpoint string
+
+const (
+	BankTransactionHistory  HistoryEndpoint = "BankTransactions"
+	BankTransferHistory     HistoryEndpoint = "BankTransfers"
+	ContactHistory          HistoryEndpoint = "Contacts"
+	CreditNoteHistory       HistoryEndpoint = "CreditNotes"
+	ExpenseClaimHistory     HistoryEndpoint = "ExpenseClaims"
+	InvoiceHistory          HistoryEndpoint = "Invoices"
+	ItemHistory             HistoryEndpoint = "Items"
+	ManualJournalHistory    HistoryEndpoint = "ManualJournals"
+	OverpaymentHistory      HistoryEndpoint = "Overpayments"
+	PaymentHistory          HistoryEndpoint = "Payments"
+	PaymentBatchHistory     HistoryEndpoint = "BatchPayments"
+	PrepaymentHistory       HistoryEndpoint = "Prepayments"
+	PurchaseOrderHistory    HistoryEndpoint = "PurchaseOrders"
+	QuoteHistory            HistoryEndpoint = "Quotes"
+	ReceiptHistory          HistoryEndpoint = "Receipts"
+	RepeatingInvoiceHistory HistoryEndpoint = "RepeatingInvoices"
+)
+
+//HistoryRecord is a change made to an entity or a note added to it
+type HistoryRecord struct {
+	// The type of change e.g. Created, Edited, Approved or Note
+	Changes string `json:"Changes,omitempty" xml:"-"`
+
+	// What was changed, or the text of the note
+	Details string `json:"Details,omitempty" xml:"Details"`
+
+	// The name of the user who made the change
+	User string `json:"User,omitempty" xml:"-"`
+
+	// When the change was made
+	DateUTC xerogolang.DateTime `json:"DateUTC,omitzero" xml:"-"`
+}
+
+//HistoryRecords is a collection of HistoryRecords
+type HistoryRecords struct {
+	XMLName        xml.Name        `json:"-" xml:"HistoryRecords"`
+	HistoryRecords []HistoryRecord `json:"HistoryRecords" xml:"HistoryRecord"`
+}
+
+func unmarshalHistoryRecord(historyRecordResponseBytes []byte) (*HistoryRecords, error) {
+	var historyRecordResponse *HistoryRecords
+	err := json.Unmarshal(historyRecordResponseBytes, &historyRecordResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return historyRecordResponse, err
+}
+
+func historyPath(endpoint HistoryEndpoint, entityID string) string {
+	return string(endpoint) + "/" + url.PathEscape(entityID) + "/History"
+}
+
+//FindHistory will get the changes made to an entity and the notes added to it, e.g. the invoice with an InvoiceID of entityID
+func FindHistory(provider *xerogolang.Provider, session goth.Session, endpoint HistoryEndpoint, entityID string) (*HistoryRecords, error) {
+	return FindHistoryCtx(context.Background(), provider, session, endpoint, entityID)
+}
+
+//FindHistoryCtx is FindHistory with a context that can cancel the request or set its deadline
+func FindHistoryCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, endpoint HistoryEndpoint, entityID string) (*HistoryRecords, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	historyRecordResponseBytes, err := provider.FindCtx(ctx, session, historyPath(endpoint, entityID), additionalHeaders, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalHistoryRecord(historyRecordResponseBytes)
+}
+
+//AddNote will add a note to the history of an entity. Notes are limited to 2500 characters
+func AddNote(provider *xerogolang.Provider, session goth.Session, endpoint HistoryEndpoint, entityID string, note string) (*HistoryRecords, error) {
+	return AddNoteCtx(context.Background(), provider, session, endpoint, entityID, note)
+}
+
+//AddNoteCtx is AddNote with a context that can cancel the request or set its deadline
+func AddNoteCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, endpoint HistoryEndpoint, entityID string, note string) (*HistoryRecords, error) {
+	v := &validator{}
+	v.required("Details", note)
+	v.maxLength("Details", note, 2500)
+	err := v.err()
+	if err != nil {
+		return nil, err
+	}
+
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/xml",
+	}
+
+	historyRecords := &HistoryRecords{
+		HistoryRecords: []HistoryRecord{{Details: note}},
+	}
+	body, err := xml.MarshalIndent(historyRecords, "  ", "	")
+	if err != nil {
+		return nil, err
+	}
+
+	historyRecordResponseBytes, err := provider.CreateCtx(ctx, session, historyPath(endpoint, entityID), additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalHistoryRecord(historyRecordResponseBytes)
+}
@@ -0,0 +1,78 @@
+package accounting
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XeroAPI/xerogolang"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FindHistory(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	response := `{"HistoryRecords":[
+		{"Changes":"Created","Details":"INV-0001 to Vanderlay Industries for 100.00.","User":"Art Vandelay","DateUTC":"/Date(1573755038314+0000)/"},
+		{"Changes":"Note","Details":"Called the customer","User":"System Generated","DateUTC":"2019-11-15T09:30:00"}
+	]}`
+	mockAccounting([]string{response}, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		history, err := FindHistory(provider, session, InvoiceHistory, "i-1")
+		a.NoError(err)
+		a.Equal([]HistoryRecord{
+			{
+				Changes: "Created",
+				Details: "INV-0001 to Vanderlay Industries for 100.00.",
+				User:    "Art Vandelay",
+				DateUTC: xerogolang.NewDateTime(time.Date(2019, time.November, 14, 18, 10, 38, 314000000, time.UTC)),
+			},
+			{
+				Changes: "Note",
+				Details: "Called the customer",
+				User:    "System Generated",
+				DateUTC: xerogolang.NewDateTime(time.Date(2019, time.November, 15, 9, 30, 0, 0, time.UTC)),
+			},
+		}, history.HistoryRecords)
+		a.Equal([]string{"GET /Invoices/i-1/History "}, *requests)
+	})
+}
+
+func Test_AddNote(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	response := `{"HistoryRecords":[{"Changes":"Note","Details":"Paid <in full> & early","DateUTC":"/Date(1573755038314+0000)/"}]}`
+	mockAccounting([]string{response}, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		history, err := AddNote(provider, session, ContactHistory, "c-1", "Paid <in full> & early")
+		a.NoError(err)
+		a.Len(history.HistoryRecords, 1)
+		a.Equal("Note", history.HistoryRecords[0].Changes)
+
+		//only the Details of the note are sent
+		a.Equal([]string{"PUT /Contacts/c-1/History " +
+			"  <HistoryRecords>\n" +
+			"  \t<HistoryRecord>\n" +
+			"  \t\t<Details>Paid &lt;in full&gt; &amp; early</Details>\n" +
+			"  \t</HistoryRecord>\n" +
+			"  </HistoryRecords>"}, *requests)
+	})
+}
+
+func Test_AddNote_Length(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockAccounting([]string{`{"HistoryRecords":[{"Changes":"Note"}]}`}, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		_, err := AddNote(provider, session, InvoiceHistory, "i-1", "")
+		a.EqualError(err, "Details is required")
+
+		_, err = AddNote(provider, session, InvoiceHistory, "i-1", strings.Repeat("x", 2501))
+		a.EqualError(err, "Details must be at most 2500 characters")
+		a.Empty(*requests)
+
+		_, err = AddNote(provider, session, InvoiceHistory, "i-1", strings.Repeat("é", 2500))
+		a.NoError(err)
+		a.Len(*requests, 1)
+	})
+}
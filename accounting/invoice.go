@@ -1,19 +1,21 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
+	"fmt"
+	"io"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
 //Invoice is an Accounts Payable or Accounts Recievable document in a Xero organisation
 type Invoice struct {
 	// See Invoice Types
-	Type string `json:"Type" xml:"Type"`
+	Type InvoiceType `json:"Type" xml:"Type"`
 
 	// See Contacts
 	Contact Contact `json:"Contact" xml:"Contact"`
@@ -22,13 +24,13 @@ type Invoice struct {
 	LineItems []LineItem `json:"LineItems" xml:"LineItems>LineItem"`
 
 	// Date invoice was issued – YYYY-MM-DD. If the Date element is not specified it will default to the current date based on the timezone setting of the organisation
-	Date string `json:"DateString,omitempty" xml:"Date,omitempty"`
+	Date xerogolang.Date `json:"DateString,omitzero" xml:"Date,omitempty"`
 
 	// Date invoice is due – YYYY-MM-DD
-	DueDate string `json:"DueDateString,omitempty" xml:"DueDate,omitempty"`
+	DueDate xerogolang.Date `json:"DueDateString,omitzero" xml:"DueDate,omitempty"`
 
 	// Line amounts are exclusive of tax by default if you don’t specify this element. See Line Amount Types
-	LineAmountTypes string `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
+	LineAmountTypes LineAmountType `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
 
 	// ACCREC – Unique alpha numeric code identifying invoice (when missing will auto-generate from your Organisation Invoice Settings) (max length = 255)
 	InvoiceNumber string `json:"InvoiceNumber,omitempty" xml:"InvoiceNumber,omitempty"`
@@ -46,31 +48,31 @@ type Invoice struct {
 	CurrencyCode string `json:"CurrencyCode,omitempty" xml:"CurrencyCode,omitempty"`
 
 	// The currency rate for a multicurrency invoice. If no rate is specified, the XE.com day rate is used. (max length = [18].[6])
-	CurrencyRate float32 `json:"CurrencyRate,omitempty" xml:"CurrencyRate,omitempty"`
+	CurrencyRate xerogolang.Decimal `json:"CurrencyRate,omitzero" xml:"CurrencyRate,omitempty"`
 
 	// See Invoice Status Codes
-	Status string `json:"Status,omitempty" xml:"Status,omitempty"`
+	Status InvoiceStatus `json:"Status,omitempty" xml:"Status,omitempty"`
 
 	// Boolean to set whether the invoice in the Xero app should be marked as “sent”. This can be set only on invoices that have been approved
 	SentToContact bool `json:"SentToContact,omitempty" xml:"SentToContact,omitempty"`
 
 	// Shown on sales invoices (Accounts Receivable) when this has been set
-	ExpectedPaymentDate string `json:"ExpectedPaymentDate,omitempty" xml:"ExpectedPaymentDate,omitempty"`
+	ExpectedPaymentDate xerogolang.Date `json:"ExpectedPaymentDate,omitzero" xml:"ExpectedPaymentDate,omitempty"`
 
 	// Shown on bills (Accounts Payable) when this has been set
-	PlannedPaymentDate string `json:"PlannedPaymentDate,omitempty" xml:"PlannedPaymentDate,omitempty"`
+	PlannedPaymentDate xerogolang.Date `json:"PlannedPaymentDate,omitzero" xml:"PlannedPaymentDate,omitempty"`
 
 	// Total of invoice excluding taxes
-	SubTotal float32 `json:"SubTotal,omitempty" xml:"SubTotal,omitempty"`
+	SubTotal xerogolang.Decimal `json:"SubTotal,omitzero" xml:"SubTotal,omitempty"`
 
 	// Total tax on invoice
-	TotalTax float32 `json:"TotalTax,omitempty" xml:"TotalTax,omitempty"`
+	TotalTax xerogolang.Decimal `json:"TotalTax,omitzero" xml:"TotalTax,omitempty"`
 
 	// Total of Invoice tax inclusive (i.e. SubTotal + TotalTax). This will be ignored if it doesn’t equal the sum of the LineAmounts
-	Total float32 `json:"Total,omitempty" xml:"Total,omitempty"`
+	Total xerogolang.Decimal `json:"Total,omitzero" xml:"Total,omitempty"`
 
 	// Total of discounts applied on the invoice line items
-	TotalDiscount float32 `json:"TotalDiscount,omitempty" xml:"-"`
+	TotalDiscount xerogolang.Decimal `json:"TotalDiscount,omitzero" xml:"-"`
 
 	// Xero generated unique identifier for invoice
 	InvoiceID string `json:"InvoiceID,omitempty" xml:"InvoiceID,omitempty"`
@@ -88,22 +90,28 @@ type Invoice struct {
 	Overpayments *[]Overpayment `json:"Overpayments,omitempty" xml:"-"`
 
 	// Amount remaining to be paid on invoice
-	AmountDue float32 `json:"AmountDue,omitempty" xml:"-"`
+	AmountDue xerogolang.Decimal `json:"AmountDue,omitzero" xml:"-"`
 
 	// Sum of payments received for invoice
-	AmountPaid float32 `json:"AmountPaid,omitempty" xml:"-"`
+	AmountPaid xerogolang.Decimal `json:"AmountPaid,omitzero" xml:"-"`
 
 	// The date the invoice was fully paid. Only returned on fully paid invoices
-	FullyPaidOnDate string `json:"FullyPaidOnDate,omitempty" xml:"-"`
+	FullyPaidOnDate xerogolang.Date `json:"FullyPaidOnDate,omitzero" xml:"-"`
 
 	// Sum of all credit notes, over-payments and pre-payments applied to invoice
-	AmountCredited float32 `json:"AmountCredited,omitempty" xml:"-"`
+	AmountCredited xerogolang.Decimal `json:"AmountCredited,omitzero" xml:"-"`
 
 	// Last modified date UTC format
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"-"`
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"-"`
 
 	// Details of credit notes that have been applied to an invoice
 	CreditNotes *[]CreditNote `json:"CreditNotes,omitempty" xml:"-"`
+
+	// OK or ERROR when the invoice was part of a batch sent with summarizeErrors=false
+	StatusAttributeString string `json:"StatusAttributeString,omitempty" xml:"-"`
+
+	// Why the invoice could not be saved when StatusAttributeString is ERROR
+	ValidationErrors []xerogolang.ValidationError `json:"ValidationErrors,omitempty" xml:"-"`
 }
 
 //Invoices contains a collection of Invoices
@@ -115,20 +123,6 @@ var (
 	dayZero = time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
 )
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (i *Invoices) convertDates() error {
-	var err error
-	for n := len(i.Invoices) - 1; n >= 0; n-- {
-		i.Invoices[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(i.Invoices[n].UpdatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalInvoice(invoiceResponseBytes []byte) (*Invoices, error) {
 	var invoiceResponse *Invoices
 	err := json.Unmarshal(invoiceResponseBytes, &invoiceResponse)
@@ -136,16 +130,47 @@ func unmarshalInvoice(invoiceResponseBytes []byte) (*Invoices, error) {
 		return nil, err
 	}
 
-	err = invoiceResponse.convertDates()
-	if err != nil {
-		return nil, err
+	return invoiceResponse, err
+}
+
+//Validate checks the invoices against the rules Xero applies so mistakes are found before anything is sent
+func (i *Invoices) Validate() error {
+	v := &validator{}
+	for n := range i.Invoices {
+		i.Invoices[n].validate(v, index("Invoices", n))
 	}
+	return v.err()
+}
 
-	return invoiceResponse, err
+func (i *Invoice) validate(v *validator, field string) {
+	if i.InvoiceID == "" {
+		v.required(field+".Type", string(i.Type))
+	}
+	v.code(field+".Type", string(i.Type), i.Type.IsValid())
+	v.code(field+".Status", string(i.Status), i.Status.IsValid())
+	v.code(field+".LineAmountTypes", string(i.LineAmountTypes), i.LineAmountTypes.IsValid())
+	v.maxLength(field+".InvoiceNumber", i.InvoiceNumber, 255)
+	v.maxLength(field+".Reference", i.Reference, 255)
+	i.Contact.validate(v, field+".Contact", i.InvoiceID != "")
+	//the Type can be left out when updating an invoice, in which case Xero checks the discounts itself
+	discountAllowed := i.Type == InvoiceTypeAccRec || i.Type == ""
+	for n := range i.LineItems {
+		i.LineItems[n].validate(v, index(field+".LineItems", n), discountAllowed)
+	}
 }
 
 //Create will create invoices given an Invoices struct
 func (i *Invoices) Create(provider *xerogolang.Provider, session goth.Session) (*Invoices, error) {
+	return i.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (i *Invoices) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Invoices, error) {
+	err := i.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -156,7 +181,7 @@ func (i *Invoices) Create(provider *xerogolang.Provider, session goth.Session) (
 		return nil, err
 	}
 
-	invoiceResponseBytes, err := provider.Create(session, "Invoices", additionalHeaders, body)
+	invoiceResponseBytes, err := provider.CreateCtx(ctx, session, "Invoices", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -167,6 +192,16 @@ func (i *Invoices) Create(provider *xerogolang.Provider, session goth.Session) (
 //Update will update an invoice given an Invoices struct
 //This will only handle single invoice - you cannot update multiple invoices in a single call
 func (i *Invoices) Update(provider *xerogolang.Provider, session goth.Session) (*Invoices, error) {
+	return i.UpdateCtx(context.Background(), provider, session)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (i *Invoices) UpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Invoices, error) {
+	err := i.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -177,7 +212,7 @@ func (i *Invoices) Update(provider *xerogolang.Provider, session goth.Session) (
 		return nil, err
 	}
 
-	invoiceResponseBytes, err := provider.Update(session, "Invoices/"+i.Invoices[0].InvoiceID, additionalHeaders, body)
+	invoiceResponseBytes, err := provider.UpdateCtx(ctx, session, "Invoices/"+i.Invoices[0].InvoiceID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -185,11 +220,83 @@ func (i *Invoices) Update(provider *xerogolang.Provider, session goth.Session) (
 	return unmarshalInvoice(invoiceResponseBytes)
 }
 
+//InvoiceResult is what happened to one invoice in a batch
+type InvoiceResult struct {
+	BatchResult
+
+	// The invoice as Xero saved it, or as it was sent if it was not saved
+	Invoice Invoice
+}
+
+//BatchCreate creates invoices in as many requests as needed, with summarizeErrors=false so that
+//every valid invoice is saved even if others are not. There is a result for each invoice, in the same order
+func (i *Invoices) BatchCreate(provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]InvoiceResult, error) {
+	return i.BatchCreateCtx(context.Background(), provider, session, options)
+}
+
+//BatchCreateCtx is BatchCreate with a context that can cancel the requests or set their deadline
+func (i *Invoices) BatchCreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]InvoiceResult, error) {
+	return i.batch(ctx, provider, session, false, options)
+}
+
+//BatchUpdate updates any number of invoices, unlike Update, in the same way as BatchCreate
+func (i *Invoices) BatchUpdate(provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]InvoiceResult, error) {
+	return i.BatchUpdateCtx(context.Background(), provider, session, options)
+}
+
+//BatchUpdateCtx is BatchUpdate with a context that can cancel the requests or set their deadline
+func (i *Invoices) BatchUpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]InvoiceResult, error) {
+	return i.batch(ctx, provider, session, true, options)
+}
+
+func (i *Invoices) batch(ctx context.Context, provider *xerogolang.Provider, session goth.Session, update bool, options BatchOptions) ([]InvoiceResult, error) {
+	results := make([]InvoiceResult, len(i.Invoices))
+	for n := range i.Invoices {
+		results[n].Invoice = i.Invoices[n]
+	}
+
+	sender := &batchSender{
+		collection: "Invoices",
+		count:      len(i.Invoices),
+		validate: func(v *validator, n int) {
+			i.Invoices[n].validate(v, index("Invoices", n))
+		},
+		marshal: func(n int) ([]byte, error) {
+			return xml.Marshal(i.Invoices[n])
+		},
+		receive: func(response []byte, indexes []int) ([]batchStatus, error) {
+			saved, err := unmarshalInvoice(response)
+			if err != nil {
+				return nil, err
+			}
+			statuses := make([]batchStatus, len(saved.Invoices))
+			for k, invoice := range saved.Invoices {
+				if k < len(indexes) {
+					results[indexes[k]].Invoice = invoice
+				}
+				statuses[k] = batchStatus{StatusAttributeString: invoice.StatusAttributeString, ValidationErrors: invoice.ValidationErrors}
+			}
+			return statuses, nil
+		},
+	}
+
+	batchResults, err := sender.send(ctx, provider, session, update, options)
+	for n := range results {
+		results[n].BatchResult = batchResults[n]
+	}
+	return results, err
+}
+
 //FindInvoicesModifiedSince will get all Invoices modified after a specified date.
 //These Invoices will not have details like default line items by default.
 //If you need details then add a 'page' querystringParameter and get 100 Invoices at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindInvoicesModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Invoices, error) {
+	return FindInvoicesModifiedSinceCtx(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindInvoicesModifiedSinceCtx is FindInvoicesModifiedSince with a context that can cancel the request or set its deadline
+func FindInvoicesModifiedSinceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Invoices, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -198,7 +305,7 @@ func FindInvoicesModifiedSince(provider *xerogolang.Provider, session goth.Sessi
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	invoiceResponseBytes, err := provider.Find(session, "Invoices", additionalHeaders, querystringParameters)
+	invoiceResponseBytes, err := provider.FindCtx(ctx, session, "Invoices", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -210,16 +317,45 @@ func FindInvoicesModifiedSince(provider *xerogolang.Provider, session goth.Sessi
 //If you need details then add a 'page' querystringParameter and get 100 Invoices at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindInvoices(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Invoices, error) {
-	return FindInvoicesModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindInvoicesCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindInvoicesCtx is FindInvoices with a context that can cancel the request or set its deadline
+func FindInvoicesCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Invoices, error) {
+	return FindInvoicesModifiedSinceCtx(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//EachInvoice calls fn with every invoice modified since modifiedSince, fetching them 100 at a time
+//until Xero returns an empty page. Pass a zero time.Time to get all invoices. where and order
+//querystringParameters are sent with every page. Return xerogolang.ErrStop from fn to stop early
+func EachInvoice(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string, fn func(invoice Invoice) error) error {
+	return xerogolang.EachPage(ctx, querystringParameters, func(pageParameters map[string]string) (int, error) {
+		invoices, err := FindInvoicesModifiedSinceCtx(ctx, provider, session, modifiedSince, pageParameters)
+		if err != nil {
+			return 0, err
+		}
+		for _, invoice := range invoices.Invoices {
+			err = fn(invoice)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return len(invoices.Invoices), nil
+	})
 }
 
 //FindInvoice will get a single invoice - invoiceID can be a GUID for an invoice or an invoice number
 func FindInvoice(provider *xerogolang.Provider, session goth.Session, invoiceID string) (*Invoices, error) {
+	return FindInvoiceCtx(context.Background(), provider, session, invoiceID)
+}
+
+//FindInvoiceCtx is FindInvoice with a context that can cancel the request or set its deadline
+func FindInvoiceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, invoiceID string) (*Invoices, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	invoiceResponseBytes, err := provider.Find(session, "Invoices/"+invoiceID, additionalHeaders, nil)
+	invoiceResponseBytes, err := provider.FindCtx(ctx, session, "Invoices/"+invoiceID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -227,23 +363,93 @@ func FindInvoice(provider *xerogolang.Provider, session goth.Session, invoiceID
 	return unmarshalInvoice(invoiceResponseBytes)
 }
 
+//FindInvoicePDF will get an invoice rendered as a PDF, as Xero would print or email it. The PDF is
+//streamed from Xero as it is read so the caller must close it
+func FindInvoicePDF(provider *xerogolang.Provider, session goth.Session, invoiceID string) (io.ReadCloser, error) {
+	return FindInvoicePDFCtx(context.Background(), provider, session, invoiceID)
+}
+
+//FindInvoicePDFCtx is FindInvoicePDF with a context that can cancel the request or set its deadline
+func FindInvoicePDFCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, invoiceID string) (io.ReadCloser, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/pdf",
+	}
+
+	return provider.DownloadCtx(ctx, session, "Invoices/"+invoiceID, additionalHeaders, nil)
+}
+
+//OnlineInvoice is the page where a customer can view and pay an invoice
+type OnlineInvoice struct {
+	OnlineInvoiceURL string `json:"OnlineInvoiceUrl,omitempty"`
+}
+
+//OnlineInvoices is a collection of OnlineInvoices
+type OnlineInvoices struct {
+	OnlineInvoices []OnlineInvoice `json:"OnlineInvoices"`
+}
+
+//FindOnlineInvoiceURL will get the address of the online invoice for a sales invoice. The invoice
+//must be an ACCREC invoice that is not a draft
+func FindOnlineInvoiceURL(provider *xerogolang.Provider, session goth.Session, invoiceID string) (string, error) {
+	return FindOnlineInvoiceURLCtx(context.Background(), provider, session, invoiceID)
+}
+
+//FindOnlineInvoiceURLCtx is FindOnlineInvoiceURL with a context that can cancel the request or set its deadline
+func FindOnlineInvoiceURLCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, invoiceID string) (string, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	onlineInvoiceResponseBytes, err := provider.FindCtx(ctx, session, "Invoices/"+invoiceID+"/OnlineInvoice", additionalHeaders, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var onlineInvoiceResponse *OnlineInvoices
+	err = json.Unmarshal(onlineInvoiceResponseBytes, &onlineInvoiceResponse)
+	if err != nil {
+		return "", err
+	}
+	if onlineInvoiceResponse == nil || len(onlineInvoiceResponse.OnlineInvoices) == 0 {
+		return "", fmt.Errorf("no online invoice found for %s", invoiceID)
+	}
+
+	return onlineInvoiceResponse.OnlineInvoices[0].OnlineInvoiceURL, nil
+}
+
+//EmailInvoice will have Xero email a sales invoice to the contact using the organisation's default
+//email template. The invoice must be an ACCREC invoice with a status of SUBMITTED, AUTHORISED or PAID
+func EmailInvoice(provider *xerogolang.Provider, session goth.Session, invoiceID string) error {
+	return EmailInvoiceCtx(context.Background(), provider, session, invoiceID)
+}
+
+//EmailInvoiceCtx is EmailInvoice with a context that can cancel the request or set its deadline
+func EmailInvoiceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, invoiceID string) error {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	_, err := provider.UpdateCtx(ctx, session, "Invoices/"+invoiceID+"/Email", additionalHeaders, nil)
+	return err
+}
+
 //GenerateExampleInvoice Creates an Example invoice
 func GenerateExampleInvoice() *Invoices {
 	lineItem := LineItem{
 		Description: "Importing & Exporting Services",
-		Quantity:    1.00,
-		UnitAmount:  395.00,
+		Quantity:    xerogolang.MustParseDecimal("1.00"),
+		UnitAmount:  xerogolang.MustParseDecimal("395.00"),
 		AccountCode: "200",
 	}
 
 	invoice := Invoice{
-		Type: "ACCREC",
+		Type: InvoiceTypeAccRec,
 		Contact: Contact{
 			Name: "George Costanza",
 		},
-		Date:            helpers.TodayRFC3339(),
-		DueDate:         helpers.FormatDate(time.Now().Add(720 * time.Hour)),
-		LineAmountTypes: "Exclusive",
+		Date:            xerogolang.Today(),
+		DueDate:         xerogolang.DateOf(time.Now().Add(720 * time.Hour)),
+		LineAmountTypes: LineAmountTypeExclusive,
 		LineItems:       []LineItem{},
 	}
 
@@ -0,0 +1,168 @@
+package accounting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/XeroAPI/xerogolang"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EachInvoice(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	responses := []string{
+		`{"Invoices":[{"InvoiceID":"i-1"},{"InvoiceID":"i-2"}]}`,
+		`{"Invoices":[{"InvoiceID":"i-3"}]}`,
+		`{"Invoices":[]}`,
+	}
+	mockAccounting(responses, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		var invoiceIDs []string
+		err := EachInvoice(context.Background(), provider, session, time.Time{}, map[string]string{"where": `Status=="PAID"`}, func(invoice Invoice) error {
+			invoiceIDs = append(invoiceIDs, invoice.InvoiceID)
+			return nil
+		})
+		a.NoError(err)
+		a.Equal([]string{"i-1", "i-2", "i-3"}, invoiceIDs)
+
+		//paging stops at the first empty page and the where is sent with every page
+		a.Equal([]string{
+			"GET /Invoices?page=1&where=Status%3D%3D%22PAID%22 ",
+			"GET /Invoices?page=2&where=Status%3D%3D%22PAID%22 ",
+			"GET /Invoices?page=3&where=Status%3D%3D%22PAID%22 ",
+		}, *requests)
+	})
+}
+
+func Test_EachInvoice_Stop(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	responses := []string{
+		`{"Invoices":[{"InvoiceID":"i-1"},{"InvoiceID":"i-2"}]}`,
+		`{"Invoices":[{"InvoiceID":"i-3"}]}`,
+	}
+	mockAccounting(responses, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		var invoiceIDs []string
+		err := EachInvoice(context.Background(), provider, session, time.Time{}, nil, func(invoice Invoice) error {
+			invoiceIDs = append(invoiceIDs, invoice.InvoiceID)
+			if invoice.InvoiceID == "i-1" {
+				return xerogolang.ErrStop
+			}
+			return nil
+		})
+		a.NoError(err)
+		a.Equal([]string{"i-1"}, invoiceIDs)
+		a.Equal([]string{"GET /Invoices?page=1 "}, *requests)
+	})
+}
+
+func Test_EachInvoice_Error(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	failed := errors.New("failed")
+	mockAccounting([]string{`{"Invoices":[{"InvoiceID":"i-1"}]}`}, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		err := EachInvoice(context.Background(), provider, session, time.Time{}, map[string]string{"page": "4"}, func(invoice Invoice) error {
+			return failed
+		})
+		a.Equal(failed, err)
+		a.Equal([]string{"GET /Invoices?page=4 "}, *requests)
+	})
+}
+
+func Test_EachContact(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	responses := []string{
+		`{"Contacts":[{"ContactID":"c-1"}]}`,
+		`{"Contacts":[]}`,
+	}
+	mockAccounting(responses, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		var contactIDs []string
+		err := EachContact(context.Background(), provider, session, time.Time{}, nil, func(contact Contact) error {
+			contactIDs = append(contactIDs, contact.ContactID)
+			return nil
+		})
+		a.NoError(err)
+		a.Equal([]string{"c-1"}, contactIDs)
+		a.Equal([]string{"GET /Contacts?page=1 ", "GET /Contacts?page=2 "}, *requests)
+	})
+}
+
+func Test_FindPDF(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var requests []string
+	handler := func(res http.ResponseWriter, req *http.Request) {
+		requests = append(requests, fmt.Sprintf("%s %s Accept: %s", req.Method, req.URL.Path, req.Header.Get("Accept")))
+		res.Header().Set("Content-Type", "application/pdf")
+		fmt.Fprint(res, "%PDF-1.4 "+req.URL.Path)
+	}
+	withAccounting(handler, func(provider *xerogolang.Provider, session *xerogolang.Session) {
+		read := func(pdf io.ReadCloser, err error) string {
+			a.NoError(err)
+			contents, err := ioutil.ReadAll(pdf)
+			a.NoError(err)
+			a.NoError(pdf.Close())
+			return string(contents)
+		}
+
+		a.Equal("%PDF-1.4 /Invoices/i-1", read(FindInvoicePDF(provider, session, "i-1")))
+		a.Equal("%PDF-1.4 /CreditNotes/cn-1", read(FindCreditNotePDF(provider, session, "cn-1")))
+		a.Equal("%PDF-1.4 /PurchaseOrders/po-1", read(FindPurchaseOrderPDFCtx(context.Background(), provider, session, "po-1")))
+
+		//the PDF is asked for by its content type rather than as JSON
+		a.Equal([]string{
+			"GET /Invoices/i-1 Accept: application/pdf",
+			"GET /CreditNotes/cn-1 Accept: application/pdf",
+			"GET /PurchaseOrders/po-1 Accept: application/pdf",
+		}, requests)
+	})
+}
+
+func Test_FindOnlineInvoiceURL(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	responses := []string{
+		`{"OnlineInvoices":[{"OnlineInvoiceUrl":"https://in.xero.com/iztKMjyAEJT7MVnmruxgCdIJUDStfRgmtdQSIW13"}]}`,
+		`{"OnlineInvoices":[]}`,
+	}
+	mockAccounting(responses, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		onlineInvoiceURL, err := FindOnlineInvoiceURL(provider, session, "i-1")
+		a.NoError(err)
+		a.Equal("https://in.xero.com/iztKMjyAEJT7MVnmruxgCdIJUDStfRgmtdQSIW13", onlineInvoiceURL)
+
+		_, err = FindOnlineInvoiceURL(provider, session, "i-2")
+		a.EqualError(err, "no online invoice found for i-2")
+
+		a.Equal([]string{"GET /Invoices/i-1/OnlineInvoice ", "GET /Invoices/i-2/OnlineInvoice "}, *requests)
+	})
+}
+
+func Test_EmailInvoice(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var requests []string
+	handler := func(res http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		requests = append(requests, fmt.Sprintf("%s %s %s", req.Method, req.URL.Path, body))
+		//Xero answers with no content at all
+		res.WriteHeader(http.StatusNoContent)
+	}
+	withAccounting(handler, func(provider *xerogolang.Provider, session *xerogolang.Session) {
+		a.NoError(EmailInvoice(provider, session, "i-1"))
+		a.Equal([]string{"POST /Invoices/i-1/Email "}, requests)
+	})
+}
@@ -1,12 +1,12 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -44,13 +44,13 @@ type Item struct {
 	IsTrackedAsInventory bool `json:"IsTrackedAsInventory,omitempty" xml:"-"`
 
 	// The value of the item on hand. Calculated using average cost accounting.
-	TotalCostPool float32 `json:"TotalCostPool,omitempty" xml:"-"`
+	TotalCostPool xerogolang.Decimal `json:"TotalCostPool,omitzero" xml:"-"`
 
 	// The quantity of the item on hand
-	QuantityOnHand float32 `json:"QuantityOnHand,omitempty" xml:"-"`
+	QuantityOnHand xerogolang.Decimal `json:"QuantityOnHand,omitzero" xml:"-"`
 
 	// Last modified date in UTC format
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"-"`
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"-"`
 
 	// The Xero identifier for an Item
 	ItemID string `json:"ItemID,omitempty" xml:"ItemID,omitempty"`
@@ -64,7 +64,7 @@ type Items struct {
 //PurchaseAndSaleDetails are Elements for Purchases and Sales
 type PurchaseAndSaleDetails struct {
 	//Unit Price of the item. By default UnitPrice is returned to two decimal places.  You can use 4 decimal places by adding the unitdp=4 querystring parameter to your request.
-	UnitPrice float32 `json:"UnitPrice,omitempty" xml:"UnitPrice,omitempty"`
+	UnitPrice xerogolang.Decimal `json:"UnitPrice,omitzero" xml:"UnitPrice,omitempty"`
 
 	//Default account code to be used for purchased/sale. Not applicable to the purchase details of tracked items
 	AccountCode string `json:"AccountCode,omitempty" xml:"AccountCode,omitempty"`
@@ -76,20 +76,6 @@ type PurchaseAndSaleDetails struct {
 	TaxType string `json:"TaxType,omitempty" xml:"TaxType,omitempty"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (i *Items) convertDates() error {
-	var err error
-	for n := len(i.Items) - 1; n >= 0; n-- {
-		i.Items[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(i.Items[n].UpdatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalItem(itemResponseBytes []byte) (*Items, error) {
 	var itemResponse *Items
 	err := json.Unmarshal(itemResponseBytes, &itemResponse)
@@ -97,16 +83,40 @@ func unmarshalItem(itemResponseBytes []byte) (*Items, error) {
 		return nil, err
 	}
 
-	err = itemResponse.convertDates()
-	if err != nil {
-		return nil, err
+	return itemResponse, err
+}
+
+//Validate checks the items against the rules Xero applies so mistakes are found before anything is sent
+func (i *Items) Validate() error {
+	v := &validator{}
+	for n := range i.Items {
+		i.Items[n].validate(v, index("Items", n))
 	}
+	return v.err()
+}
 
-	return itemResponse, err
+func (i *Item) validate(v *validator, field string) {
+	if i.ItemID == "" {
+		v.required(field+".Code", i.Code)
+	}
+	v.maxLength(field+".Code", i.Code, 30)
+	v.maxLength(field+".Name", i.Name, 50)
+	v.maxLength(field+".Description", i.Description, 4000)
+	v.maxLength(field+".PurchaseDescription", i.PurchaseDescription, 4000)
 }
 
 //Create will create items given an Items struct
 func (i *Items) Create(provider *xerogolang.Provider, session goth.Session) (*Items, error) {
+	return i.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (i *Items) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Items, error) {
+	err := i.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -117,7 +127,7 @@ func (i *Items) Create(provider *xerogolang.Provider, session goth.Session) (*It
 		return nil, err
 	}
 
-	itemResponseBytes, err := provider.Create(session, "Items", additionalHeaders, body)
+	itemResponseBytes, err := provider.CreateCtx(ctx, session, "Items", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -128,6 +138,16 @@ func (i *Items) Create(provider *xerogolang.Provider, session goth.Session) (*It
 //Update will update an item given an Items struct
 //This will only handle single item - you cannot update multiple items in a single call
 func (i *Items) Update(provider *xerogolang.Provider, session goth.Session) (*Items, error) {
+	return i.UpdateCtx(context.Background(), provider, session)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (i *Items) UpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Items, error) {
+	err := i.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -138,7 +158,7 @@ func (i *Items) Update(provider *xerogolang.Provider, session goth.Session) (*It
 		return nil, err
 	}
 
-	itemResponseBytes, err := provider.Update(session, "Items/"+i.Items[0].ItemID, additionalHeaders, body)
+	itemResponseBytes, err := provider.UpdateCtx(ctx, session, "Items/"+i.Items[0].ItemID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -149,6 +169,11 @@ func (i *Items) Update(provider *xerogolang.Provider, session goth.Session) (*It
 //FindItemsModifiedSince will get all items modified after a specified date.
 //additional querystringParameters such as where, page, order can be added as a map
 func FindItemsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Items, error) {
+	return FindItemsModifiedSinceCtx(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindItemsModifiedSinceCtx is FindItemsModifiedSince with a context that can cancel the request or set its deadline
+func FindItemsModifiedSinceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Items, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -157,7 +182,7 @@ func FindItemsModifiedSince(provider *xerogolang.Provider, session goth.Session,
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	itemResponseBytes, err := provider.Find(session, "Items", additionalHeaders, querystringParameters)
+	itemResponseBytes, err := provider.FindCtx(ctx, session, "Items", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -167,16 +192,26 @@ func FindItemsModifiedSince(provider *xerogolang.Provider, session goth.Session,
 
 //FindItems will get all items.
 func FindItems(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Items, error) {
-	return FindItemsModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindItemsCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindItemsCtx is FindItems with a context that can cancel the request or set its deadline
+func FindItemsCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Items, error) {
+	return FindItemsModifiedSinceCtx(ctx, provider, session, dayZero, querystringParameters)
 }
 
 //FindItem will get a single item - itemID must be a GUID for an item
 func FindItem(provider *xerogolang.Provider, session goth.Session, itemID string) (*Items, error) {
+	return FindItemCtx(context.Background(), provider, session, itemID)
+}
+
+//FindItemCtx is FindItem with a context that can cancel the request or set its deadline
+func FindItemCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, itemID string) (*Items, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	itemResponseBytes, err := provider.Find(session, "Items/"+itemID, additionalHeaders, nil)
+	itemResponseBytes, err := provider.FindCtx(ctx, session, "Items/"+itemID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -186,11 +221,16 @@ func FindItem(provider *xerogolang.Provider, session goth.Session, itemID string
 
 //RemoveItem will get a single item - itemID must be a GUID for an item
 func RemoveItem(provider *xerogolang.Provider, session goth.Session, itemID string) (*Items, error) {
+	return RemoveItemCtx(context.Background(), provider, session, itemID)
+}
+
+//RemoveItemCtx is RemoveItem with a context that can cancel the request or set its deadline
+func RemoveItemCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, itemID string) (*Items, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	itemResponseBytes, err := provider.Remove(session, "Items/"+itemID, additionalHeaders)
+	itemResponseBytes, err := provider.RemoveCtx(ctx, session, "Items/"+itemID, additionalHeaders)
 	if err != nil {
 		return nil, err
 	}
@@ -208,11 +248,11 @@ func GenerateExampleItem() *Items {
 		IsSold:              true,
 		IsPurchased:         true,
 		PurchaseDetails: PurchaseAndSaleDetails{
-			UnitPrice:   140.00,
+			UnitPrice:   xerogolang.MustParseDecimal("140.00"),
 			AccountCode: "300",
 		},
 		SalesDetails: PurchaseAndSaleDetails{
-			UnitPrice:   300.00,
+			UnitPrice:   xerogolang.MustParseDecimal("300.00"),
 			AccountCode: "200",
 		},
 	}
@@ -1,5 +1,7 @@
 package accounting
 
+import "github.com/XeroAPI/xerogolang"
+
 //JournalLine is a line on a Journal
 type JournalLine struct {
 	//Xero identifier
@@ -21,16 +23,16 @@ type JournalLine struct {
 	Description string `json:"Description,omitempty" xml:"Description,omitempty"`
 
 	// Net amount of journal line. This will be a positive value for a debit and negative for a credit
-	NetAmount float32 `json:"NetAmount" xml:"NetAmount"`
+	NetAmount xerogolang.Decimal `json:"NetAmount" xml:"NetAmount"`
 
 	// 	Gross amount of journal line (NetAmount + TaxAmount).
-	GrossAmount float32 `json:"GrossAmount" xml:"GrossAmount"`
+	GrossAmount xerogolang.Decimal `json:"GrossAmount" xml:"GrossAmount"`
 
 	// The calculated tax amount based on the TaxType and LineAmount
-	TaxAmount float32 `json:"TaxAmount,omitempty" xml:"TaxAmount,omitempty"`
+	TaxAmount xerogolang.Decimal `json:"TaxAmount,omitzero" xml:"TaxAmount,omitempty"`
 
 	// Used as an override if the default Tax Code for the selected <AccountCode> is not correct – see TaxTypes.
-	TaxType string `json:"TaxType,omitempty" xml:"TaxType,omitempty"`
+	TaxType TaxType `json:"TaxType,omitempty" xml:"TaxType,omitempty"`
 
 	//see tax TaxTypes
 	TaxName string `json:"TaxName,omitempty" xml:"TaxName,omitempty"`
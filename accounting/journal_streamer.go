@@ -0,0 +1,130 @@
+package accounting
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/XeroAPI/xerogolang"
+	"github.com/markbates/goth"
+)
+
+//JournalCheckpoint stores the JournalNumber of the last journal a JournalStreamer handed over
+//so that extraction can carry on from there after a restart or a crash
+type JournalCheckpoint interface {
+	// Load returns the saved JournalNumber, or 0 if nothing has been saved yet
+	Load() (int, error)
+
+	// Save records the JournalNumber of the last journal that was handled
+	Save(journalNumber int) error
+}
+
+//FileJournalCheckpoint keeps the checkpoint in a file. The file is replaced in one step
+//so a crash part way through a save never leaves it half written
+type FileJournalCheckpoint struct {
+	Path string
+}
+
+//Load reads the JournalNumber from the file, returning 0 if the file does not exist yet
+func (f *FileJournalCheckpoint) Load() (int, error) {
+	contents, err := ioutil.ReadFile(f.Path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(contents)))
+}
+
+//Save writes the JournalNumber to a temporary file and renames it over the checkpoint
+func (f *FileJournalCheckpoint) Save(journalNumber int) error {
+	temporaryFile, err := ioutil.TempFile(filepath.Dir(f.Path), filepath.Base(f.Path)+".*")
+	if err != nil {
+		return err
+	}
+	_, err = temporaryFile.WriteString(strconv.Itoa(journalNumber) + "\n")
+	if err == nil {
+		err = temporaryFile.Sync()
+	}
+	closeErr := temporaryFile.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(temporaryFile.Name())
+		return err
+	}
+	return os.Rename(temporaryFile.Name(), f.Path)
+}
+
+//JournalStreamer walks every journal in the general ledger in JournalNumber order using the
+//offset parameter of the Journals endpoint, 100 journals at a time
+type JournalStreamer struct {
+	Provider *xerogolang.Provider
+	Session  goth.Session
+
+	// Where the last JournalNumber handed over is kept. Streaming starts from the first
+	// journal when it is nil
+	Checkpoint JournalCheckpoint
+
+	// Sent with every request e.g. paymentsOnly. Any offset is replaced by the checkpoint
+	QuerystringParameters map[string]string
+}
+
+//Stream calls fn with each journal, including its JournalLines, after the one in the checkpoint.
+//The checkpoint is saved after each call to fn succeeds so a journal that fn has handled is not
+//handed over again. Stream returns once Xero has no more journals, when fn returns
+//xerogolang.ErrStop, or with the first error from fn, Xero or the checkpoint
+func (s *JournalStreamer) Stream(ctx context.Context, fn func(journal Journal) error) error {
+	offset := 0
+	if s.Checkpoint != nil {
+		var err error
+		offset, err = s.Checkpoint.Load()
+		if err != nil {
+			return err
+		}
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		querystringParameters := map[string]string{}
+		for key, value := range s.QuerystringParameters {
+			querystringParameters[key] = value
+		}
+		querystringParameters["offset"] = strconv.Itoa(offset)
+
+		journals, err := FindJournalsCtx(ctx, s.Provider, s.Session, querystringParameters)
+		if err != nil {
+			return err
+		}
+		if len(journals.Journals) == 0 {
+			return nil
+		}
+
+		for _, journal := range journals.Journals {
+			err = fn(journal)
+			if errors.Is(err, xerogolang.ErrStop) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			offset = journal.JournalNumber
+			if s.Checkpoint != nil {
+				err = s.Checkpoint.Save(offset)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package accounting
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/XeroAPI/xerogolang"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FileJournalCheckpoint(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	checkpoint := &FileJournalCheckpoint{Path: filepath.Join(t.TempDir(), "journals")}
+	journalNumber, err := checkpoint.Load()
+	a.NoError(err)
+	a.Equal(0, journalNumber)
+
+	a.NoError(checkpoint.Save(42))
+	a.NoError(checkpoint.Save(43))
+	journalNumber, err = checkpoint.Load()
+	a.NoError(err)
+	a.Equal(43, journalNumber)
+
+	//only the checkpoint is left, not the temporary files it was written through
+	files, err := ioutil.ReadDir(filepath.Dir(checkpoint.Path))
+	a.NoError(err)
+	a.Len(files, 1)
+}
+
+func Test_JournalStreamer_Stream(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	responses := []string{
+		`{"Journals":[{"JournalID":"j-1","JournalNumber":1},{"JournalID":"j-2","JournalNumber":2}]}`,
+		`{"Journals":[{"JournalID":"j-3","JournalNumber":3}]}`,
+		`{"Journals":[]}`,
+	}
+	mockAccounting(responses, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		checkpoint := &FileJournalCheckpoint{Path: filepath.Join(t.TempDir(), "journals")}
+		streamer := &JournalStreamer{
+			Provider:              provider,
+			Session:               session,
+			Checkpoint:            checkpoint,
+			QuerystringParameters: map[string]string{"paymentsOnly": "true", "offset": "99"},
+		}
+
+		var journalIDs []string
+		err := streamer.Stream(context.Background(), func(journal Journal) error {
+			journalIDs = append(journalIDs, journal.JournalID)
+			return nil
+		})
+		a.NoError(err)
+		a.Equal([]string{"j-1", "j-2", "j-3"}, journalIDs)
+
+		//each page starts after the last journal handed over, not from the offset given
+		a.Equal([]string{
+			"GET /Journals?offset=0&paymentsOnly=true ",
+			"GET /Journals?offset=2&paymentsOnly=true ",
+			"GET /Journals?offset=3&paymentsOnly=true ",
+		}, *requests)
+
+		journalNumber, err := checkpoint.Load()
+		a.NoError(err)
+		a.Equal(3, journalNumber)
+	})
+}
+
+func Test_JournalStreamer_ResumesFromCheckpoint(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	checkpoint := &FileJournalCheckpoint{Path: filepath.Join(t.TempDir(), "journals")}
+	a.NoError(checkpoint.Save(10))
+
+	failed := errors.New("failed")
+	responses := []string{
+		`{"Journals":[{"JournalID":"j-11","JournalNumber":11},{"JournalID":"j-12","JournalNumber":12}]}`,
+		`{"Journals":[{"JournalID":"j-12","JournalNumber":12}]}`,
+		`{"Journals":[]}`,
+	}
+	mockAccounting(responses, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		var journalIDs []string
+		failing := true
+		handle := func(journal Journal) error {
+			if journal.JournalID == "j-12" && failing {
+				return failed
+			}
+			journalIDs = append(journalIDs, journal.JournalID)
+			return nil
+		}
+
+		//the first run fails on j-12, so only j-11 is checkpointed
+		streamer := &JournalStreamer{Provider: provider, Session: session, Checkpoint: checkpoint}
+		a.Equal(failed, streamer.Stream(context.Background(), handle))
+		journalNumber, err := checkpoint.Load()
+		a.NoError(err)
+		a.Equal(11, journalNumber)
+
+		//a new streamer picks up after j-11 and hands over j-12 again
+		failing = false
+		streamer = &JournalStreamer{Provider: provider, Session: session, Checkpoint: checkpoint}
+		a.NoError(streamer.Stream(context.Background(), handle))
+		a.Equal([]string{"j-11", "j-12"}, journalIDs)
+		a.Equal([]string{
+			"GET /Journals?offset=10 ",
+			"GET /Journals?offset=11 ",
+			"GET /Journals?offset=12 ",
+		}, *requests)
+
+		journalNumber, err = checkpoint.Load()
+		a.NoError(err)
+		a.Equal(12, journalNumber)
+	})
+}
+
+func Test_JournalStreamer_Stop(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	responses := []string{`{"Journals":[{"JournalID":"j-1","JournalNumber":1},{"JournalID":"j-2","JournalNumber":2}]}`}
+	mockAccounting(responses, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		checkpoint := &FileJournalCheckpoint{Path: filepath.Join(t.TempDir(), "journals")}
+		streamer := &JournalStreamer{Provider: provider, Session: session, Checkpoint: checkpoint}
+		err := streamer.Stream(context.Background(), func(journal Journal) error {
+			if journal.JournalNumber == 2 {
+				return xerogolang.ErrStop
+			}
+			return nil
+		})
+		a.NoError(err)
+		a.Len(*requests, 1)
+
+		//the journal fn stopped on was not handled so it is not checkpointed
+		journalNumber, err := checkpoint.Load()
+		a.NoError(err)
+		a.Equal(1, journalNumber)
+	})
+}
@@ -1,5 +1,7 @@
 package accounting
 
+import "github.com/XeroAPI/xerogolang"
+
 //LineItem is a line containing detail on an Invoice
 type LineItem struct {
 	//The Xero generated identifier for a LineItem. It is recommended that you include LineItemIDs on update requests. If LineItemIDs are not included with line items in an update request then the line items are deleted and recreated.
@@ -9,10 +11,10 @@ type LineItem struct {
 	Description string `json:"Description,omitempty" xml:"Description,omitempty"`
 
 	// LineItem Quantity
-	Quantity float32 `json:"Quantity,omitempty" xml:"Quantity,omitempty"`
+	Quantity xerogolang.Decimal `json:"Quantity,omitzero" xml:"Quantity,omitempty"`
 
 	// LineItem Unit Amount
-	UnitAmount float32 `json:"UnitAmount,omitempty" xml:"UnitAmount,omitempty"`
+	UnitAmount xerogolang.Decimal `json:"UnitAmount,omitzero" xml:"UnitAmount,omitempty"`
 
 	// See Items
 	ItemCode string `json:"ItemCode,omitempty" xml:"ItemCode,omitempty"`
@@ -21,20 +23,32 @@ type LineItem struct {
 	AccountCode string `json:"AccountCode,omitempty" xml:"AccountCode,omitempty"`
 
 	// Used as an override if the default Tax Code for the selected <AccountCode> is not correct – see TaxTypes.
-	TaxType string `json:"TaxType,omitempty" xml:"TaxType,omitempty"`
+	TaxType TaxType `json:"TaxType,omitempty" xml:"TaxType,omitempty"`
 
 	// The tax amount is auto calculated as a percentage of the line amount (see below) based on the tax rate. This value can be overriden if the calculated <TaxAmount> is not correct.
-	TaxAmount float32 `json:"TaxAmount,omitempty" xml:"TaxAmount,omitempty"`
+	TaxAmount xerogolang.Decimal `json:"TaxAmount,omitzero" xml:"TaxAmount,omitempty"`
 
 	// If you wish to omit either of the <Quantity> or <UnitAmount> you can provide a LineAmount and Xero will calculate the missing amount for you. The line amount reflects the discounted price if a DiscountRate has been used . i.e LineAmount = Quantity * Unit Amount * ((100 – DiscountRate)/100)
-	LineAmount float32 `json:"LineAmount,omitempty" xml:"LineAmount,omitempty"`
+	LineAmount xerogolang.Decimal `json:"LineAmount,omitzero" xml:"LineAmount,omitempty"`
 
 	// Optional Tracking Category – see Tracking.  Any LineItem can have a maximum of 2 <TrackingCategory> elements.
 	Tracking []TrackingCategory `json:"Tracking,omitempty" xml:"Tracking>TrackingCategory,omitempty"`
 
 	// Percentage discount being applied to a line item (only supported on ACCREC invoices – ACC PAY invoices and credit notes in Xero do not support discounts
-	DiscountRate float32 `json:"DiscountRate,omitempty" xml:"DiscountRate,omitempty"`
+	DiscountRate xerogolang.Decimal `json:"DiscountRate,omitzero" xml:"DiscountRate,omitempty"`
 
 	// The Xero identifier for a Repeating Invoicee.g. 297c2dc5-cc47-4afd-8ec8-74990b8761e9
 	RepeatingInvoiceID string `json:"RepeatingInvoiceID,omitempty" xml:"RepeatingInvoiceID,omitempty"`
 }
+
+//validate checks a line item. Only sales invoices and purchase orders can have discounts. The
+//description of an item is used when a line with an ItemCode leaves its own out
+func (l *LineItem) validate(v *validator, field string, discountAllowed bool) {
+	if l.ItemCode == "" {
+		v.required(field+".Description", l.Description)
+	}
+	validateTracking(v, field+".Tracking", l.Tracking)
+	if !discountAllowed && !l.DiscountRate.IsZero() {
+		v.add(field+".DiscountRate", "is only supported on ACCREC invoices, quotes and purchase orders")
+	}
+}
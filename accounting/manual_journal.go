@@ -1,12 +1,12 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -20,10 +20,10 @@ type ManualJournal struct {
 	JournalLines []ManualJournalLine `json:"JournalLines" xml:"JournalLines>JournalLine"`
 
 	// Date journal was posted – YYYY-MM-DD
-	Date string `json:"Date,omitempty" xml:"Date,omitempty"`
+	Date xerogolang.Date `json:"Date,omitzero" xml:"Date,omitempty"`
 
 	// NoTax by default if you don’t specify this element. See Line Amount Types
-	LineAmountTypes string `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
+	LineAmountTypes LineAmountType `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
 
 	// See Manual Journal Status Codes
 	Status string `json:"Status,omitempty" xml:"Status,omitempty"`
@@ -38,10 +38,16 @@ type ManualJournal struct {
 	HasAttachments bool `json:"HasAttachments,omitempty" xml:"-"`
 
 	// Last modified date UTC format
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"-"`
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"-"`
 
 	// The Xero identifier for a Manual Journal
 	ManualJournalID string `json:"ManualJournalID,omitempty" xml:"ManualJournalID,omitempty"`
+
+	// OK or ERROR when the manual journal was part of a batch sent with summarizeErrors=false
+	StatusAttributeString string `json:"StatusAttributeString,omitempty" xml:"-"`
+
+	// Why the manual journal could not be saved when StatusAttributeString is ERROR
+	ValidationErrors []xerogolang.ValidationError `json:"ValidationErrors,omitempty" xml:"-"`
 }
 
 //ManualJournals is a collection of ManualJournals
@@ -49,24 +55,6 @@ type ManualJournals struct {
 	ManualJournals []ManualJournal `json:"ManualJournals,omitempty" xml:"ManualJournal,omitempty"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (m *ManualJournals) convertDates() error {
-	var err error
-	for n := len(m.ManualJournals) - 1; n >= 0; n-- {
-		m.ManualJournals[n].Date, err = helpers.DotNetJSONTimeToRFC3339(m.ManualJournals[n].Date, false)
-		if err != nil {
-			return err
-		}
-		m.ManualJournals[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(m.ManualJournals[n].UpdatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalManualJournal(manualJournalResponseBytes []byte) (*ManualJournals, error) {
 	var manualJournalResponse *ManualJournals
 	err := json.Unmarshal(manualJournalResponseBytes, &manualJournalResponse)
@@ -74,16 +62,48 @@ func unmarshalManualJournal(manualJournalResponseBytes []byte) (*ManualJournals,
 		return nil, err
 	}
 
-	err = manualJournalResponse.convertDates()
-	if err != nil {
-		return nil, err
+	return manualJournalResponse, err
+}
+
+//Validate checks the manual journals against the rules Xero applies, including that the lines
+//of each journal balance, so mistakes are found before anything is sent
+func (m *ManualJournals) Validate() error {
+	v := &validator{}
+	for n := range m.ManualJournals {
+		m.ManualJournals[n].validate(v, index("ManualJournals", n))
 	}
+	return v.err()
+}
 
-	return manualJournalResponse, err
+func (m *ManualJournal) validate(v *validator, field string) {
+	if m.ManualJournalID == "" {
+		v.required(field+".Narration", m.Narration)
+	}
+	v.code(field+".LineAmountTypes", string(m.LineAmountTypes), m.LineAmountTypes.IsValid())
+
+	total := xerogolang.Decimal{}
+	for n := range m.JournalLines {
+		m.JournalLines[n].validate(v, index(field+".JournalLines", n))
+		total = total.Add(m.JournalLines[n].LineAmount)
+	}
+	//debits are positive and credits negative so a balanced journal adds up to zero
+	if !total.IsZero() {
+		v.add(field+".JournalLines", "must add up to zero but add up to %s", total)
+	}
 }
 
 //Create will create manualJournals given an ManualJournals struct
 func (m *ManualJournals) Create(provider *xerogolang.Provider, session goth.Session) (*ManualJournals, error) {
+	return m.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (m *ManualJournals) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*ManualJournals, error) {
+	err := m.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -94,7 +114,7 @@ func (m *ManualJournals) Create(provider *xerogolang.Provider, session goth.Sess
 		return nil, err
 	}
 
-	manualJournalResponseBytes, err := provider.Create(session, "ManualJournals", additionalHeaders, body)
+	manualJournalResponseBytes, err := provider.CreateCtx(ctx, session, "ManualJournals", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -105,6 +125,16 @@ func (m *ManualJournals) Create(provider *xerogolang.Provider, session goth.Sess
 //Update will update an manualJournal given an ManualJournals struct
 //This will only handle single manualJournal - you cannot update multiple manualJournals in a single call
 func (m *ManualJournals) Update(provider *xerogolang.Provider, session goth.Session) (*ManualJournals, error) {
+	return m.UpdateCtx(context.Background(), provider, session)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (m *ManualJournals) UpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*ManualJournals, error) {
+	err := m.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -115,7 +145,7 @@ func (m *ManualJournals) Update(provider *xerogolang.Provider, session goth.Sess
 		return nil, err
 	}
 
-	manualJournalResponseBytes, err := provider.Update(session, "ManualJournals/"+m.ManualJournals[0].ManualJournalID, additionalHeaders, body)
+	manualJournalResponseBytes, err := provider.UpdateCtx(ctx, session, "ManualJournals/"+m.ManualJournals[0].ManualJournalID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -123,11 +153,83 @@ func (m *ManualJournals) Update(provider *xerogolang.Provider, session goth.Sess
 	return unmarshalManualJournal(manualJournalResponseBytes)
 }
 
+//ManualJournalResult is what happened to one manual journal in a batch
+type ManualJournalResult struct {
+	BatchResult
+
+	// The manual journal as Xero saved it, or as it was sent if it was not saved
+	ManualJournal ManualJournal
+}
+
+//BatchCreate creates manual journals in as many requests as needed, with summarizeErrors=false so that
+//every valid manual journal is saved even if others are not. There is a result for each manual journal, in the same order
+func (m *ManualJournals) BatchCreate(provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]ManualJournalResult, error) {
+	return m.BatchCreateCtx(context.Background(), provider, session, options)
+}
+
+//BatchCreateCtx is BatchCreate with a context that can cancel the requests or set their deadline
+func (m *ManualJournals) BatchCreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]ManualJournalResult, error) {
+	return m.batch(ctx, provider, session, false, options)
+}
+
+//BatchUpdate updates any number of manual journals, unlike Update, in the same way as BatchCreate
+func (m *ManualJournals) BatchUpdate(provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]ManualJournalResult, error) {
+	return m.BatchUpdateCtx(context.Background(), provider, session, options)
+}
+
+//BatchUpdateCtx is BatchUpdate with a context that can cancel the requests or set their deadline
+func (m *ManualJournals) BatchUpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]ManualJournalResult, error) {
+	return m.batch(ctx, provider, session, true, options)
+}
+
+func (m *ManualJournals) batch(ctx context.Context, provider *xerogolang.Provider, session goth.Session, update bool, options BatchOptions) ([]ManualJournalResult, error) {
+	results := make([]ManualJournalResult, len(m.ManualJournals))
+	for n := range m.ManualJournals {
+		results[n].ManualJournal = m.ManualJournals[n]
+	}
+
+	sender := &batchSender{
+		collection: "ManualJournals",
+		count:      len(m.ManualJournals),
+		validate: func(v *validator, n int) {
+			m.ManualJournals[n].validate(v, index("ManualJournals", n))
+		},
+		marshal: func(n int) ([]byte, error) {
+			return xml.Marshal(m.ManualJournals[n])
+		},
+		receive: func(response []byte, indexes []int) ([]batchStatus, error) {
+			saved, err := unmarshalManualJournal(response)
+			if err != nil {
+				return nil, err
+			}
+			statuses := make([]batchStatus, len(saved.ManualJournals))
+			for k, manualJournal := range saved.ManualJournals {
+				if k < len(indexes) {
+					results[indexes[k]].ManualJournal = manualJournal
+				}
+				statuses[k] = batchStatus{StatusAttributeString: manualJournal.StatusAttributeString, ValidationErrors: manualJournal.ValidationErrors}
+			}
+			return statuses, nil
+		},
+	}
+
+	batchResults, err := sender.send(ctx, provider, session, update, options)
+	for n := range results {
+		results[n].BatchResult = batchResults[n]
+	}
+	return results, err
+}
+
 //FindManualJournalsModifiedSince will get all ManualJournals modified after a specified date.
 //These ManualJournals will not have details like line items by default
 //If you need details then then add a 'page' querystringParameter and get 100 ManualJournals at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindManualJournalsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*ManualJournals, error) {
+	return FindManualJournalsModifiedSinceCtx(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindManualJournalsModifiedSinceCtx is FindManualJournalsModifiedSince with a context that can cancel the request or set its deadline
+func FindManualJournalsModifiedSinceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*ManualJournals, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -136,7 +238,7 @@ func FindManualJournalsModifiedSince(provider *xerogolang.Provider, session goth
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	manualJournalResponseBytes, err := provider.Find(session, "ManualJournals", additionalHeaders, querystringParameters)
+	manualJournalResponseBytes, err := provider.FindCtx(ctx, session, "ManualJournals", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -148,16 +250,45 @@ func FindManualJournalsModifiedSince(provider *xerogolang.Provider, session goth
 //If you need details then then add a 'page' querystringParameter and get 100 ManualJournals at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindManualJournals(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*ManualJournals, error) {
-	return FindManualJournalsModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindManualJournalsCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindManualJournalsCtx is FindManualJournals with a context that can cancel the request or set its deadline
+func FindManualJournalsCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*ManualJournals, error) {
+	return FindManualJournalsModifiedSinceCtx(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//EachManualJournal calls fn with every manual journal modified since modifiedSince, fetching them 100 at a time
+//until Xero returns an empty page. Pass a zero time.Time to get all manual journals. where and order
+//querystringParameters are sent with every page. Return xerogolang.ErrStop from fn to stop early
+func EachManualJournal(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string, fn func(manualJournal ManualJournal) error) error {
+	return xerogolang.EachPage(ctx, querystringParameters, func(pageParameters map[string]string) (int, error) {
+		manualJournals, err := FindManualJournalsModifiedSinceCtx(ctx, provider, session, modifiedSince, pageParameters)
+		if err != nil {
+			return 0, err
+		}
+		for _, manualJournal := range manualJournals.ManualJournals {
+			err = fn(manualJournal)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return len(manualJournals.ManualJournals), nil
+	})
 }
 
 //FindManualJournal will get a single manualJournal - manualJournalID can be a GUID for an manualJournal or an manualJournal number
 func FindManualJournal(provider *xerogolang.Provider, session goth.Session, manualJournalID string) (*ManualJournals, error) {
+	return FindManualJournalCtx(context.Background(), provider, session, manualJournalID)
+}
+
+//FindManualJournalCtx is FindManualJournal with a context that can cancel the request or set its deadline
+func FindManualJournalCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, manualJournalID string) (*ManualJournals, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	manualJournalResponseBytes, err := provider.Find(session, "ManualJournals/"+manualJournalID, additionalHeaders, nil)
+	manualJournalResponseBytes, err := provider.FindCtx(ctx, session, "ManualJournals/"+manualJournalID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -169,20 +300,20 @@ func FindManualJournal(provider *xerogolang.Provider, session goth.Session, manu
 func GenerateExampleManualJournal() *ManualJournals {
 	lineItem := ManualJournalLine{
 		Description: "Importing & Exporting Services",
-		LineAmount:  395.00,
+		LineAmount:  xerogolang.MustParseDecimal("395.00"),
 		AccountCode: "200",
 	}
 
 	lineItem2 := ManualJournalLine{
 		Description: "Importing & Exporting Services",
-		LineAmount:  -395.00,
+		LineAmount:  xerogolang.MustParseDecimal("-395.00"),
 		AccountCode: "310",
 	}
 
 	manualJournal := ManualJournal{
 		Narration:       "Missed Importing & Exporting Invoice",
-		Date:            helpers.TodayRFC3339(),
-		LineAmountTypes: "Exclusive",
+		Date:            xerogolang.Today(),
+		LineAmountTypes: LineAmountTypeExclusive,
 		Status:          "DRAFT",
 		JournalLines:    []ManualJournalLine{},
 	}
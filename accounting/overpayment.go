@@ -1,12 +1,12 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -17,7 +17,7 @@ type Overpayment struct {
 	Type string `json:"Type,omitempty" xml:"Type,omitempty"`
 
 	// The date the overpayment is created YYYY-MM-DD
-	Date string `json:"DateString,omitempty" xml:"Date,omitempty"`
+	Date xerogolang.Date `json:"DateString,omitzero" xml:"Date,omitempty"`
 
 	// See Contacts
 	Contact Contact `json:"Contact" xml:"Contact"`
@@ -26,22 +26,22 @@ type Overpayment struct {
 	Status string `json:"Status,omitempty" xml:"Status,omitempty"`
 
 	// See Overpayment Line Amount Types
-	LineAmountTypes string `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
+	LineAmountTypes LineAmountType `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
 
 	// See Overpayment Line Items
 	LineItems []LineItem `json:"LineItems,omitempty" xml:"LineItems,omitempty"`
 
 	// The subtotal of the overpayment excluding taxes
-	SubTotal float32 `json:"SubTotal,omitempty" xml:"SubTotal,omitempty"`
+	SubTotal xerogolang.Decimal `json:"SubTotal,omitzero" xml:"SubTotal,omitempty"`
 
 	// The total tax on the overpayment
-	TotalTax float32 `json:"TotalTax,omitempty" xml:"TotalTax,omitempty"`
+	TotalTax xerogolang.Decimal `json:"TotalTax,omitzero" xml:"TotalTax,omitempty"`
 
 	// The total of the overpayment (subtotal + total tax)
-	Total float32 `json:"Total,omitempty" xml:"Total,omitempty"`
+	Total xerogolang.Decimal `json:"Total,omitzero" xml:"Total,omitempty"`
 
 	// UTC timestamp of last update to the overpayment
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"UpdatedDateUTC,omitempty"`
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"UpdatedDateUTC,omitempty"`
 
 	// Currency used for the overpayment
 	CurrencyCode string `json:"CurrencyCode,omitempty" xml:"CurrencyCode,omitempty"`
@@ -50,10 +50,10 @@ type Overpayment struct {
 	OverpaymentID string `json:"OverpaymentID,omitempty" xml:"OverpaymentID,omitempty"`
 
 	// The currency rate for a multicurrency overpayment. If no rate is specified, the XE.com day rate is used
-	CurrencyRate float32 `json:"CurrencyRate,omitempty" xml:"CurrencyRate,omitempty"`
+	CurrencyRate xerogolang.Decimal `json:"CurrencyRate,omitzero" xml:"CurrencyRate,omitempty"`
 
 	// The remaining credit balance on the overpayment
-	RemainingCredit float32 `json:"RemainingCredit,omitempty" xml:"RemainingCredit,omitempty"`
+	RemainingCredit xerogolang.Decimal `json:"RemainingCredit,omitzero" xml:"RemainingCredit,omitempty"`
 
 	// See Allocations
 	Allocations []Allocation `json:"Allocations,omitempty" xml:"Allocations,omitempty"`
@@ -70,20 +70,6 @@ type Overpayments struct {
 	Overpayments []Overpayment `json:"Overpayments" xml:"Overpayment"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (o *Overpayments) convertDates() error {
-	var err error
-	for n := len(o.Overpayments) - 1; n >= 0; n-- {
-		o.Overpayments[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(o.Overpayments[n].UpdatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalOverpayment(overpaymentResponseBytes []byte) (*Overpayments, error) {
 	var overpaymentResponse *Overpayments
 	err := json.Unmarshal(overpaymentResponseBytes, &overpaymentResponse)
@@ -91,11 +77,6 @@ func unmarshalOverpayment(overpaymentResponseBytes []byte) (*Overpayments, error
 		return nil, err
 	}
 
-	err = overpaymentResponse.convertDates()
-	if err != nil {
-		return nil, err
-	}
-
 	return overpaymentResponse, err
 }
 
@@ -104,6 +85,11 @@ func unmarshalOverpayment(overpaymentResponseBytes []byte) (*Overpayments, error
 //If you need details then add a 'page' querystringParameter and get 100 Overpayments at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindOverpaymentsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Overpayments, error) {
+	return FindOverpaymentsModifiedSinceCtx(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindOverpaymentsModifiedSinceCtx is FindOverpaymentsModifiedSince with a context that can cancel the request or set its deadline
+func FindOverpaymentsModifiedSinceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Overpayments, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -112,7 +98,7 @@ func FindOverpaymentsModifiedSince(provider *xerogolang.Provider, session goth.S
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	overpaymentResponseBytes, err := provider.Find(session, "Overpayments", additionalHeaders, querystringParameters)
+	overpaymentResponseBytes, err := provider.FindCtx(ctx, session, "Overpayments", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -124,16 +110,45 @@ func FindOverpaymentsModifiedSince(provider *xerogolang.Provider, session goth.S
 //If you need details then add a 'page' querystringParameter and get 100 Overpayments at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindOverpayments(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Overpayments, error) {
-	return FindOverpaymentsModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindOverpaymentsCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindOverpaymentsCtx is FindOverpayments with a context that can cancel the request or set its deadline
+func FindOverpaymentsCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Overpayments, error) {
+	return FindOverpaymentsModifiedSinceCtx(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//EachOverpayment calls fn with every overpayment modified since modifiedSince, fetching them 100 at a time
+//until Xero returns an empty page. Pass a zero time.Time to get all overpayments. where and order
+//querystringParameters are sent with every page. Return xerogolang.ErrStop from fn to stop early
+func EachOverpayment(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string, fn func(overpayment Overpayment) error) error {
+	return xerogolang.EachPage(ctx, querystringParameters, func(pageParameters map[string]string) (int, error) {
+		overpayments, err := FindOverpaymentsModifiedSinceCtx(ctx, provider, session, modifiedSince, pageParameters)
+		if err != nil {
+			return 0, err
+		}
+		for _, overpayment := range overpayments.Overpayments {
+			err = fn(overpayment)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return len(overpayments.Overpayments), nil
+	})
 }
 
 //FindOverpayment will get a single overpayment - overpaymentID can be a GUID for an overpayment or an overpayment number
 func FindOverpayment(provider *xerogolang.Provider, session goth.Session, overpaymentID string) (*Overpayments, error) {
+	return FindOverpaymentCtx(context.Background(), provider, session, overpaymentID)
+}
+
+//FindOverpaymentCtx is FindOverpayment with a context that can cancel the request or set its deadline
+func FindOverpaymentCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, overpaymentID string) (*Overpayments, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	overpaymentResponseBytes, err := provider.Find(session, "Overpayments/"+overpaymentID, additionalHeaders, nil)
+	overpaymentResponseBytes, err := provider.FindCtx(ctx, session, "Overpayments/"+overpaymentID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -144,6 +159,11 @@ func FindOverpayment(provider *xerogolang.Provider, session goth.Session, overpa
 //Allocate allocates an overpayment - to create an overpayment
 //use the bankTransactions endpoint.
 func (o *Overpayments) Allocate(provider *xerogolang.Provider, session goth.Session, allocations Allocations) (*Overpayments, error) {
+	return o.AllocateCtx(context.Background(), provider, session, allocations)
+}
+
+//AllocateCtx is Allocate with a context that can cancel the request or set its deadline
+func (o *Overpayments) AllocateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, allocations Allocations) (*Overpayments, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -154,7 +174,7 @@ func (o *Overpayments) Allocate(provider *xerogolang.Provider, session goth.Sess
 		return nil, err
 	}
 
-	overpaymentResponseBytes, err := provider.Create(session, "Overpayments/"+o.Overpayments[0].OverpaymentID+"/Allocations", additionalHeaders, body)
+	overpaymentResponseBytes, err := provider.CreateCtx(ctx, session, "Overpayments/"+o.Overpayments[0].OverpaymentID+"/Allocations", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
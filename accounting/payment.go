@@ -1,12 +1,12 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -23,13 +23,13 @@ type Payment struct {
 	Account *Account `json:"Account,omitempty" xml:"Account,omitempty"`
 
 	// Date the payment is being made (YYYY-MM-DD) e.g. 2009-09-06
-	Date string `json:"Date,omitempty" xml:"Date,omitempty"`
+	Date xerogolang.Date `json:"Date,omitzero" xml:"Date,omitempty"`
 
 	// Exchange rate when payment is received. Only used for non base currency invoices and credit notes e.g. 0.7500
-	CurrencyRate float32 `json:"CurrencyRate,omitempty" xml:"CurrencyRate,omitempty"`
+	CurrencyRate xerogolang.Decimal `json:"CurrencyRate,omitzero" xml:"CurrencyRate,omitempty"`
 
 	// The amount of the payment. Must be less than or equal to the outstanding amount owing on the invoice e.g. 200.00
-	Amount float32 `json:"Amount,omitempty" xml:"Amount,omitempty"`
+	Amount xerogolang.Decimal `json:"Amount,omitzero" xml:"Amount,omitempty"`
 
 	// An optional description for the payment e.g. Direct Debit
 	Reference string `json:"Reference,omitempty" xml:"Reference,omitempty"`
@@ -44,10 +44,25 @@ type Payment struct {
 	PaymentType string `json:"PaymentType,omitempty" xml:"-"`
 
 	// UTC timestamp of last update to the payment
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"-"`
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"-"`
 
 	// The Xero identifier for an Payment e.g. 297c2dc5-cc47-4afd-8ec8-74990b8761e9
 	PaymentID string `json:"PaymentID,omitempty" xml:"PaymentID,omitempty"`
+
+	// The bank account the payment is made to, when it is part of a batch payment
+	BankAccountNumber string `json:"BankAccountNumber,omitempty" xml:"BankAccountNumber,omitempty"`
+
+	// NZ only – shown on the payee's bank statement when the payment is part of a batch payment (max length = 12)
+	Particulars string `json:"Particulars,omitempty" xml:"Particulars,omitempty"`
+
+	// NZ only – shown on the payee's bank statement when the payment is part of a batch payment (max length = 12)
+	Code string `json:"Code,omitempty" xml:"Code,omitempty"`
+
+	// Shown on the payee's bank statement when the payment is part of a batch payment (max length = 18)
+	Details string `json:"Details,omitempty" xml:"Details,omitempty"`
+
+	// The Xero identifier of the batch payment the payment is part of
+	BatchPaymentID string `json:"BatchPaymentID,omitempty" xml:"-"`
 }
 
 //Payments is a collection of Payments
@@ -55,24 +70,6 @@ type Payments struct {
 	Payments []Payment `json:"Payments" xml:"Payment"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (p *Payments) convertDates() error {
-	var err error
-	for n := len(p.Payments) - 1; n >= 0; n-- {
-		p.Payments[n].Date, err = helpers.DotNetJSONTimeToRFC3339(p.Payments[n].Date, false)
-		if err != nil {
-			return err
-		}
-		p.Payments[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(p.Payments[n].UpdatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalPayment(paymentResponseBytes []byte) (*Payments, error) {
 	var paymentResponse *Payments
 	err := json.Unmarshal(paymentResponseBytes, &paymentResponse)
@@ -80,16 +77,16 @@ func unmarshalPayment(paymentResponseBytes []byte) (*Payments, error) {
 		return nil, err
 	}
 
-	err = paymentResponse.convertDates()
-	if err != nil {
-		return nil, err
-	}
-
 	return paymentResponse, err
 }
 
 //Create will create payments given an Payments struct
 func (p *Payments) Create(provider *xerogolang.Provider, session goth.Session) (*Payments, error) {
+	return p.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (p *Payments) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Payments, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -100,7 +97,7 @@ func (p *Payments) Create(provider *xerogolang.Provider, session goth.Session) (
 		return nil, err
 	}
 
-	paymentResponseBytes, err := provider.Create(session, "Payments", additionalHeaders, body)
+	paymentResponseBytes, err := provider.CreateCtx(ctx, session, "Payments", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -112,6 +109,11 @@ func (p *Payments) Create(provider *xerogolang.Provider, session goth.Session) (
 //This will only handle single payment - you cannot update multiple payments in a single call
 //Payments cannot be modified, only created and deleted.
 func (p *Payments) Update(provider *xerogolang.Provider, session goth.Session) (*Payments, error) {
+	return p.UpdateCtx(context.Background(), provider, session)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (p *Payments) UpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Payments, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -127,7 +129,7 @@ func (p *Payments) Update(provider *xerogolang.Provider, session goth.Session) (
 		return nil, err
 	}
 
-	paymentResponseBytes, err := provider.Update(session, "Payments/"+p.Payments[0].PaymentID, additionalHeaders, body)
+	paymentResponseBytes, err := provider.UpdateCtx(ctx, session, "Payments/"+p.Payments[0].PaymentID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -138,6 +140,11 @@ func (p *Payments) Update(provider *xerogolang.Provider, session goth.Session) (
 //FindPaymentsModifiedSince will get all payments modified after a specified date.
 //additional querystringParameters such as where, page, order can be added as a map
 func FindPaymentsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Payments, error) {
+	return FindPaymentsModifiedSinceCtx(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindPaymentsModifiedSinceCtx is FindPaymentsModifiedSince with a context that can cancel the request or set its deadline
+func FindPaymentsModifiedSinceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Payments, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -146,7 +153,7 @@ func FindPaymentsModifiedSince(provider *xerogolang.Provider, session goth.Sessi
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	paymentResponseBytes, err := provider.Find(session, "Payments", additionalHeaders, querystringParameters)
+	paymentResponseBytes, err := provider.FindCtx(ctx, session, "Payments", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -156,16 +163,45 @@ func FindPaymentsModifiedSince(provider *xerogolang.Provider, session goth.Sessi
 
 //FindPayments will get all payments.
 func FindPayments(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Payments, error) {
-	return FindPaymentsModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindPaymentsCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindPaymentsCtx is FindPayments with a context that can cancel the request or set its deadline
+func FindPaymentsCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Payments, error) {
+	return FindPaymentsModifiedSinceCtx(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//EachPayment calls fn with every payment modified since modifiedSince, fetching them 100 at a time
+//until Xero returns an empty page. Pass a zero time.Time to get all payments. where and order
+//querystringParameters are sent with every page. Return xerogolang.ErrStop from fn to stop early
+func EachPayment(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string, fn func(payment Payment) error) error {
+	return xerogolang.EachPage(ctx, querystringParameters, func(pageParameters map[string]string) (int, error) {
+		payments, err := FindPaymentsModifiedSinceCtx(ctx, provider, session, modifiedSince, pageParameters)
+		if err != nil {
+			return 0, err
+		}
+		for _, payment := range payments.Payments {
+			err = fn(payment)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return len(payments.Payments), nil
+	})
 }
 
 //FindPayment will get a single payment - paymentID must be a GUID for an payment
 func FindPayment(provider *xerogolang.Provider, session goth.Session, paymentID string) (*Payments, error) {
+	return FindPaymentCtx(context.Background(), provider, session, paymentID)
+}
+
+//FindPaymentCtx is FindPayment with a context that can cancel the request or set its deadline
+func FindPaymentCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, paymentID string) (*Payments, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	paymentResponseBytes, err := provider.Find(session, "Payments/"+paymentID, additionalHeaders, nil)
+	paymentResponseBytes, err := provider.FindCtx(ctx, session, "Payments/"+paymentID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -175,11 +211,16 @@ func FindPayment(provider *xerogolang.Provider, session goth.Session, paymentID
 
 //RemovePayment will get a single payment - paymentID must be a GUID for an payment
 func RemovePayment(provider *xerogolang.Provider, session goth.Session, paymentID string) (*Payments, error) {
+	return RemovePaymentCtx(context.Background(), provider, session, paymentID)
+}
+
+//RemovePaymentCtx is RemovePayment with a context that can cancel the request or set its deadline
+func RemovePaymentCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, paymentID string) (*Payments, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	paymentResponseBytes, err := provider.Remove(session, "Payments/"+paymentID, additionalHeaders)
+	paymentResponseBytes, err := provider.RemoveCtx(ctx, session, "Payments/"+paymentID, additionalHeaders)
 	if err != nil {
 		return nil, err
 	}
@@ -188,9 +229,9 @@ func RemovePayment(provider *xerogolang.Provider, session goth.Session, paymentI
 }
 
 //GenerateExamplePayment Creates an Example payment
-func GenerateExamplePayment(invoiceID string, amount float32) *Payments {
+func GenerateExamplePayment(invoiceID string, amount xerogolang.Decimal) *Payments {
 	payment := Payment{
-		Date:   helpers.TodayRFC3339(),
+		Date:   xerogolang.Today(),
 		Amount: amount,
 		Invoice: &Invoice{
 			InvoiceID: invoiceID,
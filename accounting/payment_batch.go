@@ -0,0 +1,272 @@
+package accounting
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"time"
+
+	"github.com/XeroAPI/xerogolang"
+	"github.com/markbates/goth"
+)
+
+//PaymentBatch is a batch payment. It pays, or receives payment of, several invoices at once with a single transaction on the bank statement
+type PaymentBatch struct {
+
+	// The bank account the batch payment is made from or to. Give its AccountID or Code
+	Account *Account `json:"Account,omitempty" xml:"Account,omitempty"`
+
+	// Date the batch payment is being made (YYYY-MM-DD) e.g. 2009-09-06
+	Date xerogolang.Date `json:"Date,omitzero" xml:"Date,omitempty"`
+
+	// Shown on the bank statement of the account the batch payment is made from (max length = 255)
+	Reference string `json:"Reference,omitempty" xml:"Reference,omitempty"`
+
+	// NZ only – shown on the bank statement of the account the batch payment is made from (max length = 12)
+	Particulars string `json:"Particulars,omitempty" xml:"Particulars,omitempty"`
+
+	// NZ only – shown on the bank statement of the account the batch payment is made from (max length = 12)
+	Code string `json:"Code,omitempty" xml:"Code,omitempty"`
+
+	// Non-NZ only – shown on the bank statement of the account the batch payment is made from (max length = 18)
+	Details string `json:"Details,omitempty" xml:"Details,omitempty"`
+
+	// UK only – shown on the payees' bank statements (max length = 18)
+	Narrative string `json:"Narrative,omitempty" xml:"Narrative,omitempty"`
+
+	// The payments of each invoice in the batch
+	Payments []Payment `json:"Payments" xml:"Payments>Payment"`
+
+	// The Xero identifier for a batch payment e.g. 297c2dc5-cc47-4afd-8ec8-74990b8761e9
+	BatchPaymentID string `json:"BatchPaymentID,omitempty" xml:"BatchPaymentID,omitempty"`
+
+	// PAYBATCH for bill payments or RECBATCH for sales invoice payments
+	Type PaymentBatchType `json:"Type,omitempty" xml:"-"`
+
+	// AUTHORISED or DELETED
+	Status PaymentBatchStatus `json:"Status,omitempty" xml:"Status,omitempty"`
+
+	// The total of the payments in the batch
+	TotalAmount xerogolang.Decimal `json:"TotalAmount,omitzero" xml:"-"`
+
+	// Whether the batch payment has been reconciled
+	IsReconciled bool `json:"IsReconciled,omitempty" xml:"-"`
+
+	// UTC timestamp of last update to the batch payment
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"-"`
+}
+
+//PaymentBatches is a collection of PaymentBatches. Xero calls them BatchPayments
+type PaymentBatches struct {
+	XMLName        xml.Name       `json:"-" xml:"BatchPayments"`
+	PaymentBatches []PaymentBatch `json:"BatchPayments" xml:"BatchPayment"`
+}
+
+//Total adds up the amounts of the payments in the batch. Use it to check a batch payment before it is
+//created, when Xero has not yet set TotalAmount
+func (b *PaymentBatch) Total() xerogolang.Decimal {
+	var total xerogolang.Decimal
+	for _, payment := range b.Payments {
+		total = total.Add(payment.Amount)
+	}
+	return total
+}
+
+func unmarshalPaymentBatch(paymentBatchResponseBytes []byte) (*PaymentBatches, error) {
+	var paymentBatchResponse *PaymentBatches
+	err := json.Unmarshal(paymentBatchResponseBytes, &paymentBatchResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return paymentBatchResponse, err
+}
+
+//Validate checks the batch payments against the rules Xero applies so mistakes are found before anything is sent
+func (b *PaymentBatches) Validate() error {
+	v := &validator{}
+	for n := range b.PaymentBatches {
+		b.PaymentBatches[n].validate(v, index("BatchPayments", n))
+	}
+	return v.err()
+}
+
+func (b *PaymentBatch) validate(v *validator, field string) {
+	if b.Account == nil || (b.Account.AccountID == "" && b.Account.Code == "") {
+		v.add(field+".Account", "is required")
+	}
+	if b.Date.IsZero() {
+		v.add(field+".Date", "is required")
+	}
+	if len(b.Payments) == 0 {
+		v.add(field+".Payments", "is required")
+	}
+	v.code(field+".Status", string(b.Status), b.Status.IsValid())
+	v.maxLength(field+".Reference", b.Reference, 255)
+	v.maxLength(field+".Particulars", b.Particulars, 12)
+	v.maxLength(field+".Code", b.Code, 12)
+	v.maxLength(field+".Details", b.Details, 18)
+	v.maxLength(field+".Narrative", b.Narrative, 18)
+	for n := range b.Payments {
+		payment := &b.Payments[n]
+		paymentField := index(field+".Payments", n)
+		if payment.Invoice == nil || (payment.Invoice.InvoiceID == "" && payment.Invoice.InvoiceNumber == "") {
+			v.add(paymentField+".Invoice", "is required")
+		}
+		if payment.Amount.Sign() <= 0 {
+			v.add(paymentField+".Amount", "must be more than zero")
+		}
+		v.maxLength(paymentField+".Particulars", payment.Particulars, 12)
+		v.maxLength(paymentField+".Code", payment.Code, 12)
+		v.maxLength(paymentField+".Reference", payment.Reference, 12)
+		v.maxLength(paymentField+".Details", payment.Details, 18)
+	}
+}
+
+//Create will create batch payments given a PaymentBatches struct
+func (b *PaymentBatches) Create(provider *xerogolang.Provider, session goth.Session) (*PaymentBatches, error) {
+	return b.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (b *PaymentBatches) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*PaymentBatches, error) {
+	err := b.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/xml",
+	}
+
+	body, err := xml.MarshalIndent(b, "  ", "	")
+	if err != nil {
+		return nil, err
+	}
+
+	paymentBatchResponseBytes, err := provider.CreateCtx(ctx, session, "BatchPayments", additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalPaymentBatch(paymentBatchResponseBytes)
+}
+
+//FindPaymentBatchesModifiedSince will get all batch payments modified after a specified date.
+//additional querystringParameters such as where and order can be added as a map
+func FindPaymentBatchesModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*PaymentBatches, error) {
+	return FindPaymentBatchesModifiedSinceCtx(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindPaymentBatchesModifiedSinceCtx is FindPaymentBatchesModifiedSince with a context that can cancel the request or set its deadline
+func FindPaymentBatchesModifiedSinceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*PaymentBatches, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	if !modifiedSince.Equal(dayZero) {
+		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
+	}
+
+	paymentBatchResponseBytes, err := provider.FindCtx(ctx, session, "BatchPayments", additionalHeaders, querystringParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalPaymentBatch(paymentBatchResponseBytes)
+}
+
+//FindPaymentBatches will get all batch payments.
+func FindPaymentBatches(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*PaymentBatches, error) {
+	return FindPaymentBatchesCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindPaymentBatchesCtx is FindPaymentBatches with a context that can cancel the request or set its deadline
+func FindPaymentBatchesCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*PaymentBatches, error) {
+	return FindPaymentBatchesModifiedSinceCtx(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//FindPaymentBatch will get a single batch payment - batchPaymentID must be a GUID for a batch payment
+func FindPaymentBatch(provider *xerogolang.Provider, session goth.Session, batchPaymentID string) (*PaymentBatches, error) {
+	return FindPaymentBatchCtx(context.Background(), provider, session, batchPaymentID)
+}
+
+//FindPaymentBatchCtx is FindPaymentBatch with a context that can cancel the request or set its deadline
+func FindPaymentBatchCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, batchPaymentID string) (*PaymentBatches, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	paymentBatchResponseBytes, err := provider.FindCtx(ctx, session, "BatchPayments/"+batchPaymentID, additionalHeaders, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalPaymentBatch(paymentBatchResponseBytes)
+}
+
+//RemovePaymentBatch will void a batch payment and the payments in it - batchPaymentID must be a GUID for a batch payment.
+//Xero keeps the batch payment with a Status of DELETED rather than removing it
+func RemovePaymentBatch(provider *xerogolang.Provider, session goth.Session, batchPaymentID string) (*PaymentBatches, error) {
+	return RemovePaymentBatchCtx(context.Background(), provider, session, batchPaymentID)
+}
+
+//RemovePaymentBatchCtx is RemovePaymentBatch with a context that can cancel the request or set its deadline
+func RemovePaymentBatchCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, batchPaymentID string) (*PaymentBatches, error) {
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/xml",
+	}
+
+	//a batch payment is deleted by updating its status so we send nothing else, not even an empty list of payments
+	paymentBatchToMarshal := struct {
+		XMLName        xml.Name           `xml:"BatchPayment"`
+		BatchPaymentID string             `xml:"BatchPaymentID"`
+		Status         PaymentBatchStatus `xml:"Status"`
+	}{
+		BatchPaymentID: batchPaymentID,
+		Status:         PaymentBatchStatusDeleted,
+	}
+
+	body, err := xml.MarshalIndent(paymentBatchToMarshal, "  ", "	")
+	if err != nil {
+		return nil, err
+	}
+
+	paymentBatchResponseBytes, err := provider.UpdateCtx(ctx, session, "BatchPayments/"+batchPaymentID, additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalPaymentBatch(paymentBatchResponseBytes)
+}
+
+//GenerateExamplePaymentBatch Creates an Example batch payment of two bills from the bank account with the code bankAccountCode
+func GenerateExamplePaymentBatch(bankAccountCode string, firstInvoiceID string, secondInvoiceID string) *PaymentBatches {
+	paymentBatch := PaymentBatch{
+		Account: &Account{
+			Code: bankAccountCode,
+		},
+		Date:      xerogolang.Today(),
+		Reference: "Supplier run",
+		Payments:  []Payment{},
+	}
+
+	for _, invoiceID := range []string{firstInvoiceID, secondInvoiceID} {
+		paymentBatch.Payments = append(paymentBatch.Payments, Payment{
+			Invoice: &Invoice{
+				InvoiceID: invoiceID,
+			},
+			Amount: xerogolang.MustParseDecimal("100.00"),
+		})
+	}
+
+	paymentBatchCollection := &PaymentBatches{
+		PaymentBatches: []PaymentBatch{},
+	}
+
+	paymentBatchCollection.PaymentBatches = append(paymentBatchCollection.PaymentBatches, paymentBatch)
+
+	return paymentBatchCollection
+}
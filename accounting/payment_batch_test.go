@@ -0,0 +1,114 @@
+package accounting
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XeroAPI/xerogolang"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PaymentBatch_Total(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	batch := &PaymentBatch{}
+	a.True(batch.Total().IsZero())
+
+	batch.Payments = []Payment{
+		{Amount: xerogolang.MustParseDecimal("120.00")},
+		{Amount: xerogolang.MustParseDecimal("80.50")},
+		{Amount: xerogolang.MustParseDecimal("0.01")},
+	}
+	a.Equal("200.51", batch.Total().String())
+
+	//Total does not use the TotalAmount Xero sets
+	batch.TotalAmount = xerogolang.MustParseDecimal("999.00")
+	a.Equal("200.51", batch.Total().String())
+}
+
+func Test_PaymentBatches_Validate(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	batches := GenerateExamplePaymentBatch("090", "i-1", "i-2")
+	a.NoError(batches.Validate())
+
+	batches.PaymentBatches = append(batches.PaymentBatches, PaymentBatch{
+		Account:   &Account{Name: "Business Bank"},
+		Status:    "VOIDED",
+		Reference: strings.Repeat("x", 256),
+		Details:   strings.Repeat("x", 19),
+		Payments: []Payment{
+			{Invoice: &Invoice{InvoiceNumber: "INV-1"}, Amount: xerogolang.MustParseDecimal("10.00")},
+			{Invoice: &Invoice{}, Amount: xerogolang.MustParseDecimal("-10.00")},
+		},
+	}, PaymentBatch{Account: &Account{AccountID: "a-1"}, Date: xerogolang.Today()})
+	a.Equal([]string{
+		"BatchPayments[1].Account",
+		"BatchPayments[1].Date",
+		"BatchPayments[1].Status",
+		"BatchPayments[1].Reference",
+		"BatchPayments[1].Details",
+		"BatchPayments[1].Payments[1].Invoice",
+		"BatchPayments[1].Payments[1].Amount",
+		"BatchPayments[2].Payments",
+	}, fields(batches.Validate()))
+}
+
+func Test_PaymentBatches_Create(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	response := `{"BatchPayments":[{"BatchPaymentID":"b-1","Type":"PAYBATCH","Status":"AUTHORISED","TotalAmount":200.5}]}`
+	mockAccounting([]string{response}, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		batches := &PaymentBatches{PaymentBatches: []PaymentBatch{{
+			Account: &Account{Code: "090"},
+			Date:    xerogolang.NewDate(2020, time.March, 2),
+			Payments: []Payment{
+				{Invoice: &Invoice{InvoiceID: "i-1"}, Amount: xerogolang.MustParseDecimal("200.50")},
+			},
+		}}}
+
+		created, err := batches.Create(provider, session)
+		a.NoError(err)
+		a.Len(created.PaymentBatches, 1)
+		a.Equal("b-1", created.PaymentBatches[0].BatchPaymentID)
+		a.Equal(PaymentBatchTypePayBatch, created.PaymentBatches[0].Type)
+		a.Equal("200.5", created.PaymentBatches[0].TotalAmount.String())
+
+		//the collection is still sent as Xero's BatchPayments
+		a.Len(*requests, 1)
+		a.True(strings.HasPrefix((*requests)[0], "PUT /BatchPayments "), (*requests)[0])
+		a.Contains((*requests)[0], "<BatchPayments>")
+		a.Contains((*requests)[0], "<BatchPayment>")
+		a.Contains((*requests)[0], "<Code>090</Code>")
+		a.Contains((*requests)[0], "<InvoiceID>i-1</InvoiceID>")
+		a.NotContains((*requests)[0], "PaymentBatch")
+	})
+}
+
+func Test_RemovePaymentBatch(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	response := `{"BatchPayments":[{"BatchPaymentID":"b-1","Status":"DELETED"}]}`
+	mockAccounting([]string{response}, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		removed, err := RemovePaymentBatch(provider, session, "b-1")
+		a.NoError(err)
+		a.Equal(PaymentBatchStatusDeleted, removed.PaymentBatches[0].Status)
+
+		//only the status is sent, not an empty list of payments that Xero would reject
+		a.Equal([]string{"POST /BatchPayments/b-1   <BatchPayment>\n  \t<BatchPaymentID>b-1</BatchPaymentID>\n  \t<Status>DELETED</Status>\n  </BatchPayment>"}, *requests)
+	})
+}
+
+func Test_Contact_BatchPayments(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	contacts, err := unmarshalContact([]byte(`{"Contacts":[{"ContactID":"c-1","BatchPayments":{"BankAccountNumber":"12-3456-7890123-00","BankAccountName":"Vanderlay","Details":"Latex"}}]}`))
+	a.NoError(err)
+	a.Equal(BatchPayment{BankAccountNumber: "12-3456-7890123-00", BankAccountName: "Vanderlay", Details: "Latex"}, contacts.Contacts[0].BatchPayments)
+}
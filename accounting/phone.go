@@ -12,3 +12,9 @@ type Phone struct {
 	// max length = 20
 	PhoneCountryCode string `json:"PhoneCountryCode,omitempty" xml:"PhoneCountryCode,omitempty"`
 }
+
+func (p *Phone) validate(v *validator, field string) {
+	v.maxLength(field+".PhoneNumber", p.PhoneNumber, 50)
+	v.maxLength(field+".PhoneAreaCode", p.PhoneAreaCode, 10)
+	v.maxLength(field+".PhoneCountryCode", p.PhoneCountryCode, 20)
+}
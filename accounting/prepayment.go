@@ -1,12 +1,12 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -17,7 +17,7 @@ type Prepayment struct {
 	Type string `json:"Type,omitempty" xml:"Type,omitempty"`
 
 	// The date the prepayment is created YYYY-MM-DD
-	Date string `json:"DateString,omitempty" xml:"Date,omitempty"`
+	Date xerogolang.Date `json:"DateString,omitzero" xml:"Date,omitempty"`
 
 	// See Contacts
 	Contact Contact `json:"Contact" xml:"Contact"`
@@ -26,22 +26,22 @@ type Prepayment struct {
 	Status string `json:"Status,omitempty" xml:"Status,omitempty"`
 
 	// See Prepayment Line Amount Types
-	LineAmountTypes string `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
+	LineAmountTypes LineAmountType `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
 
 	// See Prepayment Line Items
 	LineItems []LineItem `json:"LineItems,omitempty" xml:"LineItems,omitempty"`
 
 	// The subtotal of the prepayment excluding taxes
-	SubTotal float32 `json:"SubTotal,omitempty" xml:"SubTotal,omitempty"`
+	SubTotal xerogolang.Decimal `json:"SubTotal,omitzero" xml:"SubTotal,omitempty"`
 
 	// The total tax on the prepayment
-	TotalTax float32 `json:"TotalTax,omitempty" xml:"TotalTax,omitempty"`
+	TotalTax xerogolang.Decimal `json:"TotalTax,omitzero" xml:"TotalTax,omitempty"`
 
 	// The total of the prepayment(subtotal + total tax)
-	Total float32 `json:"Total,omitempty" xml:"Total,omitempty"`
+	Total xerogolang.Decimal `json:"Total,omitzero" xml:"Total,omitempty"`
 
 	// UTC timestamp of last update to the prepayment
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"UpdatedDateUTC,omitempty"`
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"UpdatedDateUTC,omitempty"`
 
 	// Currency used for the prepayment
 	CurrencyCode string `json:"CurrencyCode,omitempty" xml:"CurrencyCode,omitempty"`
@@ -50,10 +50,10 @@ type Prepayment struct {
 	PrepaymentID string `json:"PrepaymentID,omitempty" xml:"PrepaymentID,omitempty"`
 
 	// The currency rate for a multicurrency prepayment. If no rate is specified, the XE.com day rate is used
-	CurrencyRate float32 `json:"CurrencyRate,omitempty" xml:"CurrencyRate,omitempty"`
+	CurrencyRate xerogolang.Decimal `json:"CurrencyRate,omitzero" xml:"CurrencyRate,omitempty"`
 
 	// The remaining credit balance on the prepayment
-	RemainingCredit float32 `json:"RemainingCredit,omitempty" xml:"RemainingCredit,omitempty"`
+	RemainingCredit xerogolang.Decimal `json:"RemainingCredit,omitzero" xml:"RemainingCredit,omitempty"`
 
 	// See Allocations
 	Allocations []Allocation `json:"Allocations,omitempty" xml:"Allocations,omitempty"`
@@ -67,20 +67,6 @@ type Prepayments struct {
 	Prepayments []Prepayment `json:"Prepayments" xml:"Prepayment"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (p *Prepayments) convertDates() error {
-	var err error
-	for n := len(p.Prepayments) - 1; n >= 0; n-- {
-		p.Prepayments[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(p.Prepayments[n].UpdatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalPrepayment(prepaymentResponseBytes []byte) (*Prepayments, error) {
 	var prepaymentResponse *Prepayments
 	err := json.Unmarshal(prepaymentResponseBytes, &prepaymentResponse)
@@ -88,11 +74,6 @@ func unmarshalPrepayment(prepaymentResponseBytes []byte) (*Prepayments, error) {
 		return nil, err
 	}
 
-	err = prepaymentResponse.convertDates()
-	if err != nil {
-		return nil, err
-	}
-
 	return prepaymentResponse, err
 }
 
@@ -101,6 +82,11 @@ func unmarshalPrepayment(prepaymentResponseBytes []byte) (*Prepayments, error) {
 //If you need details then add a 'page' querystringParameter and get 100 Prepayments at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindPrepaymentsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Prepayments, error) {
+	return FindPrepaymentsModifiedSinceCtx(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindPrepaymentsModifiedSinceCtx is FindPrepaymentsModifiedSince with a context that can cancel the request or set its deadline
+func FindPrepaymentsModifiedSinceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Prepayments, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -109,7 +95,7 @@ func FindPrepaymentsModifiedSince(provider *xerogolang.Provider, session goth.Se
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	prepaymentResponseBytes, err := provider.Find(session, "Prepayments", additionalHeaders, querystringParameters)
+	prepaymentResponseBytes, err := provider.FindCtx(ctx, session, "Prepayments", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -121,16 +107,45 @@ func FindPrepaymentsModifiedSince(provider *xerogolang.Provider, session goth.Se
 //If you need details then add a 'page' querystringParameter and get 100 Prepayments at a time
 //additional querystringParameters such as where, page, order can be added as a map
 func FindPrepayments(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Prepayments, error) {
-	return FindPrepaymentsModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindPrepaymentsCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindPrepaymentsCtx is FindPrepayments with a context that can cancel the request or set its deadline
+func FindPrepaymentsCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Prepayments, error) {
+	return FindPrepaymentsModifiedSinceCtx(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//EachPrepayment calls fn with every prepayment modified since modifiedSince, fetching them 100 at a time
+//until Xero returns an empty page. Pass a zero time.Time to get all prepayments. where and order
+//querystringParameters are sent with every page. Return xerogolang.ErrStop from fn to stop early
+func EachPrepayment(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string, fn func(prepayment Prepayment) error) error {
+	return xerogolang.EachPage(ctx, querystringParameters, func(pageParameters map[string]string) (int, error) {
+		prepayments, err := FindPrepaymentsModifiedSinceCtx(ctx, provider, session, modifiedSince, pageParameters)
+		if err != nil {
+			return 0, err
+		}
+		for _, prepayment := range prepayments.Prepayments {
+			err = fn(prepayment)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return len(prepayments.Prepayments), nil
+	})
 }
 
 //FindPrepayment will get a single prepayment - prepaymentID can be a GUID for an prepayment or an prepayment number
 func FindPrepayment(provider *xerogolang.Provider, session goth.Session, prepaymentID string) (*Prepayments, error) {
+	return FindPrepaymentCtx(context.Background(), provider, session, prepaymentID)
+}
+
+//FindPrepaymentCtx is FindPrepayment with a context that can cancel the request or set its deadline
+func FindPrepaymentCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, prepaymentID string) (*Prepayments, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	prepaymentResponseBytes, err := provider.Find(session, "Prepayments/"+prepaymentID, additionalHeaders, nil)
+	prepaymentResponseBytes, err := provider.FindCtx(ctx, session, "Prepayments/"+prepaymentID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -141,6 +156,11 @@ func FindPrepayment(provider *xerogolang.Provider, session goth.Session, prepaym
 //Allocate allocates a prepayment - to create a prepayment
 //use the bankTransactions endpoint.
 func (p *Prepayments) Allocate(provider *xerogolang.Provider, session goth.Session, allocations Allocations) (*Prepayments, error) {
+	return p.AllocateCtx(context.Background(), provider, session, allocations)
+}
+
+//AllocateCtx is Allocate with a context that can cancel the request or set its deadline
+func (p *Prepayments) AllocateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, allocations Allocations) (*Prepayments, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -151,7 +171,7 @@ func (p *Prepayments) Allocate(provider *xerogolang.Provider, session goth.Sessi
 		return nil, err
 	}
 
-	prepaymentResponseBytes, err := provider.Create(session, "Prepayments/"+p.Prepayments[0].PrepaymentID+"/Allocations", additionalHeaders, body)
+	prepaymentResponseBytes, err := provider.CreateCtx(ctx, session, "Prepayments/"+p.Prepayments[0].PrepaymentID+"/Allocations", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -1,9 +1,11 @@
 package accounting
 
+import "github.com/XeroAPI/xerogolang"
+
 type Purchase struct {
 
 	// Unit Price of the item. By default UnitPrice is rounded to two decimal places. You can use 4 decimal places by adding the unitdp=4 querystring parameter to your request.
-	UnitPrice float32 `json:"UnitPrice,omitempty"`
+	UnitPrice xerogolang.Decimal `json:"UnitPrice,omitzero"`
 
 	// Default account code to be used for purchased/sale. Not applicable to the purchase details of tracked items
 	AccountCode string `json:"AccountCode,omitempty"`
@@ -12,5 +14,5 @@ type Purchase struct {
 	COGSAccountCode string `json:"COGSAccountCode,omitempty"`
 
 	// Used as an override if the default Tax Code for the selected <AccountCode> is not correct – see TaxTypes.
-	TaxType string `json:"TaxType,omitempty"`
+	TaxType TaxType `json:"TaxType,omitempty"`
 }
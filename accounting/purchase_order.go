@@ -1,12 +1,13 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
+	"io"
 	"time"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -20,13 +21,13 @@ type PurchaseOrder struct {
 	Contact Contact `json:"Contact" xml:"Contact"`
 
 	// Date purchase order was issued – YYYY-MM-DD. If the Date element is not specified then it will default to the current date based on the timezone setting of the organisation
-	Date string `json:"DateString,omitempty" xml:"Date,omitempty"`
+	Date xerogolang.Date `json:"DateString,omitzero" xml:"Date,omitempty"`
 
 	// Date the goods are to be delivered – YYYY-MM-DD
-	DeliveryDate string `json:"DeliveryDateString,omitempty" xml:"DeliveryDate,omitempty"`
+	DeliveryDate xerogolang.Date `json:"DeliveryDateString,omitzero" xml:"DeliveryDate,omitempty"`
 
 	// Line amounts are exclusive of tax by default if you don’t specify this element. See Line Amount Types
-	LineAmountTypes string `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
+	LineAmountTypes LineAmountType `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
 
 	// Unique alpha numeric code identifying purchase order (when missing will auto-generate from your Organisation Invoice Settings)
 	PurchaseOrderNumber string `json:"PurchaseOrderNumber,omitempty" xml:"PurchaseOrderNumber,omitempty"`
@@ -59,31 +60,37 @@ type PurchaseOrder struct {
 	DeliveryInstructions string `json:"DeliveryInstructions,omitempty" xml:"DeliveryInstructions,omitempty"`
 
 	// The date the goods are expected to arrive.
-	ExpectedArrivalDate string `json:"ExpectedArrivalDate,omitempty" xml:"ExpectedArrivalDate,omitempty"`
+	ExpectedArrivalDate xerogolang.Date `json:"ExpectedArrivalDate,omitzero" xml:"ExpectedArrivalDate,omitempty"`
 
 	// Xero generated unique identifier for purchase order
 	PurchaseOrderID string `json:"PurchaseOrderID,omitempty" xml:"PurchaseOrderID,omitempty"`
 
 	// The currency rate for a multicurrency purchase order. As no rate can be specified, the XE.com day rate is used.
-	CurrencyRate float32 `json:"CurrencyRate,omitempty" xml:"CurrencyRate,omitempty"`
+	CurrencyRate xerogolang.Decimal `json:"CurrencyRate,omitzero" xml:"CurrencyRate,omitempty"`
 
 	// Total of purchase order excluding taxes
-	SubTotal float32 `json:"SubTotal,omitempty" xml:"SubTotal,omitempty"`
+	SubTotal xerogolang.Decimal `json:"SubTotal,omitzero" xml:"SubTotal,omitempty"`
 
 	// Total tax on purchase order
-	TotalTax float32 `json:"TotalTax,omitempty" xml:"TotalTax,omitempty"`
+	TotalTax xerogolang.Decimal `json:"TotalTax,omitzero" xml:"TotalTax,omitempty"`
 
 	// Total of Purchase Order tax inclusive (i.e. SubTotal + TotalTax)
-	Total float32 `json:"Total,omitempty" xml:"Total,omitempty"`
+	Total xerogolang.Decimal `json:"Total,omitzero" xml:"Total,omitempty"`
 
 	// Total of discounts applied on the purchase order line items
-	TotalDiscount float32 `json:"TotalDiscount,omitempty" xml:"TotalDiscount,omitempty"`
+	TotalDiscount xerogolang.Decimal `json:"TotalDiscount,omitzero" xml:"TotalDiscount,omitempty"`
 
 	// boolean to indicate if a purchase order has an attachment
 	HasAttachments bool `json:"HasAttachments,omitempty" xml:"-"`
 
 	// Last modified date UTC format
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"-"`
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"-"`
+
+	// OK or ERROR when the purchase order was part of a batch sent with summarizeErrors=false
+	StatusAttributeString string `json:"StatusAttributeString,omitempty" xml:"-"`
+
+	// Why the purchase order could not be saved when StatusAttributeString is ERROR
+	ValidationErrors []xerogolang.ValidationError `json:"ValidationErrors,omitempty" xml:"-"`
 }
 
 //PurchaseOrders contains a collection of PurchaseOrders
@@ -91,20 +98,6 @@ type PurchaseOrders struct {
 	PurchaseOrders []PurchaseOrder `json:"PurchaseOrders" xml:"PurchaseOrder"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (p *PurchaseOrders) convertDates() error {
-	var err error
-	for n := len(p.PurchaseOrders) - 1; n >= 0; n-- {
-		p.PurchaseOrders[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(p.PurchaseOrders[n].UpdatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalPurchaseOrder(purchaseOrderResponseBytes []byte) (*PurchaseOrders, error) {
 	var purchaseOrderResponse *PurchaseOrders
 	err := json.Unmarshal(purchaseOrderResponseBytes, &purchaseOrderResponse)
@@ -112,16 +105,39 @@ func unmarshalPurchaseOrder(purchaseOrderResponseBytes []byte) (*PurchaseOrders,
 		return nil, err
 	}
 
-	err = purchaseOrderResponse.convertDates()
-	if err != nil {
-		return nil, err
+	return purchaseOrderResponse, err
+}
+
+//Validate checks the purchase orders against the rules Xero applies so mistakes are found before anything is sent
+func (p *PurchaseOrders) Validate() error {
+	v := &validator{}
+	for n := range p.PurchaseOrders {
+		p.PurchaseOrders[n].validate(v, index("PurchaseOrders", n))
 	}
+	return v.err()
+}
 
-	return purchaseOrderResponse, err
+func (p *PurchaseOrder) validate(v *validator, field string) {
+	v.code(field+".LineAmountTypes", string(p.LineAmountTypes), p.LineAmountTypes.IsValid())
+	v.maxLength(field+".DeliveryInstructions", p.DeliveryInstructions, 500)
+	p.Contact.validate(v, field+".Contact", p.PurchaseOrderID != "")
+	for n := range p.LineItems {
+		p.LineItems[n].validate(v, index(field+".LineItems", n), true)
+	}
 }
 
 //Create will create purchaseOrders given an PurchaseOrders struct
 func (p *PurchaseOrders) Create(provider *xerogolang.Provider, session goth.Session) (*PurchaseOrders, error) {
+	return p.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (p *PurchaseOrders) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*PurchaseOrders, error) {
+	err := p.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -132,7 +148,7 @@ func (p *PurchaseOrders) Create(provider *xerogolang.Provider, session goth.Sess
 		return nil, err
 	}
 
-	purchaseOrderResponseBytes, err := provider.Create(session, "PurchaseOrders", additionalHeaders, body)
+	purchaseOrderResponseBytes, err := provider.CreateCtx(ctx, session, "PurchaseOrders", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -143,6 +159,16 @@ func (p *PurchaseOrders) Create(provider *xerogolang.Provider, session goth.Sess
 //Update will update an purchaseOrder given an PurchaseOrders struct
 //This will only handle single purchaseOrder - you cannot update multiple purchaseOrders in a single call
 func (p *PurchaseOrders) Update(provider *xerogolang.Provider, session goth.Session) (*PurchaseOrders, error) {
+	return p.UpdateCtx(context.Background(), provider, session)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (p *PurchaseOrders) UpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*PurchaseOrders, error) {
+	err := p.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -153,7 +179,7 @@ func (p *PurchaseOrders) Update(provider *xerogolang.Provider, session goth.Sess
 		return nil, err
 	}
 
-	purchaseOrderResponseBytes, err := provider.Update(session, "PurchaseOrders/"+p.PurchaseOrders[0].PurchaseOrderID, additionalHeaders, body)
+	purchaseOrderResponseBytes, err := provider.UpdateCtx(ctx, session, "PurchaseOrders/"+p.PurchaseOrders[0].PurchaseOrderID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -161,10 +187,82 @@ func (p *PurchaseOrders) Update(provider *xerogolang.Provider, session goth.Sess
 	return unmarshalPurchaseOrder(purchaseOrderResponseBytes)
 }
 
+//PurchaseOrderResult is what happened to one purchase order in a batch
+type PurchaseOrderResult struct {
+	BatchResult
+
+	// The purchase order as Xero saved it, or as it was sent if it was not saved
+	PurchaseOrder PurchaseOrder
+}
+
+//BatchCreate creates purchase orders in as many requests as needed, with summarizeErrors=false so that
+//every valid purchase order is saved even if others are not. There is a result for each purchase order, in the same order
+func (p *PurchaseOrders) BatchCreate(provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]PurchaseOrderResult, error) {
+	return p.BatchCreateCtx(context.Background(), provider, session, options)
+}
+
+//BatchCreateCtx is BatchCreate with a context that can cancel the requests or set their deadline
+func (p *PurchaseOrders) BatchCreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]PurchaseOrderResult, error) {
+	return p.batch(ctx, provider, session, false, options)
+}
+
+//BatchUpdate updates any number of purchase orders, unlike Update, in the same way as BatchCreate
+func (p *PurchaseOrders) BatchUpdate(provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]PurchaseOrderResult, error) {
+	return p.BatchUpdateCtx(context.Background(), provider, session, options)
+}
+
+//BatchUpdateCtx is BatchUpdate with a context that can cancel the requests or set their deadline
+func (p *PurchaseOrders) BatchUpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]PurchaseOrderResult, error) {
+	return p.batch(ctx, provider, session, true, options)
+}
+
+func (p *PurchaseOrders) batch(ctx context.Context, provider *xerogolang.Provider, session goth.Session, update bool, options BatchOptions) ([]PurchaseOrderResult, error) {
+	results := make([]PurchaseOrderResult, len(p.PurchaseOrders))
+	for n := range p.PurchaseOrders {
+		results[n].PurchaseOrder = p.PurchaseOrders[n]
+	}
+
+	sender := &batchSender{
+		collection: "PurchaseOrders",
+		count:      len(p.PurchaseOrders),
+		validate: func(v *validator, n int) {
+			p.PurchaseOrders[n].validate(v, index("PurchaseOrders", n))
+		},
+		marshal: func(n int) ([]byte, error) {
+			return xml.Marshal(p.PurchaseOrders[n])
+		},
+		receive: func(response []byte, indexes []int) ([]batchStatus, error) {
+			saved, err := unmarshalPurchaseOrder(response)
+			if err != nil {
+				return nil, err
+			}
+			statuses := make([]batchStatus, len(saved.PurchaseOrders))
+			for k, purchaseOrder := range saved.PurchaseOrders {
+				if k < len(indexes) {
+					results[indexes[k]].PurchaseOrder = purchaseOrder
+				}
+				statuses[k] = batchStatus{StatusAttributeString: purchaseOrder.StatusAttributeString, ValidationErrors: purchaseOrder.ValidationErrors}
+			}
+			return statuses, nil
+		},
+	}
+
+	batchResults, err := sender.send(ctx, provider, session, update, options)
+	for n := range results {
+		results[n].BatchResult = batchResults[n]
+	}
+	return results, err
+}
+
 //FindPurchaseOrdersModifiedSince will get all PurchaseOrders modified after a specified date.
 //Paging is enforced by default. 100 purchase orders are returned per page.
 //additional querystringParameters such as page, order, status, DateFrom & DateTo can be added as a map
 func FindPurchaseOrdersModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*PurchaseOrders, error) {
+	return FindPurchaseOrdersModifiedSinceCtx(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindPurchaseOrdersModifiedSinceCtx is FindPurchaseOrdersModifiedSince with a context that can cancel the request or set its deadline
+func FindPurchaseOrdersModifiedSinceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*PurchaseOrders, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -173,7 +271,7 @@ func FindPurchaseOrdersModifiedSince(provider *xerogolang.Provider, session goth
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	purchaseOrderResponseBytes, err := provider.Find(session, "PurchaseOrders", additionalHeaders, querystringParameters)
+	purchaseOrderResponseBytes, err := provider.FindCtx(ctx, session, "PurchaseOrders", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -184,16 +282,45 @@ func FindPurchaseOrdersModifiedSince(provider *xerogolang.Provider, session goth
 //FindPurchaseOrders will get all PurchaseOrders. Paging is enforced by default. 100 purchase orders are returned per page.
 //additional querystringParameters such as page, order, status, DateFrom & DateTo can be added as a map
 func FindPurchaseOrders(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*PurchaseOrders, error) {
-	return FindPurchaseOrdersModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindPurchaseOrdersCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindPurchaseOrdersCtx is FindPurchaseOrders with a context that can cancel the request or set its deadline
+func FindPurchaseOrdersCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*PurchaseOrders, error) {
+	return FindPurchaseOrdersModifiedSinceCtx(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//EachPurchaseOrder calls fn with every purchase order modified since modifiedSince, fetching them 100 at a time
+//until Xero returns an empty page. Pass a zero time.Time to get all purchase orders. where and order
+//querystringParameters are sent with every page. Return xerogolang.ErrStop from fn to stop early
+func EachPurchaseOrder(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string, fn func(purchaseOrder PurchaseOrder) error) error {
+	return xerogolang.EachPage(ctx, querystringParameters, func(pageParameters map[string]string) (int, error) {
+		purchaseOrders, err := FindPurchaseOrdersModifiedSinceCtx(ctx, provider, session, modifiedSince, pageParameters)
+		if err != nil {
+			return 0, err
+		}
+		for _, purchaseOrder := range purchaseOrders.PurchaseOrders {
+			err = fn(purchaseOrder)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return len(purchaseOrders.PurchaseOrders), nil
+	})
 }
 
 //FindPurchaseOrder will get a single purchaseOrder - purchaseOrderID can be a GUID for an purchaseOrder or an purchaseOrder number
 func FindPurchaseOrder(provider *xerogolang.Provider, session goth.Session, purchaseOrderID string) (*PurchaseOrders, error) {
+	return FindPurchaseOrderCtx(context.Background(), provider, session, purchaseOrderID)
+}
+
+//FindPurchaseOrderCtx is FindPurchaseOrder with a context that can cancel the request or set its deadline
+func FindPurchaseOrderCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, purchaseOrderID string) (*PurchaseOrders, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	purchaseOrderResponseBytes, err := provider.Find(session, "PurchaseOrders/"+purchaseOrderID, additionalHeaders, nil)
+	purchaseOrderResponseBytes, err := provider.FindCtx(ctx, session, "PurchaseOrders/"+purchaseOrderID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -201,12 +328,27 @@ func FindPurchaseOrder(provider *xerogolang.Provider, session goth.Session, purc
 	return unmarshalPurchaseOrder(purchaseOrderResponseBytes)
 }
 
+//FindPurchaseOrderPDF will get a purchase order rendered as a PDF, as Xero would print or email it. The PDF is
+//streamed from Xero as it is read so the caller must close it
+func FindPurchaseOrderPDF(provider *xerogolang.Provider, session goth.Session, purchaseOrderID string) (io.ReadCloser, error) {
+	return FindPurchaseOrderPDFCtx(context.Background(), provider, session, purchaseOrderID)
+}
+
+//FindPurchaseOrderPDFCtx is FindPurchaseOrderPDF with a context that can cancel the request or set its deadline
+func FindPurchaseOrderPDFCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, purchaseOrderID string) (io.ReadCloser, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/pdf",
+	}
+
+	return provider.DownloadCtx(ctx, session, "PurchaseOrders/"+purchaseOrderID, additionalHeaders, nil)
+}
+
 //GenerateExamplePurchaseOrder Creates an Example purchaseOrder
 func GenerateExamplePurchaseOrder(contactID string) *PurchaseOrders {
 	lineItem := LineItem{
 		Description: "Importing & Exporting Services",
-		Quantity:    1.00,
-		UnitAmount:  395.00,
+		Quantity:    xerogolang.MustParseDecimal("1.00"),
+		UnitAmount:  xerogolang.MustParseDecimal("395.00"),
 		AccountCode: "200",
 	}
 
@@ -214,8 +356,8 @@ func GenerateExamplePurchaseOrder(contactID string) *PurchaseOrders {
 		Contact: Contact{
 			ContactID: contactID,
 		},
-		Date:            helpers.TodayRFC3339(),
-		LineAmountTypes: "Exclusive",
+		Date:            xerogolang.Today(),
+		LineAmountTypes: LineAmountTypeExclusive,
 		LineItems:       []LineItem{},
 	}
 
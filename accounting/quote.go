@@ -0,0 +1,453 @@
+package accounting
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/XeroAPI/xerogolang"
+	"github.com/markbates/goth"
+)
+
+//Quote is an offer to a customer of goods or services at a price, which becomes an invoice once it is accepted
+type Quote struct {
+
+	// See Contacts
+	Contact Contact `json:"Contact" xml:"Contact"`
+
+	// See LineItems
+	LineItems []LineItem `json:"LineItems" xml:"LineItems>LineItem"`
+
+	// Date quote was issued – YYYY-MM-DD
+	Date xerogolang.Date `json:"DateString,omitzero" xml:"Date,omitempty"`
+
+	// Date the quote expires – YYYY-MM-DD
+	ExpiryDate xerogolang.Date `json:"ExpiryDateString,omitzero" xml:"ExpiryDate,omitempty"`
+
+	// See Quote Status Codes
+	Status QuoteStatus `json:"Status,omitempty" xml:"Status,omitempty"`
+
+	// Line amounts are exclusive of tax by default if you don’t specify this element. See Line Amount Types
+	LineAmountTypes LineAmountType `json:"LineAmountTypes,omitempty" xml:"LineAmountTypes,omitempty"`
+
+	// Unique alpha numeric code identifying quote (when missing will auto-generate from your Organisation Invoice Settings)
+	QuoteNumber string `json:"QuoteNumber,omitempty" xml:"QuoteNumber,omitempty"`
+
+	// Additional reference number (max length = 4000)
+	Reference string `json:"Reference,omitempty" xml:"Reference,omitempty"`
+
+	// The title of the quote (max length = 100)
+	Title string `json:"Title,omitempty" xml:"Title,omitempty"`
+
+	// The summary of the quote (max length = 3000)
+	Summary string `json:"Summary,omitempty" xml:"Summary,omitempty"`
+
+	// The terms of the quote (max length = 4000)
+	Terms string `json:"Terms,omitempty" xml:"Terms,omitempty"`
+
+	// See BrandingThemes
+	BrandingThemeID string `json:"BrandingThemeID,omitempty" xml:"BrandingThemeID,omitempty"`
+
+	// The currency that quote has been raised in (see Currencies)
+	CurrencyCode string `json:"CurrencyCode,omitempty" xml:"CurrencyCode,omitempty"`
+
+	// The currency rate for a multicurrency quote
+	CurrencyRate xerogolang.Decimal `json:"CurrencyRate,omitzero" xml:"CurrencyRate,omitempty"`
+
+	// Xero generated unique identifier for quote
+	QuoteID string `json:"QuoteID,omitempty" xml:"QuoteID,omitempty"`
+
+	// Total of quote excluding taxes
+	SubTotal xerogolang.Decimal `json:"SubTotal,omitzero" xml:"SubTotal,omitempty"`
+
+	// Total tax on quote
+	TotalTax xerogolang.Decimal `json:"TotalTax,omitzero" xml:"TotalTax,omitempty"`
+
+	// Total of quote tax inclusive (i.e. SubTotal + TotalTax)
+	Total xerogolang.Decimal `json:"Total,omitzero" xml:"Total,omitempty"`
+
+	// Total of discounts applied on the quote line items
+	TotalDiscount xerogolang.Decimal `json:"TotalDiscount,omitzero" xml:"-"`
+
+	// Last modified date UTC format
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"-"`
+
+	// OK or ERROR when the quote was part of a batch sent with summarizeErrors=false
+	StatusAttributeString string `json:"StatusAttributeString,omitempty" xml:"-"`
+
+	// Why the quote could not be saved when StatusAttributeString is ERROR
+	ValidationErrors []xerogolang.ValidationError `json:"ValidationErrors,omitempty" xml:"-"`
+}
+
+//Quotes contains a collection of Quotes
+type Quotes struct {
+	Quotes []Quote `json:"Quotes" xml:"Quote"`
+}
+
+//quoteTransitions lists the statuses a quote in each status can be moved to
+var quoteTransitions = map[QuoteStatus][]QuoteStatus{
+	QuoteStatusDraft:    {QuoteStatusSent, QuoteStatusDeleted},
+	QuoteStatusSent:     {QuoteStatusAccepted, QuoteStatusDeclined, QuoteStatusDeleted},
+	QuoteStatusAccepted: {QuoteStatusInvoiced, QuoteStatusSent, QuoteStatusDeleted},
+	QuoteStatusDeclined: {QuoteStatusSent, QuoteStatusDeleted},
+	QuoteStatusInvoiced: {QuoteStatusAccepted},
+}
+
+//CanBecome reports whether a quote in status s can be moved to next. A quote with no status is a new draft
+func (s QuoteStatus) CanBecome(next QuoteStatus) bool {
+	if s == "" {
+		s = QuoteStatusDraft
+	}
+	if s == next {
+		return true
+	}
+	for _, allowed := range quoteTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+//SetStatus moves the quote to status, or returns an error if Xero would not allow the change.
+//Call Update to save the change
+func (q *Quote) SetStatus(status QuoteStatus) error {
+	if !status.IsValid() {
+		return fmt.Errorf("%q is not a valid QuoteStatus", status)
+	}
+	if !q.Status.CanBecome(status) {
+		current := q.Status
+		if current == "" {
+			current = QuoteStatusDraft
+		}
+		return fmt.Errorf("a %s quote cannot become %s", current, status)
+	}
+	q.Status = status
+	return nil
+}
+
+//ToInvoice returns a draft sales invoice for the contact, line items and currency of the quote. The quote
+//number is used as the invoice reference. Mark the quote as INVOICED with SetStatus once the invoice is created
+func (q *Quote) ToInvoice() Invoice {
+	lineItems := make([]LineItem, len(q.LineItems))
+	copy(lineItems, q.LineItems)
+	for n := range lineItems {
+		lineItems[n].LineItemID = ""
+	}
+
+	reference := q.Reference
+	if q.QuoteNumber != "" {
+		reference = q.QuoteNumber
+	}
+
+	return Invoice{
+		Type:            InvoiceTypeAccRec,
+		Status:          InvoiceStatusDraft,
+		Contact:         Contact{ContactID: q.Contact.ContactID, Name: q.Contact.Name},
+		LineItems:       lineItems,
+		LineAmountTypes: q.LineAmountTypes,
+		Reference:       reference,
+		BrandingThemeID: q.BrandingThemeID,
+		CurrencyCode:    q.CurrencyCode,
+	}
+}
+
+//ToInvoices returns a draft sales invoice for each quote, in the same order
+func (q *Quotes) ToInvoices() *Invoices {
+	invoices := &Invoices{
+		Invoices: make([]Invoice, len(q.Quotes)),
+	}
+	for n := range q.Quotes {
+		invoices.Invoices[n] = q.Quotes[n].ToInvoice()
+	}
+	return invoices
+}
+
+func unmarshalQuote(quoteResponseBytes []byte) (*Quotes, error) {
+	var quoteResponse *Quotes
+	err := json.Unmarshal(quoteResponseBytes, &quoteResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return quoteResponse, err
+}
+
+//Validate checks the quotes against the rules Xero applies so mistakes are found before anything is sent
+func (q *Quotes) Validate() error {
+	v := &validator{}
+	for n := range q.Quotes {
+		q.Quotes[n].validate(v, index("Quotes", n))
+	}
+	return v.err()
+}
+
+func (q *Quote) validate(v *validator, field string) {
+	if q.QuoteID == "" && q.Date.IsZero() {
+		v.add(field+".Date", "is required")
+	}
+	v.code(field+".Status", string(q.Status), q.Status.IsValid())
+	v.code(field+".LineAmountTypes", string(q.LineAmountTypes), q.LineAmountTypes.IsValid())
+	v.maxLength(field+".QuoteNumber", q.QuoteNumber, 255)
+	v.maxLength(field+".Reference", q.Reference, 4000)
+	v.maxLength(field+".Title", q.Title, 100)
+	v.maxLength(field+".Summary", q.Summary, 3000)
+	v.maxLength(field+".Terms", q.Terms, 4000)
+	q.Contact.validate(v, field+".Contact", q.QuoteID != "")
+	for n := range q.LineItems {
+		q.LineItems[n].validate(v, index(field+".LineItems", n), true)
+	}
+}
+
+//Create will create quotes given a Quotes struct
+func (q *Quotes) Create(provider *xerogolang.Provider, session goth.Session) (*Quotes, error) {
+	return q.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (q *Quotes) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Quotes, error) {
+	err := q.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/xml",
+	}
+
+	body, err := xml.MarshalIndent(q, "  ", "	")
+	if err != nil {
+		return nil, err
+	}
+
+	quoteResponseBytes, err := provider.CreateCtx(ctx, session, "Quotes", additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalQuote(quoteResponseBytes)
+}
+
+//Update will update a quote given a Quotes struct
+//This will only handle single quote - you cannot update multiple quotes in a single call
+func (q *Quotes) Update(provider *xerogolang.Provider, session goth.Session) (*Quotes, error) {
+	return q.UpdateCtx(context.Background(), provider, session)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (q *Quotes) UpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Quotes, error) {
+	err := q.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/xml",
+	}
+
+	body, err := xml.MarshalIndent(q, "  ", "	")
+	if err != nil {
+		return nil, err
+	}
+
+	quoteResponseBytes, err := provider.UpdateCtx(ctx, session, "Quotes/"+q.Quotes[0].QuoteID, additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalQuote(quoteResponseBytes)
+}
+
+//QuoteResult is what happened to one quote in a batch
+type QuoteResult struct {
+	BatchResult
+
+	// The quote as Xero saved it, or as it was sent if it was not saved
+	Quote Quote
+}
+
+//BatchCreate creates quotes in as many requests as needed, with summarizeErrors=false so that
+//every valid quote is saved even if others are not. There is a result for each quote, in the same order
+func (q *Quotes) BatchCreate(provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]QuoteResult, error) {
+	return q.BatchCreateCtx(context.Background(), provider, session, options)
+}
+
+//BatchCreateCtx is BatchCreate with a context that can cancel the requests or set their deadline
+func (q *Quotes) BatchCreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]QuoteResult, error) {
+	return q.batch(ctx, provider, session, false, options)
+}
+
+//BatchUpdate updates any number of quotes, unlike Update, in the same way as BatchCreate
+func (q *Quotes) BatchUpdate(provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]QuoteResult, error) {
+	return q.BatchUpdateCtx(context.Background(), provider, session, options)
+}
+
+//BatchUpdateCtx is BatchUpdate with a context that can cancel the requests or set their deadline
+func (q *Quotes) BatchUpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, options BatchOptions) ([]QuoteResult, error) {
+	return q.batch(ctx, provider, session, true, options)
+}
+
+func (q *Quotes) batch(ctx context.Context, provider *xerogolang.Provider, session goth.Session, update bool, options BatchOptions) ([]QuoteResult, error) {
+	results := make([]QuoteResult, len(q.Quotes))
+	for n := range q.Quotes {
+		results[n].Quote = q.Quotes[n]
+	}
+
+	sender := &batchSender{
+		collection: "Quotes",
+		count:      len(q.Quotes),
+		validate: func(v *validator, n int) {
+			q.Quotes[n].validate(v, index("Quotes", n))
+		},
+		marshal: func(n int) ([]byte, error) {
+			return xml.Marshal(q.Quotes[n])
+		},
+		receive: func(response []byte, indexes []int) ([]batchStatus, error) {
+			saved, err := unmarshalQuote(response)
+			if err != nil {
+				return nil, err
+			}
+			statuses := make([]batchStatus, len(saved.Quotes))
+			for k, quote := range saved.Quotes {
+				if k < len(indexes) {
+					results[indexes[k]].Quote = quote
+				}
+				statuses[k] = batchStatus{StatusAttributeString: quote.StatusAttributeString, ValidationErrors: quote.ValidationErrors}
+			}
+			return statuses, nil
+		},
+	}
+
+	batchResults, err := sender.send(ctx, provider, session, update, options)
+	for n := range results {
+		results[n].BatchResult = batchResults[n]
+	}
+	return results, err
+}
+
+//FindQuotesModifiedSince will get all Quotes modified after a specified date.
+//Paging is enforced by default. 100 quotes are returned per page.
+//additional querystringParameters such as page, order, Status, ContactID, QuoteNumber, DateFrom, DateTo,
+//ExpiryDateFrom & ExpiryDateTo can be added as a map
+func FindQuotesModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Quotes, error) {
+	return FindQuotesModifiedSinceCtx(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindQuotesModifiedSinceCtx is FindQuotesModifiedSince with a context that can cancel the request or set its deadline
+func FindQuotesModifiedSinceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Quotes, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	if !modifiedSince.Equal(dayZero) {
+		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
+	}
+
+	quoteResponseBytes, err := provider.FindCtx(ctx, session, "Quotes", additionalHeaders, querystringParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalQuote(quoteResponseBytes)
+}
+
+//FindQuotes will get all Quotes. Paging is enforced by default. 100 quotes are returned per page.
+//additional querystringParameters such as page, order, Status, ContactID, QuoteNumber, DateFrom, DateTo,
+//ExpiryDateFrom & ExpiryDateTo can be added as a map
+func FindQuotes(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Quotes, error) {
+	return FindQuotesCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindQuotesCtx is FindQuotes with a context that can cancel the request or set its deadline
+func FindQuotesCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Quotes, error) {
+	return FindQuotesModifiedSinceCtx(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//EachQuote calls fn with every quote modified since modifiedSince, fetching them 100 at a time
+//until Xero returns an empty page. Pass a zero time.Time to get all quotes. Other
+//querystringParameters are sent with every page. Return xerogolang.ErrStop from fn to stop early
+func EachQuote(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string, fn func(quote Quote) error) error {
+	return xerogolang.EachPage(ctx, querystringParameters, func(pageParameters map[string]string) (int, error) {
+		quotes, err := FindQuotesModifiedSinceCtx(ctx, provider, session, modifiedSince, pageParameters)
+		if err != nil {
+			return 0, err
+		}
+		for _, quote := range quotes.Quotes {
+			err = fn(quote)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return len(quotes.Quotes), nil
+	})
+}
+
+//FindQuote will get a single quote - quoteID must be the GUID of a quote
+func FindQuote(provider *xerogolang.Provider, session goth.Session, quoteID string) (*Quotes, error) {
+	return FindQuoteCtx(context.Background(), provider, session, quoteID)
+}
+
+//FindQuoteCtx is FindQuote with a context that can cancel the request or set its deadline
+func FindQuoteCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, quoteID string) (*Quotes, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	quoteResponseBytes, err := provider.FindCtx(ctx, session, "Quotes/"+quoteID, additionalHeaders, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalQuote(quoteResponseBytes)
+}
+
+//FindQuotePDF will get a quote rendered as a PDF, as Xero would print or email it. The PDF is
+//streamed from Xero as it is read so the caller must close it
+func FindQuotePDF(provider *xerogolang.Provider, session goth.Session, quoteID string) (io.ReadCloser, error) {
+	return FindQuotePDFCtx(context.Background(), provider, session, quoteID)
+}
+
+//FindQuotePDFCtx is FindQuotePDF with a context that can cancel the request or set its deadline
+func FindQuotePDFCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, quoteID string) (io.ReadCloser, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/pdf",
+	}
+
+	return provider.DownloadCtx(ctx, session, "Quotes/"+quoteID, additionalHeaders, nil)
+}
+
+//GenerateExampleQuote Creates an Example quote
+func GenerateExampleQuote(contactID string) *Quotes {
+	lineItem := LineItem{
+		Description: "Consulting services as agreed",
+		Quantity:    xerogolang.MustParseDecimal("10.00"),
+		UnitAmount:  xerogolang.MustParseDecimal("100.00"),
+		AccountCode: "200",
+	}
+
+	quote := Quote{
+		Contact: Contact{
+			ContactID: contactID,
+		},
+		Date:            xerogolang.Today(),
+		ExpiryDate:      xerogolang.DateOf(time.Now().Add(720 * time.Hour)),
+		Title:           "Consulting",
+		LineAmountTypes: LineAmountTypeExclusive,
+		LineItems:       []LineItem{},
+	}
+
+	quote.LineItems = append(quote.LineItems, lineItem)
+
+	quoteCollection := &Quotes{
+		Quotes: []Quote{},
+	}
+
+	quoteCollection.Quotes = append(quoteCollection.Quotes, quote)
+
+	return quoteCollection
+}
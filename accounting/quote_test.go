@@ -0,0 +1,80 @@
+package accounting
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_QuoteStatus_CanBecome(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	statuses := []QuoteStatus{QuoteStatusDraft, QuoteStatusSent, QuoteStatusAccepted, QuoteStatusDeclined, QuoteStatusInvoiced, QuoteStatusDeleted}
+	allowed := map[QuoteStatus][]QuoteStatus{
+		"":                  {QuoteStatusDraft, QuoteStatusSent, QuoteStatusDeleted},
+		QuoteStatusDraft:    {QuoteStatusDraft, QuoteStatusSent, QuoteStatusDeleted},
+		QuoteStatusSent:     {QuoteStatusSent, QuoteStatusAccepted, QuoteStatusDeclined, QuoteStatusDeleted},
+		QuoteStatusAccepted: {QuoteStatusSent, QuoteStatusAccepted, QuoteStatusInvoiced, QuoteStatusDeleted},
+		QuoteStatusDeclined: {QuoteStatusSent, QuoteStatusDeclined, QuoteStatusDeleted},
+		QuoteStatusInvoiced: {QuoteStatusAccepted, QuoteStatusInvoiced},
+		QuoteStatusDeleted:  {QuoteStatusDeleted},
+	}
+
+	for from, to := range allowed {
+		for _, next := range statuses {
+			a.Equal(contains(to, next), from.CanBecome(next), "%q to %q", from, next)
+		}
+	}
+}
+
+func contains(statuses []QuoteStatus, status QuoteStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func Test_Quote_SetStatus(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	quote := &Quote{}
+	a.EqualError(quote.SetStatus(QuoteStatusAccepted), "a DRAFT quote cannot become ACCEPTED")
+	a.EqualError(quote.SetStatus("APPROVED"), `"APPROVED" is not a valid QuoteStatus`)
+	a.Equal(QuoteStatus(""), quote.Status)
+
+	a.NoError(quote.SetStatus(QuoteStatusSent))
+	a.NoError(quote.SetStatus(QuoteStatusAccepted))
+	a.NoError(quote.SetStatus(QuoteStatusInvoiced))
+	a.Equal(QuoteStatusInvoiced, quote.Status)
+
+	a.Error(quote.SetStatus(QuoteStatusDeleted))
+	a.Equal(QuoteStatusInvoiced, quote.Status)
+}
+
+func Test_Quote_ToInvoice(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	quote := &Quote{
+		QuoteNumber:  "QU-0001",
+		Reference:    "Latex",
+		Contact:      Contact{ContactID: "c-1", Name: "Vanderlay Industries", EmailAddress: "art@vanderlay.com"},
+		LineItems:    []LineItem{{LineItemID: "l-1", Description: "Latex"}},
+		CurrencyCode: "NZD",
+	}
+
+	invoice := quote.ToInvoice()
+	a.Equal(InvoiceTypeAccRec, invoice.Type)
+	a.Equal(InvoiceStatusDraft, invoice.Status)
+	a.Equal(Contact{ContactID: "c-1", Name: "Vanderlay Industries"}, invoice.Contact)
+	a.Equal("QU-0001", invoice.Reference)
+	a.Equal("NZD", invoice.CurrencyCode)
+
+	//the line items are copied without their IDs so the quote is left as it was
+	a.Equal([]LineItem{{Description: "Latex"}}, invoice.LineItems)
+	a.Equal("l-1", quote.LineItems[0].LineItemID)
+}
@@ -1,11 +1,11 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
 
 	"github.com/XeroAPI/xerogolang"
-	"github.com/XeroAPI/xerogolang/helpers"
 	"github.com/markbates/goth"
 )
 
@@ -22,7 +22,7 @@ type Report struct {
 	//The date of the report
 	ReportDate string `json:"ReportDate,omitempty" xml:"ReportDate,omitempty"`
 	// Last modified date UTC format
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"UpdatedDateUTC,omitempty"`
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"UpdatedDateUTC,omitempty"`
 	//Attributes of the report
 	Attributes *[]ReportAttribute `json:"Attributes,omitempty" xml:"Attributes>Attribute,omitempty"`
 	//Rows on the report that may contain cells, Attributes, or other rows
@@ -34,22 +34,6 @@ type Reports struct {
 	Reports []Report `json:"Reports" xml:"Report"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (r *Reports) convertDates() error {
-	var err error
-	for n := len(r.Reports) - 1; n >= 0; n-- {
-		if r.Reports[n].UpdatedDateUTC != "" {
-			r.Reports[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(r.Reports[n].UpdatedDateUTC, true)
-		}
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalReport(reportResponseBytes []byte) (*Reports, error) {
 	var reportResponse *Reports
 	err := json.Unmarshal(reportResponseBytes, &reportResponse)
@@ -57,17 +41,17 @@ func unmarshalReport(reportResponseBytes []byte) (*Reports, error) {
 		return nil, err
 	}
 
-	err = reportResponse.convertDates()
-	if err != nil {
-		return nil, err
-	}
-
 	return reportResponse, err
 }
 
 //Run1099 will run the 1099 Report and marshal the results to a Report Struct
 //This Report will only work for US based Organisations
 func Run1099(provider *xerogolang.Provider, session goth.Session, reportYear int) (*Reports, error) {
+	return Run1099Ctx(context.Background(), provider, session, reportYear)
+}
+
+//Run1099Ctx is Run1099 with a context that can cancel the request or set its deadline
+func Run1099Ctx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, reportYear int) (*Reports, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -76,7 +60,7 @@ func Run1099(provider *xerogolang.Provider, session goth.Session, reportYear int
 		"reportYear": strconv.Itoa(reportYear),
 	}
 
-	reportResponseBytes, err := provider.Find(session, "Reports/TenNinetyNine", additionalHeaders, querystringParameters)
+	reportResponseBytes, err := provider.FindCtx(ctx, session, "Reports/TenNinetyNine", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -87,6 +71,11 @@ func Run1099(provider *xerogolang.Provider, session goth.Session, reportYear int
 //RunAgedPayablesByContact will run the Aged Payables By Contact Report and marshal the results to a Report Struct
 //Date, FromDate and ToDate can be added as optional paramters as a map
 func RunAgedPayablesByContact(provider *xerogolang.Provider, session goth.Session, contactID string, querystringParameters map[string]string) (*Reports, error) {
+	return RunAgedPayablesByContactCtx(context.Background(), provider, session, contactID, querystringParameters)
+}
+
+//RunAgedPayablesByContactCtx is RunAgedPayablesByContact with a context that can cancel the request or set its deadline
+func RunAgedPayablesByContactCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, contactID string, querystringParameters map[string]string) (*Reports, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -99,7 +88,7 @@ func RunAgedPayablesByContact(provider *xerogolang.Provider, session goth.Sessio
 		}
 	}
 
-	reportResponseBytes, err := provider.Find(session, "Reports/AgedPayablesByContact", additionalHeaders, querystringParameters)
+	reportResponseBytes, err := provider.FindCtx(ctx, session, "Reports/AgedPayablesByContact", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -110,6 +99,11 @@ func RunAgedPayablesByContact(provider *xerogolang.Provider, session goth.Sessio
 //RunAgedReceivablesByContact will run the Aged Receivables By Contact Report and marshal the results to a Report Struct
 //Date, FromDate and ToDate can be added as optional paramters as a map
 func RunAgedReceivablesByContact(provider *xerogolang.Provider, session goth.Session, contactID string, querystringParameters map[string]string) (*Reports, error) {
+	return RunAgedReceivablesByContactCtx(context.Background(), provider, session, contactID, querystringParameters)
+}
+
+//RunAgedReceivablesByContactCtx is RunAgedReceivablesByContact with a context that can cancel the request or set its deadline
+func RunAgedReceivablesByContactCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, contactID string, querystringParameters map[string]string) (*Reports, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -122,7 +116,7 @@ func RunAgedReceivablesByContact(provider *xerogolang.Provider, session goth.Ses
 		}
 	}
 
-	reportResponseBytes, err := provider.Find(session, "Reports/AgedReceivablesByContact", additionalHeaders, querystringParameters)
+	reportResponseBytes, err := provider.FindCtx(ctx, session, "Reports/AgedReceivablesByContact", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -133,11 +127,16 @@ func RunAgedReceivablesByContact(provider *xerogolang.Provider, session goth.Ses
 //RunBalanceSheet will run the Balance Sheet Report and marshal the results to a Report Struct
 //date, trackingOptionID1, trackingOptionID2, standardLayout, and paymentsOnly can be added as optional paramters as a map
 func RunBalanceSheet(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Reports, error) {
+	return RunBalanceSheetCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//RunBalanceSheetCtx is RunBalanceSheet with a context that can cancel the request or set its deadline
+func RunBalanceSheetCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Reports, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	reportResponseBytes, err := provider.Find(session, "Reports/BalanceSheet", additionalHeaders, querystringParameters)
+	reportResponseBytes, err := provider.FindCtx(ctx, session, "Reports/BalanceSheet", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -148,6 +147,11 @@ func RunBalanceSheet(provider *xerogolang.Provider, session goth.Session, querys
 //RunBankStatement will run the Bank Statement Report and marshal the results to a Report Struct
 //FromDate and ToDate can be added as optional paramters as a map
 func RunBankStatement(provider *xerogolang.Provider, session goth.Session, bankAccountID string, querystringParameters map[string]string) (*Reports, error) {
+	return RunBankStatementCtx(context.Background(), provider, session, bankAccountID, querystringParameters)
+}
+
+//RunBankStatementCtx is RunBankStatement with a context that can cancel the request or set its deadline
+func RunBankStatementCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, bankAccountID string, querystringParameters map[string]string) (*Reports, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -160,7 +164,7 @@ func RunBankStatement(provider *xerogolang.Provider, session goth.Session, bankA
 		}
 	}
 
-	reportResponseBytes, err := provider.Find(session, "Reports/BankStatement", additionalHeaders, querystringParameters)
+	reportResponseBytes, err := provider.FindCtx(ctx, session, "Reports/BankStatement", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -171,11 +175,16 @@ func RunBankStatement(provider *xerogolang.Provider, session goth.Session, bankA
 //RunBankSummary will run the Bank Summary Report and marshal the results to a Report Struct
 //FromDate and ToDate can be added as optional paramters as a map
 func RunBankSummary(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Reports, error) {
+	return RunBankSummaryCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//RunBankSummaryCtx is RunBankSummary with a context that can cancel the request or set its deadline
+func RunBankSummaryCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Reports, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	reportResponseBytes, err := provider.Find(session, "Reports/BankSummary", additionalHeaders, querystringParameters)
+	reportResponseBytes, err := provider.FindCtx(ctx, session, "Reports/BankSummary", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -186,11 +195,16 @@ func RunBankSummary(provider *xerogolang.Provider, session goth.Session, queryst
 //RunBASReport will retrieve an individual BAS Report given a reportID and marshal the results to a Report Struct
 //Will only work for AU based Organisations
 func RunBASReport(provider *xerogolang.Provider, session goth.Session, reportID string) (*Reports, error) {
+	return RunBASReportCtx(context.Background(), provider, session, reportID)
+}
+
+//RunBASReportCtx is RunBASReport with a context that can cancel the request or set its deadline
+func RunBASReportCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, reportID string) (*Reports, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	reportResponseBytes, err := provider.Find(session, "Reports/"+reportID, additionalHeaders, nil)
+	reportResponseBytes, err := provider.FindCtx(ctx, session, "Reports/"+reportID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -201,16 +215,26 @@ func RunBASReport(provider *xerogolang.Provider, session goth.Session, reportID
 //RunBASReports will retrieve all BAS Reports and marshal the results to a Report Struct
 //Will only work for AU based Organisations
 func RunBASReports(provider *xerogolang.Provider, session goth.Session) (*Reports, error) {
-	return RunBASReport(provider, session, "")
+	return RunBASReportsCtx(context.Background(), provider, session)
+}
+
+//RunBASReportsCtx is RunBASReports with a context that can cancel the request or set its deadline
+func RunBASReportsCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Reports, error) {
+	return RunBASReportCtx(ctx, provider, session, "")
 }
 
 //RunBudgetSummary will run the Budget Summary Report and marshal the results to a Report Struct
 func RunBudgetSummary(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Reports, error) {
+	return RunBudgetSummaryCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//RunBudgetSummaryCtx is RunBudgetSummary with a context that can cancel the request or set its deadline
+func RunBudgetSummaryCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Reports, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	reportResponseBytes, err := provider.Find(session, "Reports/BudgetSummary", additionalHeaders, querystringParameters)
+	reportResponseBytes, err := provider.FindCtx(ctx, session, "Reports/BudgetSummary", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -221,11 +245,16 @@ func RunBudgetSummary(provider *xerogolang.Provider, session goth.Session, query
 //RunExecutiveSummary will run the Executive Summary Report and marshal the results to a Report Struct
 //date can be added as an optional paramter as a map
 func RunExecutiveSummary(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Reports, error) {
+	return RunExecutiveSummaryCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//RunExecutiveSummaryCtx is RunExecutiveSummary with a context that can cancel the request or set its deadline
+func RunExecutiveSummaryCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Reports, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	reportResponseBytes, err := provider.Find(session, "Reports/ExecutiveSummary", additionalHeaders, querystringParameters)
+	reportResponseBytes, err := provider.FindCtx(ctx, session, "Reports/ExecutiveSummary", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -236,11 +265,16 @@ func RunExecutiveSummary(provider *xerogolang.Provider, session goth.Session, qu
 //RunGSTReport will retrieve an individual GST Report given a reportID and marshal the results to a Report Struct
 //Will only work for NZ based Organisations
 func RunGSTReport(provider *xerogolang.Provider, session goth.Session, reportID string) (*Reports, error) {
+	return RunGSTReportCtx(context.Background(), provider, session, reportID)
+}
+
+//RunGSTReportCtx is RunGSTReport with a context that can cancel the request or set its deadline
+func RunGSTReportCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, reportID string) (*Reports, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	reportResponseBytes, err := provider.Find(session, "Reports/"+reportID, additionalHeaders, nil)
+	reportResponseBytes, err := provider.FindCtx(ctx, session, "Reports/"+reportID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -251,18 +285,28 @@ func RunGSTReport(provider *xerogolang.Provider, session goth.Session, reportID
 //RunGSTReports will retrieve all GST Reports and marshal the results to a Report Struct
 //Will only work for NZ based Organisations
 func RunGSTReports(provider *xerogolang.Provider, session goth.Session) (*Reports, error) {
-	return RunGSTReport(provider, session, "")
+	return RunGSTReportsCtx(context.Background(), provider, session)
+}
+
+//RunGSTReportsCtx is RunGSTReports with a context that can cancel the request or set its deadline
+func RunGSTReportsCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Reports, error) {
+	return RunGSTReportCtx(ctx, provider, session, "")
 }
 
 //RunProfitAndLoss will run the Profit And Loss Report and marshal the results to a Report Struct
 //date, trackingCategoryID, trackingOptionID, trackingCategoryID2, trackingOptionID2,
 //standardLayout, and paymentsOnly can be added as optional paramters as a map
 func RunProfitAndLoss(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Reports, error) {
+	return RunProfitAndLossCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//RunProfitAndLossCtx is RunProfitAndLoss with a context that can cancel the request or set its deadline
+func RunProfitAndLossCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Reports, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	reportResponseBytes, err := provider.Find(session, "Reports/ProfitAndLoss", additionalHeaders, querystringParameters)
+	reportResponseBytes, err := provider.FindCtx(ctx, session, "Reports/ProfitAndLoss", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -273,11 +317,16 @@ func RunProfitAndLoss(provider *xerogolang.Provider, session goth.Session, query
 //RunTrialBalance will run the TrialBalance Report and marshal the results to a Report Struct
 //date and paymentsOnly can be added as optional paramters as a map
 func RunTrialBalance(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Reports, error) {
+	return RunTrialBalanceCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//RunTrialBalanceCtx is RunTrialBalance with a context that can cancel the request or set its deadline
+func RunTrialBalanceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Reports, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	reportResponseBytes, err := provider.Find(session, "Reports/TrialBalance", additionalHeaders, querystringParameters)
+	reportResponseBytes, err := provider.FindCtx(ctx, session, "Reports/TrialBalance", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
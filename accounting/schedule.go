@@ -1,5 +1,7 @@
 package accounting
 
+import "github.com/XeroAPI/xerogolang"
+
 //Schedule is an element on a Repeating Invoice - do not use it separately
 type Schedule struct {
 
@@ -7,17 +9,17 @@ type Schedule struct {
 	Period float64 `json:"Period,omitempty" xml:"Period,omitempty"`
 
 	// One of the following : WEEKLY or MONTHLY
-	Unit string `json:"Unit,omitempty" xml:"Unit,omitempty"`
+	Unit ScheduleUnit `json:"Unit,omitempty" xml:"Unit,omitempty"`
 
 	// Integer used with due date type e.g 20 (of following month), 31 (of current month)
 	DueDate float64 `json:"DueDate,omitempty" xml:"DueDate,omitempty"`
 
 	// Date the first invoice of the current version of the repeating schedule was generated (changes when repeating invoice is edited)
-	StartDate string `json:"StartDate,omitempty" xml:"StartDate,omitempty"`
+	StartDate xerogolang.Date `json:"StartDate,omitzero" xml:"StartDate,omitempty"`
 
 	// The calendar date of the next invoice in the schedule to be generated
-	NextScheduledDate string `json:"NextScheduledDate,omitempty" xml:"NextScheduledDate,omitempty"`
+	NextScheduledDate xerogolang.Date `json:"NextScheduledDate,omitzero" xml:"NextScheduledDate,omitempty"`
 
 	// Invoice end date – only returned if the template has an end date set
-	EndDate string `json:"EndDate,omitempty" xml:"EndDate,omitempty"`
+	EndDate xerogolang.Date `json:"EndDate,omitzero" xml:"EndDate,omitempty"`
 }
@@ -1,5 +1,7 @@
 package accounting
 
+import "github.com/XeroAPI/xerogolang"
+
 //TaxComponent is a component of tax witjin a TaxRate
 type TaxComponent struct {
 
@@ -7,7 +9,7 @@ type TaxComponent struct {
 	Name string `json:"Name,omitempty" xml:"Name,omitempty"`
 
 	// Tax Rate (up to 4dp)
-	Rate float32 `json:"Rate,omitempty" xml:"Rate,omitempty"`
+	Rate xerogolang.Decimal `json:"Rate,omitzero" xml:"Rate,omitempty"`
 
 	// Boolean to describe if Tax rate is compounded.Learn more
 	IsCompound bool `json:"IsCompound,omitempty" xml:"IsCompound,omitempty"`
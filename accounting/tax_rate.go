@@ -1,6 +1,7 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 
@@ -15,7 +16,7 @@ type TaxRate struct {
 	Name string `json:"Name,omitempty" xml:"Name,omitempty"`
 
 	// See Tax Types – can only be used on update calls
-	TaxType string `json:"TaxType,omitempty" xml:"TaxType,omitempty"`
+	TaxType TaxType `json:"TaxType,omitempty" xml:"TaxType,omitempty"`
 
 	// See TaxComponents
 	TaxComponents []TaxComponent `json:"TaxComponents,omitempty" xml:"TaxComponents>TaxComponent,omitempty"`
@@ -42,10 +43,10 @@ type TaxRate struct {
 	CanApplyToRevenue bool `json:"CanApplyToRevenue,omitempty" xml:"CanApplyToRevenue,omitempty"`
 
 	// Tax Rate (decimal to 4dp) e.g 12.5000
-	DisplayTaxRate float32 `json:"DisplayTaxRate,omitempty" xml:"DisplayTaxRate,omitempty"`
+	DisplayTaxRate xerogolang.Decimal `json:"DisplayTaxRate,omitzero" xml:"DisplayTaxRate,omitempty"`
 
 	// Effective Tax Rate (decimal to 4dp) e.g 12.5000
-	EffectiveRate float32 `json:"EffectiveRate,omitempty" xml:"EffectiveRate,omitempty"`
+	EffectiveRate xerogolang.Decimal `json:"EffectiveRate,omitzero" xml:"EffectiveRate,omitempty"`
 }
 
 type TaxRates struct {
@@ -64,6 +65,11 @@ func unmarshalTaxRate(taxRateResponseBytes []byte) (*TaxRates, error) {
 
 //Create will create taxRates given an TaxRates struct
 func (t *TaxRates) Create(provider *xerogolang.Provider, session goth.Session) (*TaxRates, error) {
+	return t.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (t *TaxRates) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*TaxRates, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -74,7 +80,7 @@ func (t *TaxRates) Create(provider *xerogolang.Provider, session goth.Session) (
 		return nil, err
 	}
 
-	taxRateResponseBytes, err := provider.Create(session, "TaxRates", additionalHeaders, body)
+	taxRateResponseBytes, err := provider.CreateCtx(ctx, session, "TaxRates", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -85,6 +91,11 @@ func (t *TaxRates) Create(provider *xerogolang.Provider, session goth.Session) (
 //Update will update an taxRate given an TaxRates struct
 //This will only handle a single taxRate - you cannot update multiple taxRates in a single call
 func (t *TaxRates) Update(provider *xerogolang.Provider, session goth.Session) (*TaxRates, error) {
+	return t.UpdateCtx(context.Background(), provider, session)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (t *TaxRates) UpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*TaxRates, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -95,7 +106,7 @@ func (t *TaxRates) Update(provider *xerogolang.Provider, session goth.Session) (
 		return nil, err
 	}
 
-	taxRateResponseBytes, err := provider.Update(session, "TaxRates", additionalHeaders, body)
+	taxRateResponseBytes, err := provider.UpdateCtx(ctx, session, "TaxRates", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -106,11 +117,16 @@ func (t *TaxRates) Update(provider *xerogolang.Provider, session goth.Session) (
 //FindTaxRates will get all TaxRates.
 //additional querystringParameters such as taxType, where and order can be added as a map
 func FindTaxRates(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*TaxRates, error) {
+	return FindTaxRatesCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindTaxRatesCtx is FindTaxRates with a context that can cancel the request or set its deadline
+func FindTaxRatesCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*TaxRates, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	taxRateResponseBytes, err := provider.Find(session, "TaxRates", additionalHeaders, querystringParameters)
+	taxRateResponseBytes, err := provider.FindCtx(ctx, session, "TaxRates", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -122,13 +138,13 @@ func FindTaxRates(provider *xerogolang.Provider, session goth.Session, querystri
 func GenerateExampleTaxRate() *TaxRates {
 	taxComponent1 := TaxComponent{
 		Name:       "State Tax",
-		Rate:       7.5,
+		Rate:       xerogolang.MustParseDecimal("7.5"),
 		IsCompound: false,
 	}
 
 	taxComponent2 := TaxComponent{
 		Name:       "Local Sales Tax",
-		Rate:       0.625,
+		Rate:       xerogolang.MustParseDecimal("0.625"),
 		IsCompound: false,
 	}
 
@@ -1,6 +1,7 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 
@@ -39,8 +40,44 @@ func unmarshalTrackingCategory(trackingCategoryResponseBytes []byte) (*TrackingC
 	return trackingCategoryResponse, err
 }
 
+//Validate checks the tracking categories against the rules Xero applies so mistakes are found before anything is sent
+func (t *TrackingCategories) Validate() error {
+	v := &validator{}
+	for n := range t.TrackingCategories {
+		t.TrackingCategories[n].validate(v, index("TrackingCategories", n))
+	}
+	return v.err()
+}
+
+func (t *TrackingCategory) validate(v *validator, field string) {
+	if t.TrackingCategoryID == "" {
+		v.required(field+".Name", t.Name)
+	}
+	v.maxLength(field+".Name", t.Name, 100)
+	for n := range t.Options {
+		v.maxLength(index(field+".Options", n)+".Name", t.Options[n].Name, 50)
+	}
+}
+
+//validateTracking checks the tracking categories on a line
+func validateTracking(v *validator, field string, tracking []TrackingCategory) {
+	if len(tracking) > 2 {
+		v.add(field, "can have at most 2 tracking categories")
+	}
+}
+
 //Create will create trackingCategories given an TrackingCategories struct
 func (t *TrackingCategories) Create(provider *xerogolang.Provider, session goth.Session) (*TrackingCategories, error) {
+	return t.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (t *TrackingCategories) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*TrackingCategories, error) {
+	err := t.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -51,7 +88,7 @@ func (t *TrackingCategories) Create(provider *xerogolang.Provider, session goth.
 		return nil, err
 	}
 
-	trackingCategoryResponseBytes, err := provider.Create(session, "TrackingCategories", additionalHeaders, body)
+	trackingCategoryResponseBytes, err := provider.CreateCtx(ctx, session, "TrackingCategories", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -62,6 +99,16 @@ func (t *TrackingCategories) Create(provider *xerogolang.Provider, session goth.
 //Update will update an trackingCategory given an TrackingCategories struct
 //This will only handle single trackingCategory - you cannot update multiple trackingCategories in a single call
 func (t *TrackingCategories) Update(provider *xerogolang.Provider, session goth.Session) (*TrackingCategories, error) {
+	return t.UpdateCtx(context.Background(), provider, session)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (t *TrackingCategories) UpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*TrackingCategories, error) {
+	err := t.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -72,7 +119,7 @@ func (t *TrackingCategories) Update(provider *xerogolang.Provider, session goth.
 		return nil, err
 	}
 
-	trackingCategoryResponseBytes, err := provider.Update(session, "TrackingCategories/"+t.TrackingCategories[0].TrackingCategoryID, additionalHeaders, body)
+	trackingCategoryResponseBytes, err := provider.UpdateCtx(ctx, session, "TrackingCategories/"+t.TrackingCategories[0].TrackingCategoryID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -82,11 +129,16 @@ func (t *TrackingCategories) Update(provider *xerogolang.Provider, session goth.
 
 //FindTrackingCategories will get all trackingCategories
 func FindTrackingCategories(provider *xerogolang.Provider, session goth.Session) (*TrackingCategories, error) {
+	return FindTrackingCategoriesCtx(context.Background(), provider, session)
+}
+
+//FindTrackingCategoriesCtx is FindTrackingCategories with a context that can cancel the request or set its deadline
+func FindTrackingCategoriesCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*TrackingCategories, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	trackingCategoryResponseBytes, err := provider.Find(session, "TrackingCategories", additionalHeaders, nil)
+	trackingCategoryResponseBytes, err := provider.FindCtx(ctx, session, "TrackingCategories", additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -96,11 +148,16 @@ func FindTrackingCategories(provider *xerogolang.Provider, session goth.Session)
 
 //FindTrackingCategory will get a single trackingCategory - trackingCategoryID must be a GUID for an trackingCategory
 func FindTrackingCategory(provider *xerogolang.Provider, session goth.Session, trackingCategoryID string) (*TrackingCategories, error) {
+	return FindTrackingCategoryCtx(context.Background(), provider, session, trackingCategoryID)
+}
+
+//FindTrackingCategoryCtx is FindTrackingCategory with a context that can cancel the request or set its deadline
+func FindTrackingCategoryCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, trackingCategoryID string) (*TrackingCategories, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	trackingCategoryResponseBytes, err := provider.Find(session, "TrackingCategories/"+trackingCategoryID, additionalHeaders, nil)
+	trackingCategoryResponseBytes, err := provider.FindCtx(ctx, session, "TrackingCategories/"+trackingCategoryID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -110,11 +167,16 @@ func FindTrackingCategory(provider *xerogolang.Provider, session goth.Session, t
 
 //RemoveTrackingCategory will get a single trackingCategory - trackingCategoryID must be a GUID for an trackingCategory
 func RemoveTrackingCategory(provider *xerogolang.Provider, session goth.Session, trackingCategoryID string) (*TrackingCategories, error) {
+	return RemoveTrackingCategoryCtx(context.Background(), provider, session, trackingCategoryID)
+}
+
+//RemoveTrackingCategoryCtx is RemoveTrackingCategory with a context that can cancel the request or set its deadline
+func RemoveTrackingCategoryCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, trackingCategoryID string) (*TrackingCategories, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	trackingCategoryResponseBytes, err := provider.Remove(session, "TrackingCategories/"+trackingCategoryID, additionalHeaders)
+	trackingCategoryResponseBytes, err := provider.RemoveCtx(ctx, session, "TrackingCategories/"+trackingCategoryID, additionalHeaders)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package accounting
 
 import (
+	"context"
 	"encoding/xml"
 
 	"github.com/XeroAPI/xerogolang"
@@ -31,6 +32,11 @@ type Options struct {
 //Add will add tracking options to the TrackingCategory Specified on the first option
 //All options should belong to the same Tracking Category
 func (o *Options) Add(provider *xerogolang.Provider, session goth.Session) (*TrackingCategories, error) {
+	return o.AddCtx(context.Background(), provider, session)
+}
+
+//AddCtx is Add with a context that can cancel the request or set its deadline
+func (o *Options) AddCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*TrackingCategories, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -41,7 +47,7 @@ func (o *Options) Add(provider *xerogolang.Provider, session goth.Session) (*Tra
 		return nil, err
 	}
 
-	trackingCategoryResponseBytes, err := provider.Create(session, "TrackingCategories/"+o.Options[0].TrackingCategoryID+"/Options", additionalHeaders, body)
+	trackingCategoryResponseBytes, err := provider.CreateCtx(ctx, session, "TrackingCategories/"+o.Options[0].TrackingCategoryID+"/Options", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -51,6 +57,11 @@ func (o *Options) Add(provider *xerogolang.Provider, session goth.Session) (*Tra
 
 //Update will update a given tracking option
 func (t *TrackingOption) Update(provider *xerogolang.Provider, session goth.Session) (*TrackingCategories, error) {
+	return t.UpdateCtx(context.Background(), provider, session)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (t *TrackingOption) UpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*TrackingCategories, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -61,7 +72,7 @@ func (t *TrackingOption) Update(provider *xerogolang.Provider, session goth.Sess
 		return nil, err
 	}
 
-	trackingCategoryResponseBytes, err := provider.Update(session, "TrackingCategories/"+t.TrackingCategoryID+"/Options/"+t.TrackingOptionID, additionalHeaders, body)
+	trackingCategoryResponseBytes, err := provider.UpdateCtx(ctx, session, "TrackingCategories/"+t.TrackingCategoryID+"/Options/"+t.TrackingOptionID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
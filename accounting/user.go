@@ -1,6 +1,7 @@
 package accounting
 
 import (
+	"context"
 	"encoding/json"
 	"time"
 
@@ -24,7 +25,7 @@ type User struct {
 	LastName string `json:"LastName,omitempty" xml:"LastName,omitempty"`
 
 	// Timestamp of last change to user
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"UpdatedDateUTC,omitempty"`
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"UpdatedDateUTC,omitempty"`
 
 	// Boolean to indicate if user is the subscriber
 	IsSubscriber bool `json:"IsSubscriber,omitempty" xml:"IsSubscriber,omitempty"`
@@ -51,6 +52,11 @@ func unmarshalUsers(userResponseBytes []byte) (*Users, error) {
 //FindUsersModifiedSince will get all users modified after a specified date
 //additional querystringParameters such as where and order can be added as a map
 func FindUsersModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Users, error) {
+	return FindUsersModifiedSinceCtx(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindUsersModifiedSinceCtx is FindUsersModifiedSince with a context that can cancel the request or set its deadline
+func FindUsersModifiedSinceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Users, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -59,7 +65,7 @@ func FindUsersModifiedSince(provider *xerogolang.Provider, session goth.Session,
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	userResponseBytes, err := provider.Find(session, "Users", additionalHeaders, querystringParameters)
+	userResponseBytes, err := provider.FindCtx(ctx, session, "Users", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -70,16 +76,26 @@ func FindUsersModifiedSince(provider *xerogolang.Provider, session goth.Session,
 //FindUsers will get all users
 //additional querystringParameters such as where and order can be added as a map
 func FindUsers(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Users, error) {
-	return FindUsersModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindUsersCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindUsersCtx is FindUsers with a context that can cancel the request or set its deadline
+func FindUsersCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Users, error) {
+	return FindUsersModifiedSinceCtx(ctx, provider, session, dayZero, querystringParameters)
 }
 
 //FindUser will get a single user - UserID must be a GUID for a user
 func FindUser(provider *xerogolang.Provider, session goth.Session, userID string) (*Users, error) {
+	return FindUserCtx(context.Background(), provider, session, userID)
+}
+
+//FindUserCtx is FindUser with a context that can cancel the request or set its deadline
+func FindUserCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, userID string) (*Users, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	userResponseBytes, err := provider.Find(session, "Users/"+userID, additionalHeaders, nil)
+	userResponseBytes, err := provider.FindCtx(ctx, session, "Users/"+userID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
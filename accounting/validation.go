@@ -0,0 +1,73 @@
+package accounting
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+//FieldError is a field Xero would reject. Field is the path to it from the collection
+//e.g. Invoices[0].LineItems[1].Description
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + " " + e.Message
+}
+
+//ValidationErrors is every FieldError found in a collection. It is returned by Validate and
+//by Create and Update when a collection is not valid, in which case nothing is sent to Xero
+type ValidationErrors []FieldError
+
+func (v ValidationErrors) Error() string {
+	messages := make([]string, len(v))
+	for n, fieldError := range v {
+		messages[n] = fieldError.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+//validator collects FieldErrors as the entities in a collection are checked
+type validator struct {
+	errors ValidationErrors
+}
+
+func (v *validator) add(field string, format string, args ...interface{}) {
+	v.errors = append(v.errors, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+//required adds an error if value is empty
+func (v *validator) required(field string, value string) {
+	if value == "" {
+		v.add(field, "is required")
+	}
+}
+
+//maxLength adds an error if value is longer than max characters
+func (v *validator) maxLength(field string, value string, max int) {
+	if utf8.RuneCountInString(value) > max {
+		v.add(field, "must be at most %d characters", max)
+	}
+}
+
+//code adds an error if a code is set but not one Xero accepts
+func (v *validator) code(field string, value string, valid bool) {
+	if value != "" && !valid {
+		v.add(field, "%q is not a valid code", value)
+	}
+}
+
+//err returns the errors found, or nil if there were none
+func (v *validator) err() error {
+	if len(v.errors) == 0 {
+		return nil
+	}
+	return v.errors
+}
+
+//index builds the path to an element of a slice
+func index(field string, n int) string {
+	return fmt.Sprintf("%s[%d]", field, n)
+}
@@ -0,0 +1,312 @@
+package accounting
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XeroAPI/xerogolang"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Validator(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	v := &validator{}
+	a.NoError(v.err())
+
+	v.required("Empty", "")
+	v.required("Set", "x")
+	v.maxLength("Short", "héllo", 5)
+	v.maxLength("Long", "héllo!", 5)
+	v.code("Unset", "", false)
+	v.code("Known", "ACCREC", true)
+	v.code("Unknown", "ACCREQ", false)
+	v.add(index("Lines", 2), "is %s", "wrong")
+
+	err := v.err()
+	a.Equal(ValidationErrors{
+		{Field: "Empty", Message: "is required"},
+		{Field: "Long", Message: "must be at most 5 characters"},
+		{Field: "Unknown", Message: `"ACCREQ" is not a valid code`},
+		{Field: "Lines[2]", Message: "is wrong"},
+	}, err)
+	a.EqualError(err, `Empty is required; Long must be at most 5 characters; Unknown "ACCREQ" is not a valid code; Lines[2] is wrong`)
+}
+
+func Test_Validate(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	long := func(n int) string { return strings.Repeat("x", n) }
+	contact := Contact{Name: "Vanderlay Industries"}
+	line := LineItem{Description: "Latex"}
+	date := xerogolang.NewDate(2020, time.March, 2)
+
+	testCases := []struct {
+		name     string
+		validate func() error
+		fields   []string
+	}{
+		{
+			name:     "new account",
+			validate: (&Accounts{Accounts: []Account{{}}}).Validate,
+			fields:   []string{"Accounts[0].Code", "Accounts[0].Name", "Accounts[0].Type"},
+		},
+		{
+			name: "existing account",
+			validate: (&Accounts{Accounts: []Account{
+				{AccountID: "a-1"},
+				{AccountID: "a-2", Code: long(11), Name: long(151), Description: long(4001), Type: "CASH"},
+			}}).Validate,
+			fields: []string{"Accounts[1].Code", "Accounts[1].Name", "Accounts[1].Description", "Accounts[1].Type"},
+		},
+		{
+			name: "account",
+			validate: (&Accounts{Accounts: []Account{
+				{Code: "200", Name: "Sales", Type: AccountTypeBank, TaxType: "OUTPUTY24"},
+			}}).Validate,
+		},
+		{
+			name: "contact",
+			validate: (&Contacts{Contacts: []Contact{
+				{},
+				{ContactID: "c-1"},
+				{
+					Name:          long(256),
+					ContactNumber: long(51),
+					AccountNumber: long(51),
+					FirstName:     long(256),
+					LastName:      long(256),
+					EmailAddress:  long(256),
+					TaxNumber:     long(51),
+					Addresses: &[]Address{{}, {
+						AddressLine1: long(501),
+						AddressLine2: long(501),
+						AddressLine3: long(501),
+						AddressLine4: long(501),
+						City:         long(256),
+						Region:       long(256),
+						PostalCode:   long(51),
+						Country:      long(51),
+						AttentionTo:  long(256),
+					}},
+					Phones: &[]Phone{{PhoneNumber: long(51), PhoneAreaCode: long(11), PhoneCountryCode: long(21)}},
+				},
+			}}).Validate,
+			fields: []string{
+				"Contacts[0].Name",
+				"Contacts[2].ContactNumber", "Contacts[2].AccountNumber", "Contacts[2].Name", "Contacts[2].FirstName",
+				"Contacts[2].LastName", "Contacts[2].EmailAddress", "Contacts[2].TaxNumber",
+				"Contacts[2].Addresses[1].AddressLine1", "Contacts[2].Addresses[1].AddressLine2",
+				"Contacts[2].Addresses[1].AddressLine3", "Contacts[2].Addresses[1].AddressLine4",
+				"Contacts[2].Addresses[1].City", "Contacts[2].Addresses[1].Region", "Contacts[2].Addresses[1].PostalCode",
+				"Contacts[2].Addresses[1].Country", "Contacts[2].Addresses[1].AttentionTo",
+				"Contacts[2].Phones[0].PhoneNumber", "Contacts[2].Phones[0].PhoneAreaCode", "Contacts[2].Phones[0].PhoneCountryCode",
+			},
+		},
+		{
+			name: "invoice",
+			validate: (&Invoices{Invoices: []Invoice{
+				{},
+				{Type: "ACCREQ", Status: "SENT", LineAmountTypes: "Gross", InvoiceNumber: long(256), Reference: long(256), Contact: contact},
+				{Type: InvoiceTypeAccRec, Contact: contact, LineItems: []LineItem{line}},
+			}}).Validate,
+			fields: []string{
+				"Invoices[0].Type", "Invoices[0].Contact.Name",
+				"Invoices[1].Type", "Invoices[1].Status", "Invoices[1].LineAmountTypes", "Invoices[1].InvoiceNumber", "Invoices[1].Reference",
+			},
+		},
+		{
+			name: "line items",
+			validate: (&Invoices{Invoices: []Invoice{{
+				Type:    InvoiceTypeAccRec,
+				Contact: contact,
+				LineItems: []LineItem{
+					{},
+					{Description: "Latex", Tracking: []TrackingCategory{{}, {}, {}}},
+				},
+			}}}).Validate,
+			fields: []string{"Invoices[0].LineItems[0].Description", "Invoices[0].LineItems[1].Tracking"},
+		},
+		{
+			name: "line item with an item code takes the item's description",
+			validate: (&Invoices{Invoices: []Invoice{{
+				Type:      InvoiceTypeAccRec,
+				Contact:   contact,
+				LineItems: []LineItem{{ItemCode: "LATEX", Quantity: xerogolang.MustParseDecimal("2")}},
+			}}}).Validate,
+		},
+		{
+			name: "discounts",
+			validate: (&Invoices{Invoices: []Invoice{
+				{Type: InvoiceTypeAccRec, Contact: contact, LineItems: []LineItem{{Description: "Latex", DiscountRate: xerogolang.MustParseDecimal("10")}}},
+				{Type: InvoiceTypeAccPay, Contact: contact, LineItems: []LineItem{{Description: "Latex", DiscountRate: xerogolang.MustParseDecimal("10")}}},
+			}}).Validate,
+			fields: []string{"Invoices[1].LineItems[0].DiscountRate"},
+		},
+		{
+			name: "discount when updating an invoice without its type",
+			validate: (&Invoices{Invoices: []Invoice{{
+				InvoiceID: "i-1",
+				Contact:   Contact{ContactID: "c-1"},
+				LineItems: []LineItem{{Description: "Latex", DiscountRate: xerogolang.MustParseDecimal("10")}},
+			}}}).Validate,
+		},
+		{
+			name: "invoice updated without its contact",
+			validate: (&Invoices{Invoices: []Invoice{
+				{InvoiceID: "abc", Status: InvoiceStatusAuthorised},
+			}}).Validate,
+		},
+		{
+			name: "invoice to a contact given by its ContactNumber",
+			validate: (&Invoices{Invoices: []Invoice{
+				{Type: InvoiceTypeAccRec, Contact: Contact{ContactNumber: "C-1"}},
+			}}).Validate,
+		},
+		{
+			name: "contacts given by their ContactNumber",
+			validate: (&Contacts{Contacts: []Contact{
+				{ContactNumber: "C-1", EmailAddress: "art@vanderlay.com"},
+			}}).Validate,
+		},
+		{
+			name:     "bank transaction updated without its contact",
+			validate: (&BankTransactions{BankTransactions: []BankTransaction{{BankTransactionID: "b-1", Reference: "Refund"}}}).Validate,
+		},
+		{
+			name:     "credit note updated without its contact",
+			validate: (&CreditNotes{CreditNotes: []CreditNote{{CreditNoteID: "cn-1", Status: "AUTHORISED"}}}).Validate,
+		},
+		{
+			name:     "purchase order updated without its contact",
+			validate: (&PurchaseOrders{PurchaseOrders: []PurchaseOrder{{PurchaseOrderID: "po-1", DeliveryInstructions: "Leave at the back"}}}).Validate,
+		},
+		{
+			name: "bank transaction",
+			validate: (&BankTransactions{BankTransactions: []BankTransaction{
+				{Contact: contact},
+				{BankTransactionID: "b-1", Type: "GIVE", LineAmountTypes: "Gross", Contact: contact},
+				{Type: BankTransactionTypeReceive, Contact: contact, LineItems: []LineItem{{Description: "Latex", DiscountRate: xerogolang.MustParseDecimal("10")}}},
+			}}).Validate,
+			fields: []string{"BankTransactions[0].Type", "BankTransactions[1].Type", "BankTransactions[1].LineAmountTypes", "BankTransactions[2].LineItems[0].DiscountRate"},
+		},
+		{
+			name: "credit note",
+			validate: (&CreditNotes{CreditNotes: []CreditNote{
+				{LineAmountTypes: "Gross", Contact: contact, LineItems: []LineItem{{Description: "Latex", DiscountRate: xerogolang.MustParseDecimal("10")}}},
+			}}).Validate,
+			fields: []string{"CreditNotes[0].LineAmountTypes", "CreditNotes[0].LineItems[0].DiscountRate"},
+		},
+		{
+			name: "purchase order",
+			validate: (&PurchaseOrders{PurchaseOrders: []PurchaseOrder{
+				{LineAmountTypes: "Gross", DeliveryInstructions: long(501), Contact: Contact{}, LineItems: []LineItem{{Description: "Latex", DiscountRate: xerogolang.MustParseDecimal("10")}}},
+			}}).Validate,
+			fields: []string{"PurchaseOrders[0].LineAmountTypes", "PurchaseOrders[0].DeliveryInstructions", "PurchaseOrders[0].Contact.Name"},
+		},
+		{
+			name: "quote updated without its contact",
+			validate: (&Quotes{Quotes: []Quote{
+				{QuoteID: "q", Status: QuoteStatusAccepted},
+			}}).Validate,
+		},
+		{
+			name: "quote",
+			validate: (&Quotes{Quotes: []Quote{
+				{Contact: contact},
+				{QuoteID: "q-1", Contact: contact},
+				{
+					Date:            date,
+					Status:          "WON",
+					LineAmountTypes: "Gross",
+					QuoteNumber:     long(256),
+					Reference:       long(4001),
+					Title:           long(101),
+					Summary:         long(3001),
+					Terms:           long(4001),
+					Contact:         contact,
+					LineItems:       []LineItem{{Description: "Latex", DiscountRate: xerogolang.MustParseDecimal("10")}},
+				},
+			}}).Validate,
+			fields: []string{
+				"Quotes[0].Date",
+				"Quotes[2].Status", "Quotes[2].LineAmountTypes", "Quotes[2].QuoteNumber", "Quotes[2].Reference",
+				"Quotes[2].Title", "Quotes[2].Summary", "Quotes[2].Terms",
+			},
+		},
+		{
+			name: "item",
+			validate: (&Items{Items: []Item{
+				{},
+				{ItemID: "i-1", Code: long(31), Name: long(51), Description: long(4001), PurchaseDescription: long(4001)},
+			}}).Validate,
+			fields: []string{"Items[0].Code", "Items[1].Code", "Items[1].Name", "Items[1].Description", "Items[1].PurchaseDescription"},
+		},
+		{
+			name: "manual journal",
+			validate: (&ManualJournals{ManualJournals: []ManualJournal{
+				{},
+				{
+					ManualJournalID: "m-1",
+					LineAmountTypes: "Gross",
+					JournalLines: []ManualJournalLine{
+						{LineAmount: xerogolang.MustParseDecimal("100.00"), Tracking: []TrackingCategory{{}, {}, {}}},
+						{AccountCode: "200", LineAmount: xerogolang.MustParseDecimal("-99.99")},
+					},
+				},
+				{
+					Narration: "Accrual",
+					JournalLines: []ManualJournalLine{
+						{AccountCode: "200", LineAmount: xerogolang.MustParseDecimal("100.00"), TaxType: "OUTPUTY24"},
+						{AccountCode: "400", LineAmount: xerogolang.MustParseDecimal("-100.00")},
+					},
+				},
+			}}).Validate,
+			fields: []string{
+				"ManualJournals[0].Narration",
+				"ManualJournals[1].LineAmountTypes", "ManualJournals[1].JournalLines[0].AccountCode",
+				"ManualJournals[1].JournalLines[0].Tracking", "ManualJournals[1].JournalLines",
+			},
+		},
+		{
+			name: "tracking category",
+			validate: (&TrackingCategories{TrackingCategories: []TrackingCategory{
+				{},
+				{TrackingCategoryID: "t-1"},
+				{Name: long(101), Options: []TrackingOption{{Name: "North"}, {Name: long(51)}}},
+			}}).Validate,
+			fields: []string{"TrackingCategories[0].Name", "TrackingCategories[2].Name", "TrackingCategories[2].Options[1].Name"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		a.Equal(testCase.fields, fields(testCase.validate()), testCase.name)
+	}
+}
+
+func Test_Validate_ManualJournalTotal(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	journals := &ManualJournals{ManualJournals: []ManualJournal{{
+		Narration: "Accrual",
+		JournalLines: []ManualJournalLine{
+			{AccountCode: "200", LineAmount: xerogolang.MustParseDecimal("100.00")},
+			{AccountCode: "400", LineAmount: xerogolang.MustParseDecimal("-99.99")},
+		},
+	}}}
+	a.EqualError(journals.Validate(), "ManualJournals[0].JournalLines must add up to zero but add up to 0.01")
+}
+
+func Test_Create_DoesNotSendInvalidCollections(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	//the provider is never used because nothing is sent
+	invoices := &Invoices{Invoices: []Invoice{{Contact: Contact{Name: "Vanderlay Industries"}}}}
+	_, err := invoices.Create(nil, nil)
+	a.Equal([]string{"Invoices[0].Type"}, fields(err))
+}
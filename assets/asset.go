@@ -0,0 +1,180 @@
+//Package assets is a client for the Assets API (assets.xro/1.0), which keeps the fixed asset
+//register and its depreciation. It speaks JSON and requests go to provider.Endpoints.Assets
+package assets
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/markbates/goth"
+	"github.com/opensimsim/xerogolang"
+)
+
+//Asset is an item in the fixed asset register
+type Asset struct {
+
+	// The Xero-generated Id for the asset
+	AssetID string `json:"assetId,omitempty"`
+
+	// The name of the asset
+	AssetName string `json:"assetName,omitempty"`
+
+	// The Xero-generated Id for the asset type. See FindAssetTypes
+	AssetTypeID string `json:"assetTypeId,omitempty"`
+
+	// Must be unique. Xero uses the Settings to number the asset if it is left out
+	AssetNumber string `json:"assetNumber,omitempty"`
+
+	// The date the asset was purchased (YYYY-MM-DD)
+	PurchaseDate xerogolang.Date `json:"purchaseDate,omitzero"`
+
+	// The purchase price of the asset
+	PurchasePrice xerogolang.Decimal `json:"purchasePrice,omitzero"`
+
+	// The price the asset was disposed at
+	DisposalPrice xerogolang.Decimal `json:"disposalPrice,omitzero"`
+
+	// See AssetStatus
+	AssetStatus AssetStatus `json:"assetStatus,omitempty"`
+
+	// The date the asset’s warranty expires (YYYY-MM-DD)
+	WarrantyExpiryDate xerogolang.Date `json:"warrantyExpiryDate,omitzero"`
+
+	// The asset's serial number
+	SerialNumber string `json:"serialNumber,omitempty"`
+
+	// How the asset is depreciated. Left out, the settings of its asset type are used
+	BookDepreciationSetting *BookDepreciationSetting `json:"bookDepreciationSetting,omitempty"`
+
+	// The cost and depreciation of the asset so far
+	BookDepreciationDetail *BookDepreciationDetail `json:"bookDepreciationDetail,omitempty"`
+
+	// Whether the depreciation of the asset can be rolled back
+	CanRollback bool `json:"canRollback,omitempty"`
+
+	// The accounting value of the asset
+	AccountingBookValue xerogolang.Decimal `json:"accountingBookValue,omitzero"`
+
+	// Whether the asset can be deleted
+	IsDeleteEnabledForDate bool `json:"isDeleteEnabledForDate,omitempty"`
+}
+
+//Assets contains a page of Assets
+type Assets struct {
+	Pagination *xerogolang.Pagination `json:"pagination,omitempty"`
+	Assets     []Asset                `json:"items"`
+}
+
+func unmarshalAssets(assetResponseBytes []byte) (*Assets, error) {
+	var assetResponse *Assets
+	err := json.Unmarshal(assetResponseBytes, &assetResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return assetResponse, err
+}
+
+//unmarshalAsset reads a single asset, which is returned on its own rather than in a page
+func unmarshalAsset(assetResponseBytes []byte) (*Assets, error) {
+	var asset Asset
+	err := json.Unmarshal(assetResponseBytes, &asset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Assets{Assets: []Asset{asset}}, err
+}
+
+//Create will create a draft Asset. Only the AssetName is required; the rest can be filled in
+//before the asset is registered in Xero
+func (a *Asset) Create(provider *xerogolang.Provider, session goth.Session) (*Assets, error) {
+	return a.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (a *Asset) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Assets, error) {
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/json",
+	}
+
+	body, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+
+	assetResponseBytes, err := provider.UpdateWithEndpointCtx(ctx, session, provider.AssetsEndpoint(), "Assets", additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalAsset(assetResponseBytes)
+}
+
+//FindAssets will get a page of the Assets with a status.
+//additional querystringParameters such as page, pageSize, orderBy and filterBy can be added as a map
+func FindAssets(provider *xerogolang.Provider, session goth.Session, status AssetStatus, querystringParameters map[string]string) (*Assets, error) {
+	return FindAssetsCtx(context.Background(), provider, session, status, querystringParameters)
+}
+
+//FindAssetsCtx is FindAssets with a context that can cancel the request or set its deadline
+func FindAssetsCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, status AssetStatus, querystringParameters map[string]string) (*Assets, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	//the status is sent in capitals when filtering, unlike the AssetStatus of an asset
+	statusParameters := map[string]string{"status": strings.ToUpper(string(status))}
+	for key, value := range querystringParameters {
+		statusParameters[key] = value
+	}
+
+	assetResponseBytes, err := provider.FindWithEndpointCtx(ctx, session, provider.AssetsEndpoint(), "Assets", additionalHeaders, statusParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalAssets(assetResponseBytes)
+}
+
+//EachAsset calls fn with every asset with a status, a page at a time, until the last page. orderBy
+//and filterBy querystringParameters are sent with every page. Return xerogolang.ErrStop from fn to stop early
+func EachAsset(ctx context.Context, provider *xerogolang.Provider, session goth.Session, status AssetStatus, querystringParameters map[string]string, fn func(asset Asset) error) error {
+	return xerogolang.EachPage(ctx, querystringParameters, func(pageParameters map[string]string) (int, error) {
+		assets, err := FindAssetsCtx(ctx, provider, session, status, pageParameters)
+		if err != nil {
+			return 0, err
+		}
+		for _, asset := range assets.Assets {
+			err = fn(asset)
+			if err != nil {
+				return 0, err
+			}
+		}
+		if assets.Pagination.Last() {
+			return 0, xerogolang.ErrStop
+		}
+		return len(assets.Assets), nil
+	})
+}
+
+//FindAsset will get a single Asset along with its depreciation
+func FindAsset(provider *xerogolang.Provider, session goth.Session, assetID string) (*Assets, error) {
+	return FindAssetCtx(context.Background(), provider, session, assetID)
+}
+
+//FindAssetCtx is FindAsset with a context that can cancel the request or set its deadline
+func FindAssetCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, assetID string) (*Assets, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	assetResponseBytes, err := provider.FindWithEndpointCtx(ctx, session, provider.AssetsEndpoint(), "Assets/"+assetID, additionalHeaders, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalAsset(assetResponseBytes)
+}
@@ -0,0 +1,133 @@
+package assets
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/opensimsim/xerogolang"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+//mockAssets answers each request with the next of responses and records the method, path and body of each
+func mockAssets(responses []string, f func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string)) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		uri := req.URL.Path
+		if req.URL.RawQuery != "" {
+			uri += "?" + req.URL.Query().Encode()
+		}
+		requests = append(requests, fmt.Sprintf("%s %s %s", req.Method, uri, body))
+		fmt.Fprint(res, responses[len(requests)-1])
+	}))
+	defer ts.Close()
+
+	provider := xerogolang.NewOAuth2("CLIENT", "SECRET", "/foo")
+	provider.Endpoints = xerogolang.Endpoints{Assets: ts.URL + "/"}
+	session := &xerogolang.Session{OAuth2Token: &oauth2.Token{AccessToken: "ACCESSTOKEN", RefreshToken: "REFRESHTOKEN", TokenType: "Bearer"}}
+	f(provider, session, &requests)
+}
+
+func Test_Asset_Create_NameOnly(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	response := `{"assetId":"a-1","assetName":"Desk","assetNumber":"FA-0001","assetStatus":"Draft","purchasePrice":0,"accountingBookValue":0}`
+	mockAssets([]string{response}, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		asset := &Asset{AssetName: "Desk"}
+
+		assets, err := asset.Create(provider, session)
+		a.NoError(err)
+		a.Len(assets.Assets, 1)
+		a.Equal("a-1", assets.Assets[0].AssetID)
+		a.Equal(AssetStatusDraft, assets.Assets[0].AssetStatus)
+		a.True(assets.Assets[0].PurchasePrice.IsZero())
+
+		//a draft only needs its name, the unset prices and dates are not sent as zeros and nulls
+		a.Equal([]string{`POST /Assets {"assetName":"Desk"}`}, *requests)
+	})
+}
+
+func Test_Asset_Create(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockAssets([]string{`{"assetId":"a-1"}`}, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		asset := &Asset{
+			AssetName:     "Desk",
+			AssetTypeID:   "at-1",
+			PurchaseDate:  xerogolang.NewDate(2020, time.January, 31),
+			PurchasePrice: xerogolang.MustParseDecimal("1500.00"),
+			BookDepreciationSetting: &BookDepreciationSetting{
+				DepreciationMethod: DepreciationMethodStraightLine,
+				AveragingMethod:    AveragingMethodFullMonth,
+				EffectiveLifeYears: 5,
+			},
+		}
+
+		_, err := asset.Create(provider, session)
+		a.NoError(err)
+		a.Equal([]string{
+			`POST /Assets {"assetName":"Desk","assetTypeId":"at-1","purchaseDate":"2020-01-31T00:00:00","purchasePrice":1500.00,` +
+				`"bookDepreciationSetting":{"depreciationMethod":"StraightLine","averagingMethod":"FullMonth","effectiveLifeYears":5}}`,
+		}, *requests)
+	})
+}
+
+func Test_Asset_InvalidStatus(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	_, err := AssetStatus("Sold").MarshalText()
+	a.EqualError(err, `"Sold" is not a valid AssetStatus`)
+	a.False(DepreciationMethod("Linear").IsValid())
+	a.True(AveragingMethodActualDays.IsValid())
+}
+
+func Test_EachAsset(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	responses := []string{
+		`{"pagination":{"page":1,"pageSize":2,"pageCount":2,"itemCount":3},"items":[{"assetId":"a-1"},{"assetId":"a-2"}]}`,
+		`{"pagination":{"page":2,"pageSize":2,"pageCount":2,"itemCount":3},"items":[{"assetId":"a-3","bookDepreciationDetail":{"depreciationStartDate":"2020-02-01T00:00:00","costLimit":1000.5}}]}`,
+	}
+	mockAssets(responses, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		var assetIDs []string
+		var last Asset
+		err := EachAsset(context.Background(), provider, session, AssetStatusRegistered, map[string]string{"pageSize": "2"}, func(asset Asset) error {
+			assetIDs = append(assetIDs, asset.AssetID)
+			last = asset
+			return nil
+		})
+		a.NoError(err)
+		a.Equal([]string{"a-1", "a-2", "a-3"}, assetIDs)
+		a.Equal(xerogolang.NewDate(2020, time.February, 1), last.BookDepreciationDetail.DepreciationStartDate)
+		a.Equal("1000.5", last.BookDepreciationDetail.CostLimit.String())
+
+		//the status is sent in capitals and the last page stops the walk
+		a.Equal([]string{
+			"GET /Assets?page=1&pageSize=2&status=REGISTERED ",
+			"GET /Assets?page=2&pageSize=2&status=REGISTERED ",
+		}, *requests)
+	})
+}
+
+func Test_FindAsset(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockAssets([]string{`{"assetId":"a-1","assetName":"Desk","disposalPrice":250}`}, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		assets, err := FindAsset(provider, session, "a-1")
+		a.NoError(err)
+		a.Equal("Desk", assets.Assets[0].AssetName)
+		a.Equal("250", assets.Assets[0].DisposalPrice.String())
+		a.Equal([]string{"GET /Assets/a-1 "}, *requests)
+	})
+}
@@ -0,0 +1,105 @@
+package assets
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/markbates/goth"
+	"github.com/opensimsim/xerogolang"
+)
+
+//AssetType groups assets that are depreciated the same way and posted to the same accounts
+type AssetType struct {
+
+	// Xero generated unique identifier for asset types
+	AssetTypeID string `json:"assetTypeId,omitempty"`
+
+	// The name of the asset type
+	AssetTypeName string `json:"assetTypeName,omitempty"`
+
+	// The asset account for fixed assets of this type
+	FixedAssetAccountID string `json:"fixedAssetAccountId,omitempty"`
+
+	// The expense account for the depreciation of fixed assets of this type
+	DepreciationExpenseAccountID string `json:"depreciationExpenseAccountId,omitempty"`
+
+	// The account for accumulated depreciation of fixed assets of this type
+	AccumulatedDepreciationAccountID string `json:"accumulatedDepreciationAccountId,omitempty"`
+
+	// How assets of this type are depreciated unless an asset has its own setting
+	BookDepreciationSetting *BookDepreciationSetting `json:"bookDepreciationSetting,omitempty"`
+
+	// All asset types that have accumulated depreciation for any assets that use them are deemed ‘locked’ and cannot be removed
+	Locks int `json:"locks,omitempty"`
+}
+
+//AssetTypes contains a collection of AssetTypes
+type AssetTypes struct {
+	AssetTypes []AssetType `json:"assetTypes"`
+}
+
+//unmarshalAssetTypes reads the asset types, which are returned as a bare list
+func unmarshalAssetTypes(assetTypeResponseBytes []byte) (*AssetTypes, error) {
+	assetTypes := &AssetTypes{}
+	err := json.Unmarshal(assetTypeResponseBytes, &assetTypes.AssetTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	return assetTypes, err
+}
+
+//unmarshalAssetType reads a single asset type, which is returned on its own
+func unmarshalAssetType(assetTypeResponseBytes []byte) (*AssetTypes, error) {
+	var assetType AssetType
+	err := json.Unmarshal(assetTypeResponseBytes, &assetType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AssetTypes{AssetTypes: []AssetType{assetType}}, err
+}
+
+//Create will create an AssetType along with its BookDepreciationSetting
+func (a *AssetType) Create(provider *xerogolang.Provider, session goth.Session) (*AssetTypes, error) {
+	return a.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (a *AssetType) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*AssetTypes, error) {
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/json",
+	}
+
+	body, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+
+	assetTypeResponseBytes, err := provider.UpdateWithEndpointCtx(ctx, session, provider.AssetsEndpoint(), "AssetTypes", additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalAssetType(assetTypeResponseBytes)
+}
+
+//FindAssetTypes will get all AssetTypes
+func FindAssetTypes(provider *xerogolang.Provider, session goth.Session) (*AssetTypes, error) {
+	return FindAssetTypesCtx(context.Background(), provider, session)
+}
+
+//FindAssetTypesCtx is FindAssetTypes with a context that can cancel the request or set its deadline
+func FindAssetTypesCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*AssetTypes, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	assetTypeResponseBytes, err := provider.FindWithEndpointCtx(ctx, session, provider.AssetsEndpoint(), "AssetTypes", additionalHeaders, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalAssetTypes(assetTypeResponseBytes)
+}
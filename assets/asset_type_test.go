@@ -0,0 +1,60 @@
+package assets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/opensimsim/xerogolang"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AssetType_Create(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockAssets([]string{`{"assetTypeId":"at-1","assetTypeName":"Furniture","locks":0}`}, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		assetType := &AssetType{
+			AssetTypeName: "Furniture",
+			BookDepreciationSetting: &BookDepreciationSetting{
+				DepreciationMethod:            DepreciationMethodDiminishingValue100,
+				DepreciationRate:              xerogolang.MustParseDecimal("20"),
+				DepreciationCalculationMethod: DepreciationCalculationMethodRate,
+			},
+		}
+
+		assetTypes, err := assetType.Create(provider, session)
+		a.NoError(err)
+		a.Equal("at-1", assetTypes.AssetTypes[0].AssetTypeID)
+		a.Equal([]string{
+			`POST /AssetTypes {"assetTypeName":"Furniture","bookDepreciationSetting":{"depreciationMethod":"DiminishingValue100","depreciationRate":20,"depreciationCalculationMethod":"Rate"}}`,
+		}, *requests)
+	})
+}
+
+func Test_FindAssetTypes(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockAssets([]string{`[{"assetTypeId":"at-1"},{"assetTypeId":"at-2","locks":3}]`}, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		assetTypes, err := FindAssetTypes(provider, session)
+		a.NoError(err)
+		a.Len(assetTypes.AssetTypes, 2)
+		a.Equal(3, assetTypes.AssetTypes[1].Locks)
+		a.Equal([]string{"GET /AssetTypes "}, *requests)
+	})
+}
+
+func Test_FindSettings(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	response := `{"assetNumberPrefix":"FA-","assetNumberSequence":"0022","assetStartDate":"2015-07-31T00:00:00","optInForTax":false}`
+	mockAssets([]string{response}, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		settings, err := FindSettings(provider, session)
+		a.NoError(err)
+		a.Equal("FA-", settings.AssetNumberPrefix)
+		a.Equal(xerogolang.NewDate(2015, time.July, 31), settings.AssetStartDate)
+		a.True(settings.LastDepreciationDate.IsZero())
+		a.Equal([]string{"GET /Settings "}, *requests)
+	})
+}
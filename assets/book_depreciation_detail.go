@@ -0,0 +1,28 @@
+package assets
+
+import "github.com/opensimsim/xerogolang"
+
+//BookDepreciationDetail is the cost and depreciation of an asset so far
+type BookDepreciationDetail struct {
+
+	// When an asset is disposed, this will be the sell price minus the purchase price if a profit was made
+	CurrentCapitalGain xerogolang.Decimal `json:"currentCapitalGain,omitzero"`
+
+	// When an asset is disposed, this will be the lowest one of sell price or purchase price, minus the current book value
+	CurrentGainLoss xerogolang.Decimal `json:"currentGainLoss,omitzero"`
+
+	// Date the asset starts to depreciate (YYYY-MM-DD)
+	DepreciationStartDate xerogolang.Date `json:"depreciationStartDate,omitzero"`
+
+	// The value of the asset you want to depreciate, if this is less than the cost of the asset
+	CostLimit xerogolang.Decimal `json:"costLimit,omitzero"`
+
+	// The value of the asset remaining when you've fully depreciated it
+	ResidualValue xerogolang.Decimal `json:"residualValue,omitzero"`
+
+	// All depreciation prior to the current financial year
+	PriorAccumDepreciationAmount xerogolang.Decimal `json:"priorAccumDepreciationAmount,omitzero"`
+
+	// All depreciation occurring in the current financial year
+	CurrentAccumDepreciationAmount xerogolang.Decimal `json:"currentAccumDepreciationAmount,omitzero"`
+}
@@ -0,0 +1,31 @@
+package assets
+
+import "github.com/opensimsim/xerogolang"
+
+//BookDepreciationSetting is how an asset, or the assets of an asset type, are depreciated in the books
+type BookDepreciationSetting struct {
+
+	// See DepreciationMethod
+	DepreciationMethod DepreciationMethod `json:"depreciationMethod,omitempty"`
+
+	// See AveragingMethod
+	AveragingMethod AveragingMethod `json:"averagingMethod,omitempty"`
+
+	// The rate of depreciation (e.g. 0.05) when the DepreciationCalculationMethod is Rate
+	DepreciationRate xerogolang.Decimal `json:"depreciationRate,omitzero"`
+
+	// Effective life of the asset in years (e.g. 5) when the DepreciationCalculationMethod is Life
+	EffectiveLifeYears int `json:"effectiveLifeYears,omitempty"`
+
+	// See DepreciationCalculationMethod
+	DepreciationCalculationMethod DepreciationCalculationMethod `json:"depreciationCalculationMethod,omitempty"`
+
+	// Unique Xero identifier for the depreciable object
+	DepreciableObjectID string `json:"depreciableObjectId,omitempty"`
+
+	// The type of asset object
+	DepreciableObjectType string `json:"depreciableObjectType,omitempty"`
+
+	// Unique Xero identifier for the effective date change
+	BookEffectiveDateOfChangeID string `json:"bookEffectiveDateOfChangeId,omitempty"`
+}
@@ -0,0 +1,116 @@
+package assets
+
+import "fmt"
+
+//The types below are the fixed sets of codes the Assets API accepts. Encoding a value that isn't
+//one of the constants fails before the request is sent but any value is kept when decoding.
+//An empty value means the field has not been set
+
+//AssetStatus is the AssetStatus of an Asset
+type AssetStatus string
+
+const (
+	AssetStatusDraft      AssetStatus = "Draft"
+	AssetStatusRegistered AssetStatus = "Registered"
+	AssetStatusDisposed   AssetStatus = "Disposed"
+)
+
+//IsValid reports whether s is one of the AssetStatus constants
+func (s AssetStatus) IsValid() bool {
+	switch s {
+	case AssetStatusDraft, AssetStatusRegistered, AssetStatusDisposed:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown asset statuses
+func (s AssetStatus) MarshalText() ([]byte, error) {
+	return marshalEnum("AssetStatus", string(s), s.IsValid())
+}
+
+//DepreciationMethod is how the book value of an asset is written down
+type DepreciationMethod string
+
+const (
+	DepreciationMethodNoDepreciation DepreciationMethod = "NoDepreciation"
+	DepreciationMethodStraightLine   DepreciationMethod = "StraightLine"
+	//DepreciationMethodDiminishingValue100 writes down the remaining value at the rate each period
+	DepreciationMethodDiminishingValue100 DepreciationMethod = "DiminishingValue100"
+	//DepreciationMethodDiminishingValue150 writes down the remaining value at 1.5 times the rate
+	DepreciationMethodDiminishingValue150 DepreciationMethod = "DiminishingValue150"
+	//DepreciationMethodDiminishingValue200 writes down the remaining value at twice the rate
+	DepreciationMethodDiminishingValue200 DepreciationMethod = "DiminishingValue200"
+	//DepreciationMethodFullDepreciation writes off the whole cost when the asset is registered
+	DepreciationMethodFullDepreciation DepreciationMethod = "FullDepreciation"
+)
+
+//IsValid reports whether m is one of the DepreciationMethod constants
+func (m DepreciationMethod) IsValid() bool {
+	switch m {
+	case DepreciationMethodNoDepreciation, DepreciationMethodStraightLine, DepreciationMethodDiminishingValue100,
+		DepreciationMethodDiminishingValue150, DepreciationMethodDiminishingValue200, DepreciationMethodFullDepreciation:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown depreciation methods
+func (m DepreciationMethod) MarshalText() ([]byte, error) {
+	return marshalEnum("DepreciationMethod", string(m), m.IsValid())
+}
+
+//AveragingMethod is how depreciation is worked out for the period an asset is bought in
+type AveragingMethod string
+
+const (
+	//AveragingMethodFullMonth depreciates the whole of the month the asset is bought in
+	AveragingMethodFullMonth AveragingMethod = "FullMonth"
+	//AveragingMethodActualDays depreciates only the days the asset is owned
+	AveragingMethodActualDays AveragingMethod = "ActualDays"
+)
+
+//IsValid reports whether m is one of the AveragingMethod constants
+func (m AveragingMethod) IsValid() bool {
+	switch m {
+	case AveragingMethodFullMonth, AveragingMethodActualDays:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown averaging methods
+func (m AveragingMethod) MarshalText() ([]byte, error) {
+	return marshalEnum("AveragingMethod", string(m), m.IsValid())
+}
+
+//DepreciationCalculationMethod is whether depreciation follows the DepreciationRate or the EffectiveLifeYears
+type DepreciationCalculationMethod string
+
+const (
+	DepreciationCalculationMethodRate DepreciationCalculationMethod = "Rate"
+	DepreciationCalculationMethodLife DepreciationCalculationMethod = "Life"
+	DepreciationCalculationMethodNone DepreciationCalculationMethod = "None"
+)
+
+//IsValid reports whether m is one of the DepreciationCalculationMethod constants
+func (m DepreciationCalculationMethod) IsValid() bool {
+	switch m {
+	case DepreciationCalculationMethodRate, DepreciationCalculationMethodLife, DepreciationCalculationMethodNone:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown depreciation calculation methods
+func (m DepreciationCalculationMethod) MarshalText() ([]byte, error) {
+	return marshalEnum("DepreciationCalculationMethod", string(m), m.IsValid())
+}
+
+//marshalEnum returns the value to encode, or an error if it is set but not valid
+func marshalEnum(typeName string, value string, valid bool) ([]byte, error) {
+	if value != "" && !valid {
+		return nil, fmt.Errorf("%q is not a valid %s", value, typeName)
+	}
+	return []byte(value), nil
+}
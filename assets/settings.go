@@ -0,0 +1,66 @@
+package assets
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/markbates/goth"
+	"github.com/opensimsim/xerogolang"
+)
+
+//Settings are how the fixed asset register of an organisation is numbered and where disposals are posted
+type Settings struct {
+
+	// The prefix used for fixed asset numbers (“FA-” by default)
+	AssetNumberPrefix string `json:"assetNumberPrefix,omitempty"`
+
+	// The next available sequence number
+	AssetNumberSequence string `json:"assetNumberSequence,omitempty"`
+
+	// The date depreciation calculations started on registered fixed assets in Xero
+	AssetStartDate xerogolang.Date `json:"assetStartDate,omitzero"`
+
+	// The last depreciation date
+	LastDepreciationDate xerogolang.Date `json:"lastDepreciationDate,omitzero"`
+
+	// Default account that gains are posted to
+	DefaultGainOnDisposalAccountID string `json:"defaultGainOnDisposalAccountId,omitempty"`
+
+	// Default account that losses are posted to
+	DefaultLossOnDisposalAccountID string `json:"defaultLossOnDisposalAccountId,omitempty"`
+
+	// Default account that capital gains are posted to
+	DefaultCapitalGainOnDisposalAccountID string `json:"defaultCapitalGainOnDisposalAccountId,omitempty"`
+
+	// Whether tax depreciation is tracked as well as book depreciation
+	OptInForTax bool `json:"optInForTax,omitempty"`
+}
+
+func unmarshalSettings(settingsResponseBytes []byte) (*Settings, error) {
+	var settingsResponse *Settings
+	err := json.Unmarshal(settingsResponseBytes, &settingsResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return settingsResponse, err
+}
+
+//FindSettings will get the asset Settings of the organisation
+func FindSettings(provider *xerogolang.Provider, session goth.Session) (*Settings, error) {
+	return FindSettingsCtx(context.Background(), provider, session)
+}
+
+//FindSettingsCtx is FindSettings with a context that can cancel the request or set its deadline
+func FindSettingsCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Settings, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	settingsResponseBytes, err := provider.FindWithEndpointCtx(ctx, session, provider.AssetsEndpoint(), "Settings", additionalHeaders, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalSettings(settingsResponseBytes)
+}
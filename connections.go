@@ -0,0 +1,132 @@
+package xerogolang
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/markbates/goth"
+)
+
+var (
+	connectionsURL = "https://api.xero.com/connections"
+)
+
+const (
+	//tenantHeader is the header Xero uses to choose the organisation an OAuth 2.0 request is for
+	tenantHeader = "Xero-Tenant-Id"
+)
+
+//Connection is a tenant (an organisation or practice) that the user has authorised the app to access
+type Connection struct {
+	// Xero identifier for the connection - used to disconnect the tenant
+	ID string `json:"id,omitempty"`
+
+	// Identifier of the tenant, sent as the Xero-Tenant-Id header
+	TenantID string `json:"tenantId,omitempty"`
+
+	// The type of tenant e.g. ORGANISATION or PRACTICEMANAGER
+	TenantType string `json:"tenantType,omitempty"`
+
+	// Name of the tenant
+	TenantName string `json:"tenantName,omitempty"`
+
+	// The identifier of the authorisation event that created the connection
+	AuthEventID string `json:"authEventId,omitempty"`
+
+	// Created date UTC format
+	CreatedDateUTC DateTime `json:"createdDateUtc,omitzero"`
+
+	// Last modified date UTC format
+	UpdatedDateUTC DateTime `json:"updatedDateUtc,omitzero"`
+}
+
+//oauth2Session makes sure the session is an authorised OAuth 2.0 session as connections do not exist for OAuth 1.0a
+func oauth2Session(session goth.Session) (*Session, error) {
+	sess := session.(*Session)
+	if sess.OAuth2Token == nil {
+		return nil, errors.New("connections are only available to OAuth 2.0 sessions")
+	}
+	return sess, nil
+}
+
+//FindConnections will get all the tenants the session has been authorised to access
+func (p *Provider) FindConnections(session goth.Session) ([]Connection, error) {
+	return p.FindConnectionsCtx(context.Background(), session)
+}
+
+//FindConnectionsCtx is FindConnections with a context that can cancel the request or set its deadline
+func (p *Provider) FindConnectionsCtx(ctx context.Context, session goth.Session) ([]Connection, error) {
+	sess, err := oauth2Session(session)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, "GET", p.endpoints().Connections, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	connectionResponseBytes, err := p.processRequest(request, sess, additionalHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	var connections []Connection
+	err = json.Unmarshal(connectionResponseBytes, &connections)
+	if err != nil {
+		return nil, fmt.Errorf("Could not unmarshal response: %s", err.Error())
+	}
+
+	return connections, nil
+}
+
+//SelectTenant finds the connection matching a tenant ID or tenant name and makes the session target it
+func (p *Provider) SelectTenant(session goth.Session, tenantIDOrName string) (*Connection, error) {
+	return p.SelectTenantCtx(context.Background(), session, tenantIDOrName)
+}
+
+//SelectTenantCtx is SelectTenant with a context that can cancel the request or set its deadline
+func (p *Provider) SelectTenantCtx(ctx context.Context, session goth.Session, tenantIDOrName string) (*Connection, error) {
+	connections, err := p.FindConnectionsCtx(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, connection := range connections {
+		if connection.TenantID == tenantIDOrName || connection.TenantName == tenantIDOrName {
+			session.(*Session).SelectTenant(connection.TenantID)
+			return &connection, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no connection found for tenant %s", tenantIDOrName)
+}
+
+//RemoveConnection disconnects a tenant so the app can no longer access it.
+//connectionID is the ID of the Connection, not the TenantID
+func (p *Provider) RemoveConnection(session goth.Session, connectionID string) error {
+	return p.RemoveConnectionCtx(context.Background(), session, connectionID)
+}
+
+//RemoveConnectionCtx is RemoveConnection with a context that can cancel the request or set its deadline
+func (p *Provider) RemoveConnectionCtx(ctx context.Context, session goth.Session, connectionID string) error {
+	sess, err := oauth2Session(session)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, "DELETE", p.endpoints().Connections+"/"+connectionID, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.processRequest(request, sess, nil)
+	return err
+}
@@ -0,0 +1,99 @@
+package xerogolang
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mrjones/oauth"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+func testOAuth2Session() *Session {
+	return &Session{OAuth2Token: &oauth2.Token{AccessToken: "ACCESSTOKEN", RefreshToken: "REFRESHTOKEN", TokenType: "Bearer"}}
+}
+
+func echoHeaders(a *assert.Assertions, provider *Provider, session *Session, additionalHeaders map[string]string) http.Header {
+	response, err := provider.Find(session, "Echo", additionalHeaders, nil)
+	a.NoError(err)
+
+	var headers http.Header
+	a.NoError(json.Unmarshal(response, &headers))
+	return headers
+}
+
+func Test_FindConnections(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := NewOAuth2("CLIENT", "SECRET", "/foo")
+		provider.Endpoints = mockEndpoints(ts)
+
+		connections, err := provider.FindConnections(testOAuth2Session())
+		a.NoError(err)
+		a.Len(connections, 2)
+		a.Equal("t-1", connections[0].TenantID)
+		a.Equal("Kramerica Industries", connections[1].TenantName)
+
+		_, err = provider.FindConnections(&Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}})
+		a.Error(err)
+	})
+}
+
+func Test_SelectTenant(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := NewOAuth2("CLIENT", "SECRET", "/foo")
+		provider.Endpoints = mockEndpoints(ts)
+		session := testOAuth2Session()
+
+		connection, err := provider.SelectTenant(session, "Kramerica Industries")
+		a.NoError(err)
+		a.Equal("c-2", connection.ID)
+		a.Equal("t-2", session.TenantID)
+		a.Equal("t-2", echoHeaders(a, provider, session, nil).Get("Xero-Tenant-Id"))
+
+		_, err = provider.SelectTenant(session, "Vandelay")
+		a.Error(err)
+		a.Equal("t-2", session.TenantID)
+	})
+}
+
+func Test_TenantHeader(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := NewOAuth2("CLIENT", "SECRET", "/foo")
+		provider.Endpoints = mockEndpoints(ts)
+		session := testOAuth2Session()
+
+		a.Empty(echoHeaders(a, provider, session, nil).Get("Xero-Tenant-Id"))
+
+		session.SelectTenant("t-1")
+		other := session.WithTenant("t-2")
+		a.Equal("t-1", echoHeaders(a, provider, session, nil).Get("Xero-Tenant-Id"))
+		a.Equal("t-2", echoHeaders(a, provider, other, nil).Get("Xero-Tenant-Id"))
+		a.Equal(session.OAuth2Token, other.OAuth2Token)
+
+		headers := echoHeaders(a, provider, session, map[string]string{"Xero-Tenant-Id": "t-3"})
+		a.Equal([]string{"t-3"}, headers["Xero-Tenant-Id"])
+	})
+}
+
+func Test_RemoveConnection(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := NewOAuth2("CLIENT", "SECRET", "/foo")
+		provider.Endpoints = mockEndpoints(ts)
+
+		a.NoError(provider.RemoveConnection(testOAuth2Session(), "c-1"))
+	})
+}
@@ -0,0 +1,63 @@
+package xerogolang
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FindCtx_CancelledContext(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := NewOAuth2("CLIENT", "SECRET", "/foo")
+		provider.Endpoints = mockEndpoints(ts)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := provider.FindCtx(ctx, testOAuth2Session(), "Echo", nil, nil)
+		a.ErrorIs(err, context.Canceled)
+	})
+}
+
+func Test_FindCtx_DeadlineStopsRetries(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := NewOAuth2("CLIENT", "SECRET", "/foo")
+		provider.Endpoints = mockEndpoints(ts)
+		provider.RetryPolicy.MinBackoff = time.Hour
+		provider.RetryPolicy.Jitter = 0
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		started := time.Now()
+		_, err := provider.FindCtx(ctx, testOAuth2Session().WithTenant("deadline-tenant"), "Unavailable", nil, nil)
+		a.ErrorIs(err, context.DeadlineExceeded)
+		a.True(time.Since(started) < time.Minute)
+	})
+}
+
+func Test_RateLimiter_WaitCtx(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	limiter, clock := fakeRateLimiter()
+	limiter.MinuteLimit = 1
+	limiter.Wait("t-1")()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	release, err := limiter.WaitCtx(ctx, "t-1")
+	a.ErrorIs(err, context.Canceled)
+	a.Nil(release)
+	a.Len(clock.slept, 1)
+}
@@ -0,0 +1,216 @@
+package xerogolang
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	//dateLayout is how Xero expects a date in the organisation's timezone to be sent - without an offset
+	dateLayout = "2006-01-02T00:00:00"
+	//dateTimeLayout is how a UTC time is sent
+	dateTimeLayout = "2006-01-02T15:04:05Z"
+)
+
+var (
+	//dotNetDate matches the .NET JSON dates returned by Xero e.g. /Date(1494201600000+0000)/
+	dotNetDate = regexp.MustCompile(`^/Date\((-?\d+)([+-]\d{4})?\)/$`)
+	//timeLayouts are the ISO 8601 forms Xero uses, with and without a time and offset
+	timeLayouts = []string{
+		time.RFC3339Nano,
+		"2006-01-02T15:04:05.999999999",
+		"2006-01-02",
+	}
+)
+
+//Date is a day in the organisation's own timezone such as the Date or DueDate of an invoice.
+//It is held as midnight UTC on that day and sent to Xero without an offset, so Xero reads it as
+//a local date. The zero value is no date at all. It is left out of XML requests but written as
+//null in JSON, so tag optional fields `json:",omitzero"` (Go 1.24 or later) to leave it out there too
+type Date struct {
+	time.Time
+}
+
+//DateTime is an instant Xero records in UTC such as UpdatedDateUTC. It is always held in UTC.
+//The zero value is no time at all. Like Date it is left out of XML but needs omitzero to be left out of JSON
+type DateTime struct {
+	time.Time
+}
+
+//NewDate creates the Date for a day
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+//DateOf returns the day t falls on in its own location
+func DateOf(t time.Time) Date {
+	if t.IsZero() {
+		return Date{}
+	}
+	return NewDate(t.Year(), t.Month(), t.Day())
+}
+
+//Today returns today's date on this machine's clock
+func Today() Date {
+	return DateOf(time.Now())
+}
+
+//NewDateTime creates a DateTime for the instant t
+func NewDateTime(t time.Time) DateTime {
+	if t.IsZero() {
+		return DateTime{}
+	}
+	return DateTime{t.UTC()}
+}
+
+//parseXeroTime reads a .NET JSON date or an ISO 8601 date or time. Times without an offset are taken to be UTC
+func parseXeroTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if match := dotNetDate.FindStringSubmatch(value); match != nil {
+		//the milliseconds are since the Unix epoch in UTC - the offset only says which timezone the value came from
+		milliseconds, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q", value)
+		}
+		return time.Unix(0, 0).Add(time.Duration(milliseconds) * time.Millisecond).UTC(), nil
+	}
+
+	for _, layout := range timeLayouts {
+		parsed, err := time.Parse(layout, value)
+		if err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+//String returns the date as 2006-01-02, or an empty string for the zero Date
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format("2006-01-02")
+}
+
+//MarshalJSON writes the date without an offset e.g. "2017-05-08T00:00:00". The zero Date is null
+//unless omitzero leaves the field out
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + d.Format(dateLayout) + `"`), nil
+}
+
+//UnmarshalJSON reads a .NET JSON date such as "/Date(1494201600000+0000)/" or an ISO 8601 date
+func (d *Date) UnmarshalJSON(data []byte) error {
+	value, err := unquoteJSONTime(data)
+	if err != nil {
+		return err
+	}
+	parsed, err := parseXeroTime(value)
+	if err != nil {
+		return err
+	}
+	*d = DateOf(parsed)
+	return nil
+}
+
+//MarshalXML writes the date without an offset. The zero Date is left out
+func (d Date) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if d.IsZero() {
+		return nil
+	}
+	return e.EncodeElement(d.Format(dateLayout), start)
+}
+
+//UnmarshalXML reads an ISO 8601 date. An empty element is the zero Date
+func (d *Date) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	parsed, err := decodeXMLTime(decoder, start)
+	if err != nil {
+		return err
+	}
+	*d = DateOf(parsed)
+	return nil
+}
+
+//String returns the time in RFC 3339 format, or an empty string for the zero DateTime
+func (d DateTime) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.UTC().Format(time.RFC3339)
+}
+
+//MarshalJSON writes the time in UTC e.g. "2017-05-08T10:15:32Z". The zero DateTime is null
+//unless omitzero leaves the field out
+func (d DateTime) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + d.UTC().Format(dateTimeLayout) + `"`), nil
+}
+
+//UnmarshalJSON reads a .NET JSON date such as "/Date(1494239732123+0000)/" or an ISO 8601 time
+func (d *DateTime) UnmarshalJSON(data []byte) error {
+	value, err := unquoteJSONTime(data)
+	if err != nil {
+		return err
+	}
+	parsed, err := parseXeroTime(value)
+	if err != nil {
+		return err
+	}
+	*d = NewDateTime(parsed)
+	return nil
+}
+
+//MarshalXML writes the time in UTC. The zero DateTime is left out
+func (d DateTime) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if d.IsZero() {
+		return nil
+	}
+	return e.EncodeElement(d.UTC().Format(dateTimeLayout), start)
+}
+
+//UnmarshalXML reads an ISO 8601 time. An empty element is the zero DateTime
+func (d *DateTime) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	parsed, err := decodeXMLTime(decoder, start)
+	if err != nil {
+		return err
+	}
+	*d = NewDateTime(parsed)
+	return nil
+}
+
+//unquoteJSONTime returns the string held by a JSON value, treating null as empty. Xero escapes the
+//slashes of its dates as \/Date(1494201600000+0000)\/ so the value is decoded as JSON rather than Go
+func unquoteJSONTime(data []byte) (string, error) {
+	value := string(data)
+	if value == "null" {
+		return "", nil
+	}
+	var unquoted string
+	err := json.Unmarshal(data, &unquoted)
+	if err != nil {
+		return "", fmt.Errorf("invalid date %s", value)
+	}
+	return unquoted, nil
+}
+
+func decodeXMLTime(decoder *xml.Decoder, start xml.StartElement) (time.Time, error) {
+	var value string
+	err := decoder.DecodeElement(&value, &start)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return parseXeroTime(value)
+}
@@ -0,0 +1,129 @@
+package xerogolang
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Date_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	for value, expected := range map[string]Date{
+		`"/Date(1494201600000+0000)/"`:   NewDate(2017, time.May, 8),
+		`"/Date(1494201600000+1200)/"`:   NewDate(2017, time.May, 8),
+		`"/Date(1494201600000)/"`:        NewDate(2017, time.May, 8),
+		`"/Date(-86400000+0000)/"`:       NewDate(1969, time.December, 31),
+		`"\/Date(1494201600000+0000)\/"`: NewDate(2017, time.May, 8),
+		`"2017-05-08T00:00:00"`:          NewDate(2017, time.May, 8),
+		`"2017-05-08"`:                   NewDate(2017, time.May, 8),
+		`"2017-05-08T00:00:00+12:00"`:    NewDate(2017, time.May, 8),
+		`""`:                             {},
+		`null`:                           {},
+	} {
+		var d Date
+		a.NoError(json.Unmarshal([]byte(value), &d), value)
+		a.True(expected.Equal(d.Time), "%s gave %s", value, d)
+	}
+
+	for _, value := range []string{`"8 May 2017"`, `"/Date(abc)/"`, `1494201600000`} {
+		var d Date
+		a.Error(json.Unmarshal([]byte(value), &d), value)
+	}
+}
+
+func Test_DateTime_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	expected := time.Date(2017, time.May, 8, 10, 35, 32, 123000000, time.UTC)
+	for _, value := range []string{
+		`"/Date(1494239732123+0000)/"`,
+		`"/Date(1494239732123+1200)/"`,
+		`"\/Date(1494239732123+0000)\/"`,
+		`"2017-05-08T10:35:32.123"`,
+		`"2017-05-08T10:35:32.123Z"`,
+		`"2017-05-08T22:35:32.123+12:00"`,
+	} {
+		var d DateTime
+		a.NoError(json.Unmarshal([]byte(value), &d), value)
+		a.True(expected.Equal(d.Time), "%s gave %s", value, d)
+		a.Equal(time.UTC, d.Location(), value)
+	}
+}
+
+func Test_Date_Marshal(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	//the date is sent without an offset so Xero reads it in the organisation's timezone
+	d := DateOf(time.Date(2017, time.May, 8, 23, 30, 0, 0, time.FixedZone("NZST", 12*60*60)))
+	a.Equal("2017-05-08", d.String())
+
+	body, err := json.Marshal(d)
+	a.NoError(err)
+	a.Equal(`"2017-05-08T00:00:00"`, string(body))
+
+	body, err = json.Marshal(Date{})
+	a.NoError(err)
+	a.Equal("null", string(body))
+
+	type invoice struct {
+		XMLName xml.Name `xml:"Invoice"`
+		Date    Date     `xml:"Date,omitempty"`
+		DueDate Date     `xml:"DueDate,omitempty"`
+	}
+	body, err = xml.Marshal(invoice{Date: d})
+	a.NoError(err)
+	a.Equal("<Invoice><Date>2017-05-08T00:00:00</Date></Invoice>", string(body))
+
+	var decoded invoice
+	a.NoError(xml.Unmarshal([]byte("<Invoice><Date>2017-05-08T00:00:00</Date><DueDate></DueDate></Invoice>"), &decoded))
+	a.True(d.Equal(decoded.Date.Time))
+	a.True(decoded.DueDate.IsZero())
+}
+
+func Test_DateTime_Marshal(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	d := NewDateTime(time.Date(2017, time.May, 8, 22, 35, 32, 0, time.FixedZone("NZST", 12*60*60)))
+	a.Equal("2017-05-08T10:35:32Z", d.String())
+
+	body, err := json.Marshal(d)
+	a.NoError(err)
+	a.Equal(`"2017-05-08T10:35:32Z"`, string(body))
+
+	var roundTrip DateTime
+	a.NoError(json.Unmarshal(body, &roundTrip))
+	a.True(d.Equal(roundTrip.Time))
+
+	type report struct {
+		XMLName        xml.Name `xml:"Report"`
+		UpdatedDateUTC DateTime `xml:"UpdatedDateUTC,omitempty"`
+	}
+	body, err = xml.Marshal(report{})
+	a.NoError(err)
+	a.Equal("<Report></Report>", string(body))
+}
+
+func Test_Date_OmitZero(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	type asset struct {
+		PurchaseDate   Date     `json:"purchaseDate,omitzero"`
+		DisposalDate   Date     `json:"disposalDate,omitzero"`
+		UpdatedDateUTC DateTime `json:"updatedDateUTC,omitzero"`
+		WarrantyExpiry Date     `json:"warrantyExpiry,omitempty"`
+	}
+
+	//omitempty can't leave out a struct so the zero date is sent as null
+	body, err := json.Marshal(asset{PurchaseDate: NewDate(2020, time.January, 31)})
+	a.NoError(err)
+	a.Equal(`{"purchaseDate":"2020-01-31T00:00:00","warrantyExpiry":null}`, string(body))
+}
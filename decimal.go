@@ -0,0 +1,393 @@
+package xerogolang
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+//Decimal is an exact decimal number used for money, quantities and rates. Unlike a float it holds
+//values such as 0.10 or 123456789.1234 exactly, and it keeps the number of decimal places it was
+//given so 395.00 is sent back to Xero as 395.00. The zero value is 0.
+//
+//Decimals are values: every operation returns a new Decimal and leaves its operands alone, so they
+//can be copied freely. Use Cmp or Equal to compare them as == compares their storage, not their value.
+//
+//MarshalJSON always writes a number, so tag optional fields `json:",omitzero"` (Go 1.24 or later)
+//rather than omitempty for a zero Decimal to be left out of a request
+type Decimal struct {
+	//unscaled is never changed once it is set, which is what lets copies share it. nil is zero
+	unscaled *big.Int
+	scale    int32
+}
+
+//RoundingMode chooses which way Round and Div go when a value lies between two results
+type RoundingMode int
+
+const (
+	//RoundHalfUp rounds to the nearest value, and away from zero when halfway. This is how Xero rounds
+	RoundHalfUp RoundingMode = iota
+	//RoundHalfEven rounds to the nearest value, and to the even digit when halfway (banker's rounding)
+	RoundHalfEven
+	//RoundHalfDown rounds to the nearest value, and towards zero when halfway
+	RoundHalfDown
+	//RoundUp rounds away from zero
+	RoundUp
+	//RoundDown rounds towards zero, truncating
+	RoundDown
+	//RoundCeiling rounds towards positive infinity
+	RoundCeiling
+	//RoundFloor rounds towards negative infinity
+	RoundFloor
+)
+
+//maxDecimalExponent stops a value such as 1e999999999 from allocating an enormous number
+const maxDecimalExponent = 1000
+
+var (
+	bigTen  = big.NewInt(10)
+	bigZero = new(big.Int)
+
+	//currencyDecimalPlaces lists the currencies whose minor unit is not a hundredth
+	currencyDecimalPlaces = map[string]int32{
+		"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+		"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+		"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	}
+)
+
+//newDecimal copies value into a new Decimal so it never shares memory with the caller
+func newDecimal(value *big.Int, scale int32) Decimal {
+	d := Decimal{scale: scale}
+	if value.Sign() != 0 {
+		d.unscaled = new(big.Int).Set(value)
+	}
+	return d
+}
+
+//int returns the unscaled value of d. It must only be read, as copies of d share it
+func (d Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return bigZero
+	}
+	return d.unscaled
+}
+
+//NewDecimal creates the Decimal unscaled × 10^-scale e.g. NewDecimal(39500, 2) is 395.00
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	if scale < 0 {
+		return newDecimal(new(big.Int).Mul(big.NewInt(unscaled), pow10(-scale)), 0)
+	}
+	return newDecimal(big.NewInt(unscaled), scale)
+}
+
+//DecimalFromInt creates a whole number Decimal
+func DecimalFromInt(value int64) Decimal {
+	return NewDecimal(value, 0)
+}
+
+//DecimalFromFloat creates the Decimal with the fewest digits that converts back to value exactly,
+//so DecimalFromFloat(0.1) is 0.1 rather than the binary approximation held by the float
+func DecimalFromFloat(value float64) Decimal {
+	d, err := ParseDecimal(strconv.FormatFloat(value, 'f', -1, 64))
+	if err != nil {
+		//NaN and infinities have no decimal value
+		return Decimal{}
+	}
+	return d
+}
+
+//ParseDecimal reads a decimal number such as 395.00, -0.125 or 1.5E-3
+func ParseDecimal(value string) (Decimal, error) {
+	s := strings.TrimSpace(value)
+	exponent := 0
+	if index := strings.IndexAny(s, "eE"); index >= 0 {
+		var err error
+		exponent, err = strconv.Atoi(s[index+1:])
+		if err != nil || exponent > maxDecimalExponent || exponent < -maxDecimalExponent {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", value)
+		}
+		s = s[:index]
+	}
+
+	negative := false
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+
+	integerPart, fractionPart := s, ""
+	if index := strings.IndexByte(s, '.'); index >= 0 {
+		integerPart, fractionPart = s[:index], s[index+1:]
+	}
+	digits := integerPart + fractionPart
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", value)
+	}
+
+	unscaled, _ := new(big.Int).SetString(digits, 10)
+	if negative {
+		unscaled.Neg(unscaled)
+	}
+	scale := int32(len(fractionPart) - exponent)
+	if scale < 0 {
+		unscaled.Mul(unscaled, pow10(-scale))
+		scale = 0
+	}
+	return newDecimal(unscaled, scale), nil
+}
+
+//MustParseDecimal is ParseDecimal for constants in code. It panics if value is not a decimal
+func MustParseDecimal(value string) Decimal {
+	d, err := ParseDecimal(value)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+//rescale returns the unscaled value of d with scale digits after the point. scale must not be less than d.scale
+func (d Decimal) rescale(scale int32) *big.Int {
+	return new(big.Int).Mul(d.int(), pow10(scale-d.scale))
+}
+
+//align returns the unscaled values of a and b at the larger of their scales
+func align(a, b Decimal) (*big.Int, *big.Int, int32) {
+	scale := a.scale
+	if b.scale > scale {
+		scale = b.scale
+	}
+	return a.rescale(scale), b.rescale(scale), scale
+}
+
+//Scale is the number of digits after the decimal point
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+//Sign returns -1, 0 or 1 when d is negative, zero or positive
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+//IsZero reports whether d is zero, whatever its scale
+func (d Decimal) IsZero() bool {
+	return d.int().Sign() == 0
+}
+
+//Cmp returns -1, 0 or 1 when d is less than, equal to or greater than other
+func (d Decimal) Cmp(other Decimal) int {
+	a, b, _ := align(d, other)
+	return a.Cmp(b)
+}
+
+//Equal reports whether d and other are the same number, so 1.5 equals 1.50
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+//Add returns d + other
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b, scale := align(d, other)
+	return newDecimal(a.Add(a, b), scale)
+}
+
+//Sub returns d - other
+func (d Decimal) Sub(other Decimal) Decimal {
+	a, b, scale := align(d, other)
+	return newDecimal(a.Sub(a, b), scale)
+}
+
+//Mul returns d × other exactly. The result has as many decimal places as d and other together
+func (d Decimal) Mul(other Decimal) Decimal {
+	return newDecimal(new(big.Int).Mul(d.int(), other.int()), d.scale+other.scale)
+}
+
+//Div returns d ÷ other rounded to places decimal places. It panics if other is zero
+func (d Decimal) Div(other Decimal, places int32, mode RoundingMode) Decimal {
+	if other.IsZero() {
+		panic("xerogolang: division of a Decimal by zero")
+	}
+	numerator := new(big.Int).Set(d.int())
+	denominator := new(big.Int).Set(other.int())
+	exponent := other.scale - d.scale + places
+	if exponent >= 0 {
+		numerator.Mul(numerator, pow10(exponent))
+	} else {
+		denominator.Mul(denominator, pow10(-exponent))
+	}
+	return newDecimal(roundQuotient(numerator, denominator, mode), places)
+}
+
+//Neg returns -d
+func (d Decimal) Neg() Decimal {
+	return newDecimal(new(big.Int).Neg(d.int()), d.scale)
+}
+
+//Abs returns d without its sign
+func (d Decimal) Abs() Decimal {
+	return newDecimal(new(big.Int).Abs(d.int()), d.scale)
+}
+
+//Round returns d with exactly places decimal places, rounding with mode when digits are dropped
+func (d Decimal) Round(places int32, mode RoundingMode) Decimal {
+	if places >= d.scale {
+		return newDecimal(d.rescale(places), places)
+	}
+	return newDecimal(roundQuotient(new(big.Int).Set(d.int()), pow10(d.scale-places), mode), places)
+}
+
+//roundQuotient divides numerator by denominator and rounds the result with mode
+func roundQuotient(numerator, denominator *big.Int, mode RoundingMode) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient
+	}
+
+	//the quotient was truncated towards zero so it moves away from zero, in the direction of the result's sign, when rounded up
+	sign := numerator.Sign() * denominator.Sign()
+	//halfCmp compares the remainder with half of the denominator
+	twiceRemainder := new(big.Int).Abs(remainder)
+	twiceRemainder.Lsh(twiceRemainder, 1)
+	halfCmp := twiceRemainder.Cmp(new(big.Int).Abs(denominator))
+
+	awayFromZero := false
+	switch mode {
+	case RoundHalfUp:
+		awayFromZero = halfCmp >= 0
+	case RoundHalfDown:
+		awayFromZero = halfCmp > 0
+	case RoundHalfEven:
+		awayFromZero = halfCmp > 0 || (halfCmp == 0 && quotient.Bit(0) == 1)
+	case RoundUp:
+		awayFromZero = true
+	case RoundDown:
+		awayFromZero = false
+	case RoundCeiling:
+		awayFromZero = sign > 0
+	case RoundFloor:
+		awayFromZero = sign < 0
+	}
+	if awayFromZero {
+		quotient.Add(quotient, big.NewInt(int64(sign)))
+	}
+	return quotient
+}
+
+//Float64 returns the nearest float64 to d, for use with code that needs one
+func (d Decimal) Float64() float64 {
+	value, _ := strconv.ParseFloat(d.String(), 64)
+	return value
+}
+
+//String returns d in plain notation with all of its decimal places e.g. -1234.50
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.int()).String()
+	if d.scale > 0 {
+		if len(digits) <= int(d.scale) {
+			digits = strings.Repeat("0", int(d.scale)-len(digits)+1) + digits
+		}
+		point := len(digits) - int(d.scale)
+		digits = digits[:point] + "." + digits[point:]
+	}
+	if d.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+//StringFixed returns d rounded half up to places decimal places e.g. 1234.5 to 2 places is 1234.50
+func (d Decimal) StringFixed(places int32) string {
+	return d.Round(places, RoundHalfUp).String()
+}
+
+//CurrencyDecimalPlaces is the number of decimal places used by an ISO 4217 currency e.g. 2 for NZD and 0 for JPY
+func CurrencyDecimalPlaces(currencyCode string) int32 {
+	places, ok := currencyDecimalPlaces[strings.ToUpper(currencyCode)]
+	if !ok {
+		return 2
+	}
+	return places
+}
+
+//FormatMoney rounds d half up to the currency's decimal places and formats it with the currency code
+//and thousands separators e.g. NZD 1,234.50, JPY -1,235 or KWD 0.125
+func (d Decimal) FormatMoney(currencyCode string) string {
+	rounded := d.Round(CurrencyDecimalPlaces(currencyCode), RoundHalfUp)
+	digits := rounded.Abs().String()
+	integerPart, fractionPart := digits, ""
+	if index := strings.IndexByte(digits, '.'); index >= 0 {
+		integerPart, fractionPart = digits[:index], digits[index:]
+	}
+
+	var grouped strings.Builder
+	for n, digit := range integerPart {
+		if n > 0 && (len(integerPart)-n)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+
+	sign := ""
+	if rounded.Sign() < 0 {
+		sign = "-"
+	}
+	return strings.ToUpper(currencyCode) + " " + sign + grouped.String() + fractionPart
+}
+
+//MarshalJSON writes d as a JSON number with all of its decimal places. Zero is written as a number
+//too; it is the omitzero tag option that leaves it out
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+//UnmarshalJSON reads a JSON number or a string holding one. null leaves d unchanged
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	value := string(data)
+	if value == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+	parsed, err := ParseDecimal(value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+//MarshalXML writes d as the text of an element. Zero is left out altogether, as the omitempty
+//float fields Decimal replaced were, so requests don't overwrite values Xero calculates
+func (d Decimal) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if d.IsZero() {
+		return nil
+	}
+	return e.EncodeElement(d.String(), start)
+}
+
+//UnmarshalXML reads the text of an element. An empty element is zero
+func (d *Decimal) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	var value string
+	err := decoder.DecodeElement(&value, &start)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(value) == "" {
+		*d = Decimal{}
+		return nil
+	}
+	parsed, err := ParseDecimal(value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,182 @@
+package xerogolang
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseDecimal(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	for value, expected := range map[string]string{
+		"395.00":  "395.00",
+		"-0.125":  "-0.125",
+		"+12":     "12",
+		".5":      "0.5",
+		"1.5E-3":  "0.0015",
+		"2.5e2":   "250",
+		" 7.10 ":  "7.10",
+		"-0.0001": "-0.0001",
+	} {
+		d, err := ParseDecimal(value)
+		a.NoError(err, value)
+		a.Equal(expected, d.String(), value)
+	}
+
+	for _, value := range []string{"", ".", "-", "1.2.3", "abc", "1e", "1e99999"} {
+		_, err := ParseDecimal(value)
+		a.Error(err, value)
+	}
+}
+
+func Test_Decimal_Exact(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	//a float32 can't even hold this total to the nearest dollar
+	total := MustParseDecimal("123456789.12")
+	a.Equal("123456789.13", total.Add(MustParseDecimal("0.01")).String())
+
+	sum := Decimal{}
+	for i := 0; i < 10; i++ {
+		sum = sum.Add(MustParseDecimal("0.1"))
+	}
+	a.True(sum.Equal(DecimalFromInt(1)))
+	a.Equal("1.0", sum.String())
+
+	a.Equal("0.1", DecimalFromFloat(0.1).String())
+	a.Equal("14.197440", MustParseDecimal("12.3456").Mul(MustParseDecimal("1.15")).String())
+	a.Equal("14.1974", MustParseDecimal("12.3456").Mul(MustParseDecimal("1.15")).Round(4, RoundHalfUp).String())
+	a.Equal("-2.50", MustParseDecimal("2.50").Neg().String())
+	a.Equal("2.50", MustParseDecimal("-2.50").Abs().String())
+	a.Equal(-1, MustParseDecimal("1.5").Cmp(MustParseDecimal("1.51")))
+	a.Equal("395.00", NewDecimal(39500, 2).String())
+	a.Equal("1500", NewDecimal(15, -2).String())
+	a.InDelta(12.25, MustParseDecimal("12.25").Float64(), 0)
+}
+
+func Test_Decimal_Round(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	cases := []struct {
+		value    string
+		mode     RoundingMode
+		expected string
+	}{
+		{"2.345", RoundHalfUp, "2.35"},
+		{"-2.345", RoundHalfUp, "-2.35"},
+		{"2.345", RoundHalfEven, "2.34"},
+		{"2.355", RoundHalfEven, "2.36"},
+		{"2.345", RoundHalfDown, "2.34"},
+		{"2.341", RoundUp, "2.35"},
+		{"-2.349", RoundDown, "-2.34"},
+		{"-2.341", RoundCeiling, "-2.34"},
+		{"-2.341", RoundFloor, "-2.35"},
+		{"2.341", RoundCeiling, "2.35"},
+		{"2.3", RoundHalfUp, "2.30"},
+	}
+	for _, c := range cases {
+		a.Equal(c.expected, MustParseDecimal(c.value).Round(2, c.mode).String(), c.value)
+	}
+}
+
+func Test_Decimal_Div(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	a.Equal("33.33", DecimalFromInt(100).Div(DecimalFromInt(3), 2, RoundHalfUp).String())
+	a.Equal("-0.6667", DecimalFromInt(-2).Div(DecimalFromInt(3), 4, RoundHalfUp).String())
+	a.Equal("0.1250", MustParseDecimal("0.5").Div(DecimalFromInt(4), 4, RoundHalfUp).String())
+	a.Panics(func() { DecimalFromInt(1).Div(Decimal{}, 2, RoundHalfUp) })
+}
+
+func Test_Decimal_FormatMoney(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	a.Equal("NZD 1,234,567.50", MustParseDecimal("1234567.5").FormatMoney("NZD"))
+	a.Equal("JPY -1,235", MustParseDecimal("-1234.5").FormatMoney("jpy"))
+	a.Equal("KWD 0.125", MustParseDecimal("0.1245").FormatMoney("KWD"))
+	a.Equal("AUD 100.00", DecimalFromInt(100).FormatMoney("AUD"))
+}
+
+func Test_Decimal_JSON(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var line struct {
+		UnitAmount   Decimal
+		CurrencyRate Decimal
+		Quantity     Decimal
+	}
+	a.NoError(json.Unmarshal([]byte(`{"UnitAmount":395.0000,"CurrencyRate":"1.123456","Quantity":null}`), &line))
+	a.Equal("395.0000", line.UnitAmount.String())
+	a.Equal("1.123456", line.CurrencyRate.String())
+	a.True(line.Quantity.IsZero())
+
+	encoded, err := json.Marshal(line)
+	a.NoError(err)
+	a.Equal(`{"UnitAmount":395.0000,"CurrencyRate":1.123456,"Quantity":0}`, string(encoded))
+}
+
+func Test_Decimal_OmitZero(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	type lineItem struct {
+		UnitAmount   Decimal `json:",omitzero"`
+		DiscountRate Decimal `json:",omitzero"`
+	}
+
+	encoded, err := json.Marshal(lineItem{UnitAmount: MustParseDecimal("12.34"), DiscountRate: MustParseDecimal("0.00")})
+	a.NoError(err)
+	a.Equal(`{"UnitAmount":12.34}`, string(encoded))
+
+	encoded, err = json.Marshal(lineItem{})
+	a.NoError(err)
+	a.Equal(`{}`, string(encoded))
+}
+
+func Test_Decimal_Copy(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	original := MustParseDecimal("10.50")
+	copied := original
+	a.Equal("21.00", copied.Add(original).String())
+	a.Equal("-10.50", copied.Neg().String())
+	a.Equal("10.50", original.String())
+	a.Equal("10.50", copied.String())
+
+	//models holding Decimals can still be compared and used as map keys
+	type amount struct {
+		Value Decimal
+	}
+	var zero amount
+	a.True(zero == amount{})
+	a.True(zero.Value.Equal(DecimalFromInt(0)))
+}
+
+func Test_Decimal_XML(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	type lineItem struct {
+		UnitAmount Decimal `xml:"UnitAmount,omitempty"`
+		TaxAmount  Decimal `xml:"TaxAmount,omitempty"`
+	}
+
+	encoded, err := xml.Marshal(lineItem{UnitAmount: MustParseDecimal("12.3400")})
+	a.NoError(err)
+	a.Equal(`<lineItem><UnitAmount>12.3400</UnitAmount></lineItem>`, string(encoded))
+
+	var decoded lineItem
+	a.NoError(xml.Unmarshal([]byte(`<lineItem><UnitAmount>99999999.99</UnitAmount><TaxAmount></TaxAmount></lineItem>`), &decoded))
+	a.Equal("99999999.99", decoded.UnitAmount.String())
+	a.True(decoded.TaxAmount.IsZero())
+}
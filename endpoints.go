@@ -0,0 +1,98 @@
+package xerogolang
+
+var (
+	payrollEndpoint   = "https://api.xero.com/payroll.xro/1.0/"
+	payrollV2Endpoint = "https://api.xero.com/payroll.xro/2.0/"
+	assetsEndpoint    = "https://api.xero.com/assets.xro/1.0/"
+)
+
+//Endpoints are the base URLs a Provider sends requests to. Change them to talk to a regional or
+//proxy endpoint, or to a local fake in integration tests. Any left empty use Xero's own.
+//Set them before the provider is used as the OAuth 1.0a consumer keeps the URLs it was created with
+type Endpoints struct {
+	// The Accounting API e.g. https://api.xero.com/api.xro/2.0/
+	Accounting string
+
+	// The AU Payroll API e.g. https://api.xero.com/payroll.xro/1.0/
+	Payroll string
+
+	// The UK and NZ Payroll APIs e.g. https://api.xero.com/payroll.xro/2.0/
+	PayrollV2 string
+
+	// The Assets API e.g. https://api.xero.com/assets.xro/1.0/
+	Assets string
+
+	// The identity API used to find and remove connections e.g. https://api.xero.com/connections
+	Connections string
+
+	// The OAuth 1.0a request token, authorize and access token URLs
+	RequestToken string
+	Authorize    string
+	AccessToken  string
+
+	// The OAuth 2.0 authorize and token URLs
+	OAuth2Authorize string
+	OAuth2Token     string
+}
+
+//DefaultEndpoints returns the URLs of Xero's APIs
+func DefaultEndpoints() Endpoints {
+	return Endpoints{
+		Accounting:      endpointProfile,
+		Payroll:         payrollEndpoint,
+		PayrollV2:       payrollV2Endpoint,
+		Assets:          assetsEndpoint,
+		Connections:     connectionsURL,
+		RequestToken:    requestURL,
+		Authorize:       authorizeURL,
+		AccessToken:     tokenURL,
+		OAuth2Authorize: oauth2AuthorizeURL,
+		OAuth2Token:     oauth2TokenURL,
+	}
+}
+
+//endpoints returns the provider's endpoints with any that are empty set to the defaults
+func (p *Provider) endpoints() Endpoints {
+	endpoints := p.Endpoints
+	defaults := DefaultEndpoints()
+	for _, endpoint := range []struct {
+		value    *string
+		fallback string
+	}{
+		{&endpoints.Accounting, defaults.Accounting},
+		{&endpoints.Payroll, defaults.Payroll},
+		{&endpoints.PayrollV2, defaults.PayrollV2},
+		{&endpoints.Assets, defaults.Assets},
+		{&endpoints.Connections, defaults.Connections},
+		{&endpoints.RequestToken, defaults.RequestToken},
+		{&endpoints.Authorize, defaults.Authorize},
+		{&endpoints.AccessToken, defaults.AccessToken},
+		{&endpoints.OAuth2Authorize, defaults.OAuth2Authorize},
+		{&endpoints.OAuth2Token, defaults.OAuth2Token},
+	} {
+		if *endpoint.value == "" {
+			*endpoint.value = endpoint.fallback
+		}
+	}
+	return endpoints
+}
+
+//AccountingEndpoint is the base URL the provider sends Accounting API requests to
+func (p *Provider) AccountingEndpoint() string {
+	return p.endpoints().Accounting
+}
+
+//PayrollEndpoint is the base URL the provider sends AU Payroll API requests to
+func (p *Provider) PayrollEndpoint() string {
+	return p.endpoints().Payroll
+}
+
+//PayrollV2Endpoint is the base URL the provider sends UK and NZ Payroll API requests to
+func (p *Provider) PayrollV2Endpoint() string {
+	return p.endpoints().PayrollV2
+}
+
+//AssetsEndpoint is the base URL the provider sends Assets API requests to
+func (p *Provider) AssetsEndpoint() string {
+	return p.endpoints().Assets
+}
@@ -0,0 +1,95 @@
+package xerogolang
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DefaultEndpoints(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	provider := NewOAuth2("CLIENT", "SECRET", "/foo")
+	a.Equal("https://api.xero.com/payroll.xro/1.0/", provider.PayrollEndpoint())
+	a.Equal("https://api.xero.com/payroll.xro/2.0/", provider.PayrollV2Endpoint())
+	a.Equal("https://api.xero.com/assets.xro/1.0/", provider.AssetsEndpoint())
+
+	//endpoints left empty fall back to Xero's own
+	provider.Endpoints = Endpoints{Accounting: "https://proxy.example.com/api.xro/2.0/"}
+	a.Equal("https://proxy.example.com/api.xro/2.0/", provider.AccountingEndpoint())
+	a.Equal("https://api.xero.com/payroll.xro/1.0/", provider.PayrollEndpoint())
+	a.True(strings.HasPrefix(provider.oauth2Config().Endpoint.TokenURL, "https://identity.xero.com/"))
+}
+
+func Test_Endpoints_PerProvider(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	//this fake is not installed by mockXero so the provider can only reach it through its Endpoints
+	mux := http.NewServeMux()
+	mux.HandleFunc("/fake/api.xro/2.0/Echo", func(res http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(res, `{"API":"accounting"}`)
+	})
+	mux.HandleFunc("/fake/payroll.xro/1.0/Echo", func(res http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(res, `{"API":"payroll"}`)
+	})
+	mux.HandleFunc("/fake/connections", func(res http.ResponseWriter, req *http.Request) {
+		json.NewEncoder(res).Encode([]Connection{{ID: "c-9", TenantID: "t-9"}})
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	provider := NewOAuth2("CLIENT", "SECRET", "/foo")
+	provider.Endpoints = Endpoints{
+		Accounting:  ts.URL + "/fake/api.xro/2.0/",
+		Payroll:     ts.URL + "/fake/payroll.xro/1.0/",
+		Connections: ts.URL + "/fake/connections",
+	}
+	session := testOAuth2Session()
+
+	response, err := provider.Find(session, "Echo", nil, nil)
+	a.NoError(err)
+	a.Equal(`{"API":"accounting"}`, string(response))
+
+	response, err = provider.FindWithEndpoint(session, provider.PayrollEndpoint(), "Echo", nil, nil)
+	a.NoError(err)
+	a.Equal(`{"API":"payroll"}`, string(response))
+
+	connections, err := provider.FindConnections(session)
+	a.NoError(err)
+	a.Equal("t-9", connections[0].TenantID)
+}
+
+func Test_WithEndpoint(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/fake/payroll.xro/1.0/Employees", func(res http.ResponseWriter, req *http.Request) {
+		fmt.Fprintf(res, `{"Method":%q}`, req.Method)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	provider := NewOAuth2("CLIENT", "SECRET", "/foo")
+	provider.Endpoints = Endpoints{Payroll: ts.URL + "/fake/payroll.xro/1.0/"}
+	session := testOAuth2Session()
+
+	response, err := provider.CreateWithEndpoint(session, provider.PayrollEndpoint(), "Employees", nil, []byte("<Employees />"))
+	a.NoError(err)
+	a.Equal(`{"Method":"PUT"}`, string(response))
+
+	response, err = provider.UpdateWithEndpoint(session, provider.PayrollEndpoint(), "Employees", nil, []byte("<Employees />"))
+	a.NoError(err)
+	a.Equal(`{"Method":"POST"}`, string(response))
+
+	response, err = provider.RemoveWithEndpoint(session, provider.PayrollEndpoint(), "Employees", nil)
+	a.NoError(err)
+	a.Equal(`{"Method":"DELETE"}`, string(response))
+}
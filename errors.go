@@ -0,0 +1,283 @@
+package xerogolang
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+//APIError is returned whenever Xero responds with a status code outside of the 2xx range.
+//Use errors.As to get at the details:
+//
+//	var apiError *xerogolang.APIError
+//	if errors.As(err, &apiError) && apiError.IsValidation() {
+//		for _, validationError := range apiError.ValidationErrors {
+//			log.Println(validationError.Element, validationError.Message)
+//		}
+//	}
+type APIError struct {
+	// The HTTP status code of the response e.g. 400
+	StatusCode int
+
+	// The Xero error number e.g. 10 for a ValidationException
+	ErrorNumber int
+
+	// The type of error e.g. ValidationException, QueryParseException, token_expired
+	Type string
+
+	// A description of the error
+	Message string
+
+	// The validation errors reported against each element that was sent
+	ValidationErrors []ValidationError
+
+	// How long Xero asked us to wait before trying again. Only set on 429 responses
+	RetryAfter time.Duration
+
+	// Which rate limit was exceeded on a 429 response e.g. minute, day, appminute or concurrent
+	RateLimitProblem string
+
+	// The raw response body
+	Body []byte
+}
+
+//ValidationError is a single validation failure reported by Xero
+type ValidationError struct {
+	// The index of the element in the request that failed validation e.g. 1 for the second invoice
+	Element int
+
+	// A description of the failure e.g. Email address must be valid.
+	Message string
+}
+
+func (e *APIError) Error() string {
+	message := fmt.Sprintf("%d error from Xero", e.StatusCode)
+	if e.Type != "" {
+		message = message + " (" + e.Type + ")"
+	}
+	if e.Message != "" {
+		message = message + ": " + e.Message
+	}
+	for _, validationError := range e.ValidationErrors {
+		message = message + fmt.Sprintf("\n  element %d: %s", validationError.Element, validationError.Message)
+	}
+	return message
+}
+
+//IsValidation reports whether the request was rejected because the data sent was invalid
+func (e *APIError) IsValidation() bool {
+	return e.StatusCode == http.StatusBadRequest
+}
+
+//IsUnauthorized reports whether the access token was missing, invalid or expired
+func (e *APIError) IsUnauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+//IsForbidden reports whether the token is not allowed to access the resource or tenant
+func (e *APIError) IsForbidden() bool {
+	return e.StatusCode == http.StatusForbidden
+}
+
+//IsNotFound reports whether the requested resource does not exist
+func (e *APIError) IsNotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+//IsRateLimited reports whether a Xero API rate limit was exceeded
+func (e *APIError) IsRateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
+//IsServerError reports whether Xero failed to process the request e.g. the organisation is offline
+func (e *APIError) IsServerError() bool {
+	return e.StatusCode >= http.StatusInternalServerError
+}
+
+//AsAPIError returns the *APIError within err, if there is one
+func AsAPIError(err error) (*APIError, bool) {
+	var apiError *APIError
+	ok := errors.As(err, &apiError)
+	return apiError, ok
+}
+
+//newAPIError builds an APIError from an unsuccessful response, reading the whole body
+func newAPIError(response *http.Response) *APIError {
+	body, _ := ioutil.ReadAll(response.Body)
+	apiError := &APIError{
+		StatusCode: response.StatusCode,
+		Body:       body,
+	}
+	if response.StatusCode == http.StatusTooManyRequests {
+		apiError.RetryAfter = retryAfter(response.Header)
+		apiError.RateLimitProblem = response.Header.Get("X-Rate-Limit-Problem")
+	}
+
+	trimmedBody := bytes.TrimSpace(body)
+	switch {
+	case len(trimmedBody) == 0:
+		apiError.Message = http.StatusText(response.StatusCode)
+	case trimmedBody[0] == '{':
+		apiError.parseJSON(trimmedBody)
+	case trimmedBody[0] == '<':
+		apiError.parseXML(trimmedBody)
+	case bytes.HasPrefix(trimmedBody, []byte("oauth_problem=")):
+		apiError.parseOAuthProblem(trimmedBody)
+	default:
+		apiError.Message = string(trimmedBody)
+	}
+
+	return apiError
+}
+
+//jsonAPIError covers the accounting API exceptions, the identity errors returned for OAuth 2.0
+//and the problems returned by the UK and NZ payroll APIs
+type jsonAPIError struct {
+	ErrorNumber int                      `json:"ErrorNumber"`
+	Type        string                   `json:"Type"`
+	Message     string                   `json:"Message"`
+	Title       string                   `json:"Title"`
+	Detail      string                   `json:"Detail"`
+	Elements    []map[string]interface{} `json:"Elements"`
+	Problem     *jsonProblem             `json:"problem"`
+}
+
+//jsonProblem is how payroll.xro/2.0 describes a request it rejected
+type jsonProblem struct {
+	Title         string `json:"title"`
+	Detail        string `json:"detail"`
+	InvalidFields []struct {
+		Name   string `json:"name"`
+		Reason string `json:"reason"`
+	} `json:"invalidFields"`
+}
+
+func (e *APIError) parseJSON(body []byte) {
+	var jsonError jsonAPIError
+	err := json.Unmarshal(body, &jsonError)
+	if err != nil {
+		e.Message = string(body)
+		return
+	}
+
+	e.ErrorNumber = jsonError.ErrorNumber
+	e.Type = jsonError.Type
+	e.Message = jsonError.Message
+	if e.Type == "" {
+		e.Type = jsonError.Title
+	}
+	if e.Message == "" {
+		e.Message = jsonError.Detail
+	}
+
+	for index, element := range jsonError.Elements {
+		e.ValidationErrors = append(e.ValidationErrors, jsonValidationErrors(index, element)...)
+	}
+
+	if jsonError.Problem != nil {
+		if e.Type == "" {
+			e.Type = jsonError.Problem.Title
+		}
+		if e.Message == "" {
+			e.Message = jsonError.Problem.Detail
+		}
+		//payroll.xro/2.0 takes one element at a time so every invalid field belongs to the first
+		for _, invalidField := range jsonError.Problem.InvalidFields {
+			e.ValidationErrors = append(e.ValidationErrors, ValidationError{Element: 0, Message: invalidField.Name + ": " + invalidField.Reason})
+		}
+	}
+}
+
+//jsonValidationErrors collects the ValidationErrors of an element, including those on nested values like LineItems
+func jsonValidationErrors(index int, value interface{}) []ValidationError {
+	var validationErrors []ValidationError
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			child := v[key]
+			if key != "ValidationErrors" {
+				validationErrors = append(validationErrors, jsonValidationErrors(index, child)...)
+				continue
+			}
+			messages, _ := child.([]interface{})
+			for _, message := range messages {
+				fields, _ := message.(map[string]interface{})
+				text, _ := fields["Message"].(string)
+				validationErrors = append(validationErrors, ValidationError{Element: index, Message: text})
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			validationErrors = append(validationErrors, jsonValidationErrors(index, child)...)
+		}
+	}
+	return validationErrors
+}
+
+//parseXML walks an ApiException document. Each child of Elements is one element of the request
+//and every ValidationError/Message beneath it is reported against that element
+func (e *APIError) parseXML(body []byte) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	var path []string
+	element := -1
+	var text strings.Builder
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			path = append(path, t.Name.Local)
+			if len(path) == 3 && path[1] == "Elements" {
+				element++
+			}
+			text.Reset()
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			value := strings.TrimSpace(text.String())
+			switch {
+			case len(path) == 2 && path[1] == "ErrorNumber":
+				fmt.Sscanf(value, "%d", &e.ErrorNumber)
+			case len(path) == 2 && path[1] == "Type":
+				e.Type = value
+			case len(path) == 2 && path[1] == "Message":
+				e.Message = value
+			case len(path) > 3 && path[len(path)-2] == "ValidationError" && t.Name.Local == "Message":
+				e.ValidationErrors = append(e.ValidationErrors, ValidationError{Element: element, Message: value})
+			}
+			path = path[:len(path)-1]
+			text.Reset()
+		}
+	}
+
+	if e.Type == "" && e.Message == "" {
+		e.Message = string(body)
+	}
+}
+
+//parseOAuthProblem reads the form encoded errors returned for OAuth 1.0a e.g. oauth_problem=token_expired
+func (e *APIError) parseOAuthProblem(body []byte) {
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		e.Message = string(body)
+		return
+	}
+	e.Type = values.Get("oauth_problem")
+	e.Message = values.Get("oauth_problem_advice")
+}
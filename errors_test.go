@@ -0,0 +1,157 @@
+package xerogolang
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mrjones/oauth"
+	"github.com/stretchr/testify/assert"
+)
+
+func errorResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func Test_APIError_JSON(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	apiError := newAPIError(errorResponse(400, `{
+		"ErrorNumber": 10,
+		"Type": "ValidationException",
+		"Message": "A validation exception occurred",
+		"Elements": [
+			{"InvoiceID": "00000000-0000-0000-0000-000000000000", "ValidationErrors": []},
+			{
+				"LineItems": [{"ValidationErrors": [{"Message": "Description needs to be at least 1 char long"}]}],
+				"ValidationErrors": [{"Message": "Email address must be valid."}]
+			}
+		]
+	}`))
+
+	a.Equal(400, apiError.StatusCode)
+	a.Equal(10, apiError.ErrorNumber)
+	a.Equal("ValidationException", apiError.Type)
+	a.Equal("A validation exception occurred", apiError.Message)
+	a.True(apiError.IsValidation())
+	a.Equal([]ValidationError{
+		{Element: 1, Message: "Description needs to be at least 1 char long"},
+		{Element: 1, Message: "Email address must be valid."},
+	}, apiError.ValidationErrors)
+	a.Contains(apiError.Error(), "element 1: Email address must be valid.")
+}
+
+func Test_APIError_PayrollProblem(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	apiError := newAPIError(errorResponse(400, `{
+		"id": "7a1d2b1c-2d7b-4b3f-9c1e-000000000000",
+		"httpStatusCode": "BadRequest",
+		"pagination": null,
+		"problem": {
+			"type": "application/problem+json",
+			"title": "BadRequest",
+			"status": 400,
+			"detail": "Validation error occurred.",
+			"invalidFields": [{"name": "FirstName", "reason": "The First Name is required."}]
+		}
+	}`))
+
+	a.Equal("BadRequest", apiError.Type)
+	a.Equal("Validation error occurred.", apiError.Message)
+	a.True(apiError.IsValidation())
+	a.Equal([]ValidationError{{Element: 0, Message: "FirstName: The First Name is required."}}, apiError.ValidationErrors)
+}
+
+func Test_APIError_XML(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	apiError := newAPIError(errorResponse(400, `<ApiException xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+  <ErrorNumber>10</ErrorNumber>
+  <Type>ValidationException</Type>
+  <Message>A validation exception occurred</Message>
+  <Elements>
+    <DataContractBase xsi:type="Invoice">
+      <ValidationErrors>
+        <ValidationError>
+          <Message>Invoice not of valid status for modification</Message>
+        </ValidationError>
+      </ValidationErrors>
+      <Type>ACCREC</Type>
+    </DataContractBase>
+    <DataContractBase xsi:type="Invoice">
+      <ValidationErrors>
+        <ValidationError>
+          <Message>Account code '999' is not a valid code for this document.</Message>
+        </ValidationError>
+      </ValidationErrors>
+    </DataContractBase>
+  </Elements>
+</ApiException>`))
+
+	a.Equal(10, apiError.ErrorNumber)
+	a.Equal("ValidationException", apiError.Type)
+	a.Equal("A validation exception occurred", apiError.Message)
+	a.Equal([]ValidationError{
+		{Element: 0, Message: "Invoice not of valid status for modification"},
+		{Element: 1, Message: "Account code '999' is not a valid code for this document."},
+	}, apiError.ValidationErrors)
+}
+
+func Test_APIError_OtherBodies(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	apiError := newAPIError(errorResponse(401, "oauth_problem=token_expired&oauth_problem_advice=The%20access%20token%20has%20expired"))
+	a.True(apiError.IsUnauthorized())
+	a.Equal("token_expired", apiError.Type)
+	a.Equal("The access token has expired", apiError.Message)
+
+	apiError = newAPIError(errorResponse(403, `{"Type":null,"Title":"Forbidden","Status":403,"Detail":"AuthenticationUnsuccessful"}`))
+	a.True(apiError.IsForbidden())
+	a.Equal("Forbidden", apiError.Type)
+	a.Equal("AuthenticationUnsuccessful", apiError.Message)
+
+	apiError = newAPIError(errorResponse(404, "The resource you're looking for cannot be found"))
+	a.True(apiError.IsNotFound())
+	a.Equal("The resource you're looking for cannot be found", apiError.Message)
+
+	apiError = newAPIError(errorResponse(503, ""))
+	a.True(apiError.IsServerError())
+	a.Equal("Service Unavailable", apiError.Message)
+
+	a.True(newAPIError(errorResponse(429, "")).IsRateLimited())
+}
+
+func Test_Create_ReturnsAPIError(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := xeroProvider()
+		provider.Endpoints = mockEndpoints(ts)
+		session := Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
+
+		_, err := provider.Create(&session, "Invoices", map[string]string{"Accept": "application/json"}, []byte("<Invoices />"))
+		a.Error(err)
+
+		var apiError *APIError
+		a.True(errors.As(fmt.Errorf("wrapped: %w", err), &apiError))
+		a.True(apiError.IsValidation())
+		a.Equal([]ValidationError{{Element: 0, Message: "Email address must be valid."}}, apiError.ValidationErrors)
+
+		apiError, ok := AsAPIError(err)
+		a.True(ok)
+		a.Equal(400, apiError.StatusCode)
+	})
+}
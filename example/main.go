@@ -1264,10 +1264,10 @@ func updateHandler(res http.ResponseWriter, req *http.Request) {
 			fmt.Fprintln(res, "Could not update Invoice")
 			return
 		}
-		if invoices.Invoices[0].Status == "DRAFT" {
-			invoices.Invoices[0].Status = "SUBMITTED"
-		} else if invoices.Invoices[0].Status == "SUBMITTED" {
-			invoices.Invoices[0].Status = "DRAFT"
+		if invoices.Invoices[0].Status == accounting.InvoiceStatusDraft {
+			invoices.Invoices[0].Status = accounting.InvoiceStatusSubmitted
+		} else if invoices.Invoices[0].Status == accounting.InvoiceStatusSubmitted {
+			invoices.Invoices[0].Status = accounting.InvoiceStatusDraft
 		}
 
 		invoiceCollection, err := invoices.Update(provider, session)
@@ -23,7 +23,7 @@ func ReaderToString(reader io.ReadCloser) string {
 		return ""
 	}
 	newString := buf.String()
-	_, err = fmt.Printf(newString)
+	_, err = fmt.Print(newString)
 	if err != nil {
 		return ""
 	}
@@ -0,0 +1,170 @@
+package xerogolang
+
+import (
+	"context"
+	cryptorand "crypto/rand"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+const (
+	//requestIDHeader is the header RequestIDMiddleware sets when it is not given one
+	requestIDHeader = "X-Request-Id"
+	//correlationIDHeader is the header Xero uses to identify a response when asked about it by support
+	correlationIDHeader = "Xero-Correlation-Id"
+)
+
+//Middleware wraps the transport that sends requests to Xero. The request it is given has already
+//been signed so it can be logged, traced or have headers added. It must not modify the request
+//it is given - clone it first.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+//RoundTripperFunc lets an ordinary function be used as an http.RoundTripper
+type RoundTripperFunc func(request *http.Request) (*http.Response, error)
+
+//RoundTrip calls f(request)
+func (f RoundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+//Use adds middleware around every request the provider sends, including token requests.
+//The first middleware added sees the request first. Add all middleware before the provider is
+//used to make requests as Use is not safe to call alongside them
+func (p *Provider) Use(middleware ...Middleware) {
+	p.middleware = append(p.middleware, middleware...)
+}
+
+//transport wraps send in the provider's middleware
+func (p *Provider) transport(send RoundTripperFunc) http.RoundTripper {
+	var transport http.RoundTripper = send
+	for n := len(p.middleware) - 1; n >= 0; n-- {
+		transport = p.middleware[n](transport)
+	}
+	return transport
+}
+
+//middlewareClient sends the requests signed by the OAuth 1.0a consumer through the provider's middleware
+type middlewareClient struct {
+	provider *Provider
+	client   interface {
+		Do(request *http.Request) (*http.Response, error)
+	}
+}
+
+func (c *middlewareClient) Do(request *http.Request) (*http.Response, error) {
+	return c.provider.transport(c.client.Do).RoundTrip(request)
+}
+
+//LoggingMiddleware logs the method, URL, status and duration of every request. The Authorization
+//header is never logged. If logger is nil the standard logger is used
+func LoggingMiddleware(logger *log.Logger) Middleware {
+	logf := log.Printf
+	if logger != nil {
+		logf = logger.Printf
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			started := time.Now()
+			response, err := next.RoundTrip(request)
+			duration := time.Since(started)
+			if err != nil {
+				logf("xero: %s %s failed after %s: %s", request.Method, request.URL.Redacted(), duration, err)
+				return response, err
+			}
+			logf("xero: %s %s %d in %s (correlation id %s)", request.Method, request.URL.Redacted(), response.StatusCode, duration, response.Header.Get(correlationIDHeader))
+			return response, err
+		})
+	}
+}
+
+//RequestMetrics describes a request that has been sent to Xero
+type RequestMetrics struct {
+	// The method and path of the request e.g. GET /api.xro/2.0/Invoices
+	Method string
+	Path   string
+
+	// The tenant the request was sent to, if any
+	TenantID string
+
+	// The status code of the response, or 0 if no response was received
+	StatusCode int
+
+	// How long it took to receive the response headers
+	Duration time.Duration
+
+	// The error that occurred sending the request, if there was one
+	Err error
+
+	// The Xero-Correlation-Id of the response
+	CorrelationID string
+}
+
+//MetricsMiddleware calls observe with the outcome and latency of every request so they can be
+//recorded by a metrics library
+func MetricsMiddleware(observe func(metrics RequestMetrics)) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			started := time.Now()
+			response, err := next.RoundTrip(request)
+			metrics := RequestMetrics{
+				Method:   request.Method,
+				Path:     request.URL.Path,
+				TenantID: request.Header.Get(tenantHeader),
+				Duration: time.Since(started),
+				Err:      err,
+			}
+			if response != nil {
+				metrics.StatusCode = response.StatusCode
+				metrics.CorrelationID = response.Header.Get(correlationIDHeader)
+			}
+			observe(metrics)
+			return response, err
+		})
+	}
+}
+
+type requestIDKey struct{}
+
+//WithRequestID returns a context carrying a request ID for RequestIDMiddleware to send
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+//RequestIDFromContext returns the request ID carried by the context, if there is one
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(requestIDKey{}).(string)
+	return requestID, ok && requestID != ""
+}
+
+//RequestIDMiddleware sends the request ID carried by the request's context in the given header,
+//or a new random ID when the context does not carry one. X-Request-Id is used if header is empty
+func RequestIDMiddleware(header string) Middleware {
+	if header == "" {
+		header = requestIDHeader
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			if request.Header.Get(header) != "" {
+				return next.RoundTrip(request)
+			}
+			requestID, ok := RequestIDFromContext(request.Context())
+			if !ok {
+				requestID = newRandomID()
+			}
+			request = request.Clone(request.Context())
+			request.Header.Set(header, requestID)
+			return next.RoundTrip(request)
+		})
+	}
+}
+
+//newRandomID generates a random version 4 UUID
+func newRandomID() string {
+	b := make([]byte, 16)
+	cryptorand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,123 @@
+package xerogolang
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/mrjones/oauth"
+	"github.com/stretchr/testify/assert"
+)
+
+//recordingMiddleware adds its name to calls and sets a header so the order middleware runs in can be checked
+func recordingMiddleware(name string, calls *[]string, mutex *sync.Mutex) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			mutex.Lock()
+			*calls = append(*calls, name+":"+request.Header.Get("Authorization")[:6])
+			mutex.Unlock()
+
+			request = request.Clone(request.Context())
+			request.Header.Add("X-Middleware", name)
+			return next.RoundTrip(request)
+		})
+	}
+}
+
+func Test_Use_OAuth2(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := NewOAuth2("CLIENT", "SECRET", "/foo")
+		provider.Endpoints = mockEndpoints(ts)
+		var calls []string
+		mutex := sync.Mutex{}
+		provider.Use(recordingMiddleware("first", &calls, &mutex), recordingMiddleware("second", &calls, &mutex))
+
+		headers := echoHeaders(a, provider, testOAuth2Session(), nil)
+		a.Equal([]string{"first", "second"}, headers["X-Middleware"])
+		//the middleware sees the signed request
+		a.Equal([]string{"first:Bearer", "second:Bearer"}, calls)
+	})
+}
+
+func Test_Use_OAuth1(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := xeroProvider()
+		provider.Endpoints = mockEndpoints(ts)
+		var calls []string
+		mutex := sync.Mutex{}
+		provider.Use(recordingMiddleware("only", &calls, &mutex))
+
+		session := &Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
+		headers := echoHeaders(a, provider, session, nil)
+		a.Equal([]string{"only"}, headers["X-Middleware"])
+		a.Equal([]string{"only:OAuth "}, calls)
+	})
+}
+
+func Test_RequestIDMiddleware(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := NewOAuth2("CLIENT", "SECRET", "/foo")
+		provider.Endpoints = mockEndpoints(ts)
+		provider.Use(RequestIDMiddleware(""))
+
+		response, err := provider.FindCtx(WithRequestID(context.Background(), "request-1"), testOAuth2Session(), "Echo", nil, nil)
+		a.NoError(err)
+		a.Contains(string(response), `"X-Request-Id":["request-1"]`)
+
+		headers := echoHeaders(a, provider, testOAuth2Session(), nil)
+		a.Len(headers.Get("X-Request-Id"), 36)
+	})
+}
+
+func Test_MetricsMiddleware(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := NewOAuth2("CLIENT", "SECRET", "/foo")
+		provider.Endpoints = mockEndpoints(ts)
+		var observed []RequestMetrics
+		provider.Use(MetricsMiddleware(func(metrics RequestMetrics) {
+			observed = append(observed, metrics)
+		}))
+
+		_, err := provider.Create(testOAuth2Session().WithTenant("t-1"), "Invoices", nil, []byte("{}"))
+		a.Error(err)
+
+		a.Len(observed, 1)
+		a.Equal("PUT", observed[0].Method)
+		a.Equal("/api.xro/2.0/Invoices", observed[0].Path)
+		a.Equal("t-1", observed[0].TenantID)
+		a.Equal(http.StatusBadRequest, observed[0].StatusCode)
+		a.NoError(observed[0].Err)
+	})
+}
+
+func Test_LoggingMiddleware(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := NewOAuth2("CLIENT", "SECRET", "/foo")
+		provider.Endpoints = mockEndpoints(ts)
+		var buffer bytes.Buffer
+		provider.Use(LoggingMiddleware(log.New(&buffer, "", 0)))
+
+		echoHeaders(a, provider, testOAuth2Session(), nil)
+		a.Contains(buffer.String(), "xero: GET "+ts.URL+"/api.xro/2.0/Echo 200 in ")
+		a.NotContains(buffer.String(), "ACCESSTOKEN")
+	})
+}
@@ -0,0 +1,163 @@
+package xerogolang
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+var (
+	oauth2AuthorizeURL = "https://login.xero.com/identity/connect/authorize"
+	oauth2TokenURL     = "https://identity.xero.com/connect/token"
+	//defaultScopes are requested when an OAuth 2.0 Provider is not given any scopes.
+	//offline_access is required for Xero to issue a refresh token
+	defaultScopes = []string{
+		"openid",
+		"profile",
+		"email",
+		"accounting.transactions",
+		"accounting.settings",
+		"accounting.contacts",
+		"offline_access",
+	}
+)
+
+const (
+	//oauth2Method is the Provider.Method used for the OAuth 2.0 authorization code flow
+	oauth2Method = "oauth2"
+	//tokenRefreshWindow is how close to expiry an OAuth 2.0 access token can get
+	//before it is refreshed ahead of a request
+	tokenRefreshWindow = time.Minute
+)
+
+// NewOAuth2 creates a new Xero provider that uses the OAuth 2.0 authorization code flow with PKCE.
+// If no scopes are supplied the provider will request openid, profile, email, the accounting
+// scopes and offline_access.
+func NewOAuth2(clientID, secret, callbackURL string, scopes ...string) *Provider {
+	p := &Provider{
+		ClientKey:       clientID,
+		Secret:          secret,
+		CallbackURL:     callbackURL,
+		Method:          oauth2Method,
+		Scopes:          scopes,
+		UserAgentString: userAgentString,
+		RateLimiter:     NewRateLimiter(),
+		RetryPolicy:     DefaultRetryPolicy(),
+		Endpoints:       DefaultEndpoints(),
+		providerName:    "xero",
+	}
+	return p
+}
+
+//oauth2Config builds the oauth2.Config for the provider from its credentials and scopes
+func (p *Provider) oauth2Config() *oauth2.Config {
+	scopes := p.Scopes
+	if len(scopes) == 0 {
+		scopes = defaultScopes
+	}
+	return &oauth2.Config{
+		ClientID:     p.ClientKey,
+		ClientSecret: p.Secret,
+		RedirectURL:  p.CallbackURL,
+		Scopes:       scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  p.endpoints().OAuth2Authorize,
+			TokenURL: p.endpoints().OAuth2Token,
+		},
+	}
+}
+
+//oauth2Context makes sure token requests go through the provider's middleware and HTTPClient when they are set
+func (p *Provider) oauth2Context(ctx context.Context) context.Context {
+	if len(p.middleware) > 0 {
+		return context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: p.transport(p.Client().Do)})
+	}
+	if p.HTTPClient != nil {
+		return context.WithValue(ctx, oauth2.HTTPClient, p.HTTPClient)
+	}
+	return ctx
+}
+
+//beginOAuth2 generates a PKCE verifier and the URL the user must visit to authorize the app
+func (p *Provider) beginOAuth2(state string) *Session {
+	verifier := oauth2.GenerateVerifier()
+	return &Session{
+		AuthURL:      p.oauth2Config().AuthCodeURL(state, oauth2.S256ChallengeOption(verifier)),
+		CodeVerifier: verifier,
+	}
+}
+
+//exchangeOAuth2Code swaps the authorization code returned to the callback URL for a token
+func (p *Provider) exchangeOAuth2Code(session *Session, code string) error {
+	if code == "" {
+		return errors.New("Missing authorization code")
+	}
+	token, err := p.oauth2Config().Exchange(p.oauth2Context(context.Background()), code, oauth2.VerifierOption(session.CodeVerifier))
+	if err != nil {
+		return err
+	}
+	session.OAuth2Token = token
+	session.AccessTokenExpires = token.Expiry
+	session.CodeVerifier = ""
+	return nil
+}
+
+//RefreshOAuth2Token exchanges the refresh token held by the session for a new access token.
+//Xero rotates refresh tokens so the session's token is updated in place - make sure you
+//store the session again afterwards or the next refresh will fail
+func (p *Provider) RefreshOAuth2Token(session *Session) error {
+	p.tokenMutex.Lock()
+	defer p.tokenMutex.Unlock()
+
+	return p.refreshOAuth2Token(context.Background(), session)
+}
+
+//oauth2Token returns a copy of the session's access token to sign a request with, refreshing it first
+//when it is about to expire. Copies of a session made by WithTenant share their token so that a refresh
+//is seen by all of them, which is why it is only read and replaced while holding the token lock. The
+//expiry is checked again once the write lock is held so concurrent requests only refresh once
+func (p *Provider) oauth2Token(ctx context.Context, session *Session) (oauth2.Token, error) {
+	p.tokenMutex.RLock()
+	token := *session.OAuth2Token
+	p.tokenMutex.RUnlock()
+	if !oauth2TokenExpiring(&token) {
+		return token, nil
+	}
+
+	p.tokenMutex.Lock()
+	defer p.tokenMutex.Unlock()
+
+	if oauth2TokenExpiring(session.OAuth2Token) {
+		err := p.refreshOAuth2Token(ctx, session)
+		if err != nil {
+			return oauth2.Token{}, err
+		}
+	}
+	return *session.OAuth2Token, nil
+}
+
+func (p *Provider) refreshOAuth2Token(ctx context.Context, session *Session) error {
+	if session.OAuth2Token == nil || session.OAuth2Token.RefreshToken == "" {
+		return fmt.Errorf("Could not refresh token as no refresh token was found - make sure the offline_access scope was requested")
+	}
+	token, err := p.refreshToken(ctx, session.OAuth2Token.RefreshToken)
+	if err != nil {
+		return err
+	}
+	//the token is replaced in place so any copies of the session that share it see the rotated refresh token
+	*session.OAuth2Token = *token
+	session.AccessTokenExpires = token.Expiry
+	return nil
+}
+
+//oauth2TokenExpiring reports whether a token expires within the refresh window
+func oauth2TokenExpiring(token *oauth2.Token) bool {
+	if token.Expiry.IsZero() {
+		return false
+	}
+	return token.Expiry.Before(time.Now().Add(tokenRefreshWindow))
+}
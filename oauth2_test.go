@@ -0,0 +1,164 @@
+package xerogolang
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+func Test_BeginAuth_OAuth2(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := NewOAuth2("CLIENT", "SECRET", "/foo")
+		provider.Endpoints = mockEndpoints(ts)
+		session, err := provider.BeginAuth("state")
+		a.NoError(err)
+
+		s := session.(*Session)
+		authURL, err := url.Parse(s.AuthURL)
+		a.NoError(err)
+		a.Contains(s.AuthURL, ts.URL+"/identity/connect/authorize")
+		a.Equal("code", authURL.Query().Get("response_type"))
+		a.Equal("CLIENT", authURL.Query().Get("client_id"))
+		a.Equal("state", authURL.Query().Get("state"))
+		a.Equal("S256", authURL.Query().Get("code_challenge_method"))
+		a.Contains(authURL.Query().Get("scope"), "offline_access")
+		a.NotEmpty(authURL.Query().Get("code_challenge"))
+		a.NotEmpty(s.CodeVerifier)
+	})
+}
+
+func Test_Authorize_OAuth2(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := NewOAuth2("CLIENT", "SECRET", "/foo")
+		provider.Endpoints = mockEndpoints(ts)
+		session, err := provider.BeginAuth("state")
+		a.NoError(err)
+
+		s := session.(*Session)
+		token, err := s.Authorize(provider, url.Values{"code": {"CODE"}})
+		a.NoError(err)
+		a.Equal("ACCESSTOKEN", token)
+		a.Equal("REFRESHTOKEN", s.OAuth2Token.RefreshToken)
+		a.Empty(s.CodeVerifier)
+		a.True(s.AccessTokenExpires.After(time.Now().Add(29 * time.Minute)))
+
+		_, err = s.Authorize(provider, url.Values{"code": {"WRONG"}})
+		a.Error(err)
+	})
+}
+
+func Test_RefreshOAuth2Token(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := NewOAuth2("CLIENT", "SECRET", "/foo")
+		provider.Endpoints = mockEndpoints(ts)
+		a.True(provider.RefreshTokenAvailable())
+
+		session := &Session{OAuth2Token: &oauth2.Token{AccessToken: "OLD", RefreshToken: "R1"}}
+		err := provider.RefreshOAuth2Token(session)
+		a.NoError(err)
+		a.Equal("REFRESHED-R1", session.OAuth2Token.AccessToken)
+		a.Equal("ROTATED-R1", session.OAuth2Token.RefreshToken)
+		a.Equal(session.OAuth2Token.Expiry, session.AccessTokenExpires)
+
+		err = provider.RefreshOAuth2Token(&Session{OAuth2Token: &oauth2.Token{AccessToken: "OLD"}})
+		a.Error(err)
+	})
+}
+
+func Test_Find_RefreshesExpiringOAuth2Token(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := NewOAuth2("CLIENT", "SECRET", "/foo")
+		provider.Endpoints = mockEndpoints(ts)
+		token := &oauth2.Token{
+			AccessToken:  "OLD",
+			RefreshToken: "R1",
+			TokenType:    "Bearer",
+			Expiry:       time.Now().Add(10 * time.Second),
+		}
+		session := &Session{OAuth2Token: token}
+
+		response, err := provider.Find(session, "TrackingCategories", map[string]string{"Accept": "application/json"}, nil)
+		a.NoError(err)
+
+		var testResponse *Tests
+		a.NoError(json.Unmarshal(response, &testResponse))
+		a.Equal("Store", testResponse.Tests[0].Name)
+
+		a.Equal("REFRESHED-R1", token.AccessToken)
+		a.Equal("ROTATED-R1", token.RefreshToken)
+		a.True(session.AccessTokenExpires.After(time.Now().Add(29 * time.Minute)))
+	})
+}
+
+func Test_Find_RefreshesSharedOAuth2TokenOnce(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := NewOAuth2("CLIENT", "SECRET", "/foo")
+		provider.Endpoints = mockEndpoints(ts)
+		session := &Session{OAuth2Token: &oauth2.Token{
+			AccessToken:  "OLD",
+			RefreshToken: "R1",
+			TokenType:    "Bearer",
+			Expiry:       time.Now().Add(10 * time.Second),
+		}}
+		tenants := []*Session{session.WithTenant("t-1"), session.WithTenant("t-2")}
+
+		//run with -race: both tenants share the token that one of their requests refreshes
+		var wg sync.WaitGroup
+		var mutex sync.Mutex
+		authorizations := make([][]string, len(tenants))
+		for i, tenant := range tenants {
+			for j := 0; j < 5; j++ {
+				wg.Add(1)
+				go func(i int, tenant *Session) {
+					defer wg.Done()
+					headers := echoHeaders(a, provider, tenant, nil)
+					mutex.Lock()
+					authorizations[i] = append(authorizations[i], headers.Get("Authorization"))
+					mutex.Unlock()
+				}(i, tenant)
+			}
+		}
+		wg.Wait()
+
+		//a second refresh would have used the rotated refresh token
+		for i := range tenants {
+			a.Len(authorizations[i], 5)
+			for _, authorization := range authorizations[i] {
+				a.Equal("Bearer REFRESHED-R1", authorization)
+			}
+		}
+		a.Equal("ROTATED-R1", session.OAuth2Token.RefreshToken)
+		a.Same(session.OAuth2Token, tenants[1].OAuth2Token)
+	})
+}
+
+func Test_RefreshToken_OAuth1(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	provider := xeroProvider()
+	a.False(provider.RefreshTokenAvailable())
+	_, err := provider.RefreshToken("R1")
+	a.Error(err)
+}
@@ -0,0 +1,58 @@
+package xerogolang
+
+import (
+	"context"
+	"errors"
+	"strconv"
+)
+
+//ErrStop can be returned by the function passed to an Each function, such as
+//accounting.EachInvoice, to stop fetching pages early. The Each function then returns nil
+var ErrStop = errors.New("stop paging")
+
+//EachPage calls fetchPage with the page parameter set to each page in turn, starting from the page in
+//querystringParameters or page 1, until a page comes back empty. fetchPage returns the number of
+//records in the page. The other querystringParameters, such as where and order, are passed on each time
+func EachPage(ctx context.Context, querystringParameters map[string]string, fetchPage func(querystringParameters map[string]string) (int, error)) error {
+	page := 1
+	if requestedPage, err := strconv.Atoi(querystringParameters["page"]); err == nil && requestedPage > 0 {
+		page = requestedPage
+	}
+
+	for ; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		pageParameters := map[string]string{}
+		for key, value := range querystringParameters {
+			pageParameters[key] = value
+		}
+		pageParameters["page"] = strconv.Itoa(page)
+
+		count, err := fetchPage(pageParameters)
+		if errors.Is(err, ErrStop) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return nil
+		}
+	}
+}
+
+//Pagination is the paging summary the UK and NZ payroll APIs return alongside each page of results
+type Pagination struct {
+	Page      int `json:"page"`
+	PageSize  int `json:"pageSize"`
+	PageCount int `json:"pageCount"`
+	ItemCount int `json:"itemCount"`
+}
+
+//Last reports whether this is the final page, so there is no need to ask for the next one.
+//Without a Pagination it can't tell, so paging carries on until a page comes back empty
+func (p *Pagination) Last() bool {
+	return p != nil && p.Page >= p.PageCount
+}
@@ -0,0 +1,91 @@
+package xerogolang
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EachPage(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var pages []map[string]string
+	querystringParameters := map[string]string{"where": `Status=="AUTHORISED"`, "order": "Date"}
+	err := EachPage(context.Background(), querystringParameters, func(pageParameters map[string]string) (int, error) {
+		pages = append(pages, pageParameters)
+		if len(pages) == 3 {
+			return 0, nil
+		}
+		return 100, nil
+	})
+	a.NoError(err)
+	a.Len(pages, 3)
+	for n, page := range pages {
+		a.Equal([]string{"1", "2", "3"}[n], page["page"])
+		a.Equal(`Status=="AUTHORISED"`, page["where"])
+		a.Equal("Date", page["order"])
+	}
+	//the caller's parameters are left alone
+	a.NotContains(querystringParameters, "page")
+}
+
+func Test_EachPage_StartsFromRequestedPage(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var pages []string
+	err := EachPage(context.Background(), map[string]string{"page": "4"}, func(pageParameters map[string]string) (int, error) {
+		pages = append(pages, pageParameters["page"])
+		return len(pages) % 2, nil
+	})
+	a.NoError(err)
+	a.Equal([]string{"4", "5"}, pages)
+}
+
+func Test_EachPage_Stop(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	calls := 0
+	err := EachPage(context.Background(), nil, func(pageParameters map[string]string) (int, error) {
+		calls++
+		return 0, ErrStop
+	})
+	a.NoError(err)
+	a.Equal(1, calls)
+
+	failure := errors.New("could not unmarshal")
+	err = EachPage(context.Background(), nil, func(pageParameters map[string]string) (int, error) {
+		return 0, failure
+	})
+	a.Equal(failure, err)
+}
+
+func Test_EachPage_CancelledContext(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := EachPage(ctx, nil, func(pageParameters map[string]string) (int, error) {
+		calls++
+		cancel()
+		return 100, nil
+	})
+	a.ErrorIs(err, context.Canceled)
+	a.Equal(1, calls)
+}
+
+func Test_Pagination_Last(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	var missing *Pagination
+	a.False(missing.Last())
+	a.False((&Pagination{Page: 1, PageSize: 100, PageCount: 2, ItemCount: 150}).Last())
+	a.True((&Pagination{Page: 2, PageSize: 100, PageCount: 2, ItemCount: 150}).Last())
+	a.True((&Pagination{Page: 1, PageSize: 100, PageCount: 0, ItemCount: 0}).Last())
+}
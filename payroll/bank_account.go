@@ -0,0 +1,26 @@
+package payroll
+
+import "github.com/opensimsim/xerogolang"
+
+//BankAccount is an account an employee's net pay is paid into. An employee can have several, with a fixed
+//Amount paid into each and the Remainder going to the last
+type BankAccount struct {
+
+	// The text that will appear on your employee's bank statement when they receive payment
+	StatementText string `json:"StatementText,omitempty" xml:"StatementText,omitempty"`
+
+	// The name of the account
+	AccountName string `json:"AccountName,omitempty" xml:"AccountName,omitempty"`
+
+	// The BSB number of the account
+	BSB string `json:"BSB,omitempty" xml:"BSB,omitempty"`
+
+	// The account number
+	AccountNumber string `json:"AccountNumber,omitempty" xml:"AccountNumber,omitempty"`
+
+	// If this account is the Remaining bank account
+	Remainder bool `json:"Remainder,omitempty" xml:"Remainder,omitempty"`
+
+	// Fixed amount paid into the account. Not used for the Remainder account
+	Amount xerogolang.Decimal `json:"Amount,omitzero" xml:"Amount,omitempty"`
+}
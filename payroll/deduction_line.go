@@ -0,0 +1,22 @@
+package payroll
+
+import "github.com/opensimsim/xerogolang"
+
+//DeductionLine is an amount taken from an employee's pay, on a pay template or a payslip
+type DeductionLine struct {
+
+	// Xero deduction type identifier (see PayItems)
+	DeductionTypeID string `json:"DeductionTypeID,omitempty" xml:"DeductionTypeID,omitempty"`
+
+	// FIXEDAMOUNT, PRETAX or POSTTAX
+	CalculationType string `json:"CalculationType,omitempty" xml:"CalculationType,omitempty"`
+
+	// Percentage of gross earnings deducted when CalculationType is PRETAX or POSTTAX
+	Percentage xerogolang.Decimal `json:"Percentage,omitzero" xml:"Percentage,omitempty"`
+
+	// Deduction type amount when CalculationType is FIXEDAMOUNT
+	Amount xerogolang.Decimal `json:"Amount,omitzero" xml:"Amount,omitempty"`
+
+	// Deduction number of units
+	NumberOfUnits xerogolang.Decimal `json:"NumberOfUnits,omitzero" xml:"NumberOfUnits,omitempty"`
+}
@@ -0,0 +1,34 @@
+package payroll
+
+import "github.com/opensimsim/xerogolang"
+
+//DeductionType is a type of deduction that can be taken from an employee's pay, such as union fees or salary sacrifice
+type DeductionType struct {
+
+	// Xero identifier
+	DeductionTypeID string `json:"DeductionTypeID,omitempty" xml:"DeductionTypeID,omitempty"`
+
+	// Name of the deduction type (max length = 50)
+	Name string `json:"Name,omitempty" xml:"Name,omitempty"`
+
+	// See Accounts
+	AccountCode string `json:"AccountCode,omitempty" xml:"AccountCode,omitempty"`
+
+	// Indicates that this is a pre-tax deduction that will reduce the amount of tax you withhold from an employee
+	ReducesTax bool `json:"ReducesTax,omitempty" xml:"ReducesTax,omitempty"`
+
+	// Most deductions don’t reduce your superannuation guarantee contribution liability, so typically you will not set any value for this
+	ReducesSuper bool `json:"ReducesSuper,omitempty" xml:"ReducesSuper,omitempty"`
+
+	// Boolean to determine if the deduction type is reportable or exempt from W1
+	IsExemptFromW1 bool `json:"IsExemptFromW1,omitempty" xml:"IsExemptFromW1,omitempty"`
+
+	// NONE, UNIONFEES, WORKPLACEGIVING or one of the salary sacrifice categories
+	DeductionCategory string `json:"DeductionCategory,omitempty" xml:"DeductionCategory,omitempty"`
+
+	// Is the current record
+	CurrentRecord bool `json:"CurrentRecord,omitempty" xml:"CurrentRecord,omitempty"`
+
+	// Last modified timestamp
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"-"`
+}
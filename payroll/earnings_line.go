@@ -0,0 +1,34 @@
+package payroll
+
+import "github.com/opensimsim/xerogolang"
+
+//EarningsLine is an amount an employee earns at an earnings rate, on a pay template or a payslip
+type EarningsLine struct {
+
+	// Xero earnings rate identifier (see PayItems)
+	EarningsRateID string `json:"EarningsRateID,omitempty" xml:"EarningsRateID,omitempty"`
+
+	// USEEARNINGSRATE, ENTEREARNINGSRATE or ANNUALSALARY
+	CalculationType string `json:"CalculationType,omitempty" xml:"CalculationType,omitempty"`
+
+	// Annual salary for the earnings line when CalculationType is ANNUALSALARY
+	AnnualSalary xerogolang.Decimal `json:"AnnualSalary,omitzero" xml:"AnnualSalary,omitempty"`
+
+	// Number of units of the earnings rate worked per week when CalculationType is ANNUALSALARY
+	NumberOfUnitsPerWeek xerogolang.Decimal `json:"NumberOfUnitsPerWeek,omitzero" xml:"NumberOfUnitsPerWeek,omitempty"`
+
+	// Rate per unit of the earnings line
+	RatePerUnit xerogolang.Decimal `json:"RatePerUnit,omitzero" xml:"RatePerUnit,omitempty"`
+
+	// Normal number of units for the earnings line
+	NormalNumberOfUnits xerogolang.Decimal `json:"NormalNumberOfUnits,omitzero" xml:"NormalNumberOfUnits,omitempty"`
+
+	// Earnings line number of units
+	NumberOfUnits xerogolang.Decimal `json:"NumberOfUnits,omitzero" xml:"NumberOfUnits,omitempty"`
+
+	// Earnings line fixed amount, only for earnings rates with a RateType of FIXEDAMOUNT
+	FixedAmount xerogolang.Decimal `json:"FixedAmount,omitzero" xml:"FixedAmount,omitempty"`
+
+	// Earnings line amount, only for payslip lines
+	Amount xerogolang.Decimal `json:"Amount,omitzero" xml:"Amount,omitempty"`
+}
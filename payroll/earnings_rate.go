@@ -0,0 +1,58 @@
+package payroll
+
+import "github.com/opensimsim/xerogolang"
+
+//EarningsRate is a type of earnings an employee can be paid, such as ordinary hours, overtime or an allowance
+type EarningsRate struct {
+
+	// Xero identifier
+	EarningsRateID string `json:"EarningsRateID,omitempty" xml:"EarningsRateID,omitempty"`
+
+	// Name of the earnings rate (max length = 100)
+	Name string `json:"Name,omitempty" xml:"Name,omitempty"`
+
+	// See Accounts
+	AccountCode string `json:"AccountCode,omitempty" xml:"AccountCode,omitempty"`
+
+	// Type of units used to record earnings (max length = 50). Only when RateType is RATEPERUNIT
+	TypeOfUnits string `json:"TypeOfUnits,omitempty" xml:"TypeOfUnits,omitempty"`
+
+	// Most payments are subject to tax, so you should only set this value if you are sure that a payment is exempt from PAYG withholding
+	IsExemptFromTax bool `json:"IsExemptFromTax,omitempty" xml:"IsExemptFromTax,omitempty"`
+
+	// See the ATO website for details of which payments are exempt from SGC
+	IsExemptFromSuper bool `json:"IsExemptFromSuper,omitempty" xml:"IsExemptFromSuper,omitempty"`
+
+	// Boolean to determine if the earnings rate is reportable or exempt from W1
+	IsReportableAsW1 bool `json:"IsReportableAsW1,omitempty" xml:"IsReportableAsW1,omitempty"`
+
+	// ORDINARYTIMEEARNINGS, OVERTIMEEARNINGS, ALLOWANCE, LUMPSUMD or EMPLOYMENTTERMINATIONPAYMENT among others
+	EarningsType string `json:"EarningsType,omitempty" xml:"EarningsType,omitempty"`
+
+	// FIXEDAMOUNT, MULTIPLE or RATEPERUNIT
+	RateType string `json:"RateType,omitempty" xml:"RateType,omitempty"`
+
+	// Default rate per unit (optional). Only applicable if RateType is RATEPERUNIT
+	RatePerUnit xerogolang.Decimal `json:"RatePerUnit,omitzero" xml:"RatePerUnit,omitempty"`
+
+	// This is the multiplier used to calculate the rate per unit, based on the employee’s ordinary earnings rate. For example, for time and a half enter 1.5. Only applicable if RateType is MULTIPLE
+	Multiplier xerogolang.Decimal `json:"Multiplier,omitzero" xml:"Multiplier,omitempty"`
+
+	// Indicates that this earnings rate should accrue leave. Only applicable if RateType is MULTIPLE
+	AccrueLeave bool `json:"AccrueLeave,omitempty" xml:"AccrueLeave,omitempty"`
+
+	// Optional Amount for FIXEDAMOUNT RateType EarningsRate
+	Amount xerogolang.Decimal `json:"Amount,omitzero" xml:"Amount,omitempty"`
+
+	// O or R. Only when EarningsType is EMPLOYMENTTERMINATIONPAYMENT
+	EmploymentTerminationPaymentType string `json:"EmploymentTerminationPaymentType,omitempty" xml:"EmploymentTerminationPaymentType,omitempty"`
+
+	// CAR, TRANSPORT, TRAVEL, LAUNDRY, MEALS, JOBKEEPER or OTHER. Only when EarningsType is ALLOWANCE
+	AllowanceType string `json:"AllowanceType,omitempty" xml:"AllowanceType,omitempty"`
+
+	// Is the current record
+	CurrentRecord bool `json:"CurrentRecord,omitempty" xml:"CurrentRecord,omitempty"`
+
+	// Last modified timestamp
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"-"`
+}
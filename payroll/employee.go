@@ -1,58 +1,106 @@
 package payroll
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
-	"log"
 	"time"
 
 	"github.com/markbates/goth"
 	"github.com/opensimsim/xerogolang"
-	"github.com/opensimsim/xerogolang/helpers"
 )
 
-//Employee - placeholder waiting for .
+//Employee is a person paid through Xero Payroll, along with their tax, super, bank and pay details
 type Employee struct {
 
 	// The Xero identifier for an employee e.g. 297c2dc5-cc47-4afd-8ec8-74990b8761e9
-	EmployeeID string `json:"EmployeeID,omitempty"`
+	EmployeeID string `json:"EmployeeID,omitempty" xml:"EmployeeID,omitempty"`
+
+	// Title of the employee e.g. Mr
+	Title string `json:"Title,omitempty" xml:"Title,omitempty"`
+
+	// First name of an employee (max length = 35)
+	FirstName string `json:"FirstName,omitempty" xml:"FirstName,omitempty"`
+
+	// Middle name(s) of the employee (max length = 35)
+	MiddleNames string `json:"MiddleNames,omitempty" xml:"MiddleNames,omitempty"`
+
+	// Last name of an employee (max length = 35)
+	LastName string `json:"LastName,omitempty" xml:"LastName,omitempty"`
 
 	// Current status of an employee – see employee status types
-	Status string `json:"Status,omitempty"`
+	Status EmployeeStatus `json:"Status,omitempty" xml:"Status,omitempty"`
+
+	// The email address for the employee
+	Email string `json:"Email,omitempty" xml:"Email,omitempty"`
+
+	// Date of birth of the employee (YYYY-MM-DD)
+	DateOfBirth xerogolang.Date `json:"DateOfBirth,omitzero" xml:"DateOfBirth,omitempty"`
+
+	// The employee’s gender: N (Not stated), M (Male), F (Female) or I (Indeterminate)
+	Gender string `json:"Gender,omitempty" xml:"Gender,omitempty"`
+
+	// Employee phone number
+	Phone string `json:"Phone,omitempty" xml:"Phone,omitempty"`
+
+	// Employee mobile number
+	Mobile string `json:"Mobile,omitempty" xml:"Mobile,omitempty"`
+
+	// Employee’s twitter name
+	TwitterUserName string `json:"TwitterUserName,omitempty" xml:"TwitterUserName,omitempty"`
+
+	// Start date for an employee (YYYY-MM-DD)
+	StartDate xerogolang.Date `json:"StartDate,omitzero" xml:"StartDate,omitempty"`
+
+	// Employment termination date (YYYY-MM-DD)
+	TerminationDate xerogolang.Date `json:"TerminationDate,omitzero" xml:"TerminationDate,omitempty"`
+
+	// Xero unique identifier for the earnings rate an employee is paid for ordinary hours (see PayItems)
+	OrdinaryEarningsRateID string `json:"OrdinaryEarningsRateID,omitempty" xml:"OrdinaryEarningsRateID,omitempty"`
 
-	// First name of an employee (max length = 255)
-	FirstName string `json:"FirstName,omitempty"`
+	// Xero unique identifier for the payroll calendar of the employee
+	PayrollCalendarID string `json:"PayrollCalendarID,omitempty" xml:"PayrollCalendarID,omitempty"`
 
-	// Last name of an employee (max length = 255)
-	LastName string `json:"LastName,omitempty"`
+	// The employee group the employee belongs to, one of the options of the Settings employee groups tracking category
+	EmployeeGroupName string `json:"EmployeeGroupName,omitempty" xml:"EmployeeGroupName,omitempty"`
 
-	//
-	Email string `json:"Email,omitempty"`
+	// Authorised to approve other employees' leave requests
+	IsAuthorisedToApproveLeave bool `json:"IsAuthorisedToApproveLeave,omitempty" xml:"IsAuthorisedToApproveLeave,omitempty"`
 
-	Gender string `json:"Gender,omitempty"`
+	// Authorised to approve timesheets
+	IsAuthorisedToApproveTimesheets bool `json:"IsAuthorisedToApproveTimesheets,omitempty" xml:"IsAuthorisedToApproveTimesheets,omitempty"`
 
-	Phone string `json:"Gender,omitempty"`
+	// JobTitle of the employee
+	JobTitle string `json:"JobTitle,omitempty" xml:"JobTitle,omitempty"`
 
-	UpdatedDateUTC string `json:"UpdatedDateUTC,omitempty" xml:"-"`
+	// Employees under an award must be given a classification (max length = 100)
+	Classification string `json:"Classification,omitempty" xml:"Classification,omitempty"`
+
+	// See HomeAddress
+	HomeAddress *HomeAddress `json:"HomeAddress,omitempty" xml:"HomeAddress,omitempty"`
+
+	// The accounts the employee's net pay is paid into. See BankAccounts
+	BankAccounts *[]BankAccount `json:"BankAccounts,omitempty" xml:"BankAccounts>BankAccount,omitempty"`
+
+	// See TaxDeclaration
+	TaxDeclaration *TaxDeclaration `json:"TaxDeclaration,omitempty" xml:"TaxDeclaration,omitempty"`
+
+	// The super funds the employee is a member of. See SuperMemberships
+	SuperMemberships *[]SuperMembership `json:"SuperMemberships,omitempty" xml:"SuperMemberships>SuperMembership,omitempty"`
+
+	// The leave the employee has available (read only)
+	LeaveBalances *[]LeaveBalance `json:"LeaveBalances,omitempty" xml:"-"`
+
+	// See PayTemplate
+	PayTemplate *PayTemplate `json:"PayTemplate,omitempty" xml:"PayTemplate,omitempty"`
+
+	// Last modified timestamp
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"-"`
+
+	// Why the employee could not be saved
+	ValidationErrors []xerogolang.ValidationError `json:"ValidationErrors,omitempty" xml:"-"`
 }
 
-/*
-<Employee>
-    <EmployeeID>fb4ebd68-6568-41eb-96ab-628a0f54b4b8</EmployeeID>
-    <FirstName>James</FirstName>
-    <LastName>Lebron</LastName>
-    <Status>ACTIVE</Status>
-    <Email>JL@madeup.email.com</Email>
-    <DateOfBirth>1978-08-13T00:00:00</DateOfBirth>
-    <Gender>M</Gender>
-    <Phone>0400-000-123</Phone>
-    <Mobile> 408-230-9732</Mobile>
-    <StartDate>2012-01-30T00:00:00</StartDate>
-    <OrdinaryEarningsRateID>72e962d1-fcac-4083-8a71-742bb3e7ae14</OrdinaryEarningsRateID>
-    <PayrollCalendarID>cb8e4706-2fdc-4170-aebd-0ffb855557f5</PayrollCalendarID>
-    <UpdatedDateUTC>2013-04-01T23:02:36</UpdatedDateUTC>
-  </Employee>
-*/
 //Employees contains a collection of Employees
 type Employees struct {
 	Employees []Employee `json:"Employees" xml:"Employee"`
@@ -62,40 +110,23 @@ var (
 	dayZero = time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
 )
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (c *Employees) convertDates() error {
-	var err error
-	for n := len(c.Employees) - 1; n >= 0; n-- {
-		c.Employees[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(c.Employees[n].UpdatedDateUTC, true)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func unmarshalEmployee(employeeResponseBytes []byte) (*Employees, error) {
 	var employeeResponse *Employees
-
-	log.Printf("Employee: %s\n", string(employeeResponseBytes))
-
 	err := json.Unmarshal(employeeResponseBytes, &employeeResponse)
 	if err != nil {
 		return nil, err
 	}
 
-	err = employeeResponse.convertDates()
-	if err != nil {
-		return nil, err
-	}
-
 	return employeeResponse, err
 }
 
 //Create will create Employees given an Employees struct
 func (c *Employees) Create(provider *xerogolang.Provider, session goth.Session) (*Employees, error) {
+	return c.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (c *Employees) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Employees, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -106,7 +137,8 @@ func (c *Employees) Create(provider *xerogolang.Provider, session goth.Session)
 		return nil, err
 	}
 
-	employeeResponseBytes, err := provider.Create(session, "Employees", additionalHeaders, body)
+	//the AU Payroll API creates employees with a POST rather than a PUT
+	employeeResponseBytes, err := provider.UpdateWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "Employees", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -117,6 +149,11 @@ func (c *Employees) Create(provider *xerogolang.Provider, session goth.Session)
 //Update will update a Employee given a Employees struct
 //This will only handle single Employee - you cannot update multiple Employees in a single call
 func (c *Employees) Update(provider *xerogolang.Provider, session goth.Session) (*Employees, error) {
+	return c.UpdateCtx(context.Background(), provider, session)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (c *Employees) UpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Employees, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -127,7 +164,7 @@ func (c *Employees) Update(provider *xerogolang.Provider, session goth.Session)
 		return nil, err
 	}
 
-	employeeResponseBytes, err := provider.Update(session, "Employees/"+c.Employees[0].EmployeeID, additionalHeaders, body)
+	employeeResponseBytes, err := provider.UpdateWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "Employees/"+c.Employees[0].EmployeeID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -135,8 +172,14 @@ func (c *Employees) Update(provider *xerogolang.Provider, session goth.Session)
 	return unmarshalEmployee(employeeResponseBytes)
 }
 
-//FindEmployeesModifiedSince
+//FindEmployeesModifiedSince will get all Employees modified after a specified date. Paging is enforced by default. 100 employees are returned per page.
+//additional querystringParameters such as where, page, order can be added as a map
 func FindEmployeesModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Employees, error) {
+	return FindEmployeesModifiedSinceCtx(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindEmployeesModifiedSinceCtx is FindEmployeesModifiedSince with a context that can cancel the request or set its deadline
+func FindEmployeesModifiedSinceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Employees, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -145,7 +188,7 @@ func FindEmployeesModifiedSince(provider *xerogolang.Provider, session goth.Sess
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	employeeResponseBytes, err := provider.FindWithEndpoint(session, "https://api.xero.com/payroll.xro/1.0/", "Employees", additionalHeaders, querystringParameters)
+	employeeResponseBytes, err := provider.FindWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "Employees", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -155,18 +198,45 @@ func FindEmployeesModifiedSince(provider *xerogolang.Provider, session goth.Sess
 
 //FindEmployees will get all Employees.
 func FindEmployees(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Employees, error) {
-	return FindEmployeesModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindEmployeesCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindEmployeesCtx is FindEmployees with a context that can cancel the request or set its deadline
+func FindEmployeesCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Employees, error) {
+	return FindEmployeesModifiedSinceCtx(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//EachEmployee calls fn with every employee modified since modifiedSince, fetching them 100 at a time
+//until Xero returns an empty page. Pass a zero time.Time to get all employees. where and order
+//querystringParameters are sent with every page. Return xerogolang.ErrStop from fn to stop early
+func EachEmployee(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string, fn func(employee Employee) error) error {
+	return xerogolang.EachPage(ctx, querystringParameters, func(pageParameters map[string]string) (int, error) {
+		employees, err := FindEmployeesModifiedSinceCtx(ctx, provider, session, modifiedSince, pageParameters)
+		if err != nil {
+			return 0, err
+		}
+		for _, employee := range employees.Employees {
+			err = fn(employee)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return len(employees.Employees), nil
+	})
 }
 
-//FindEmployee will get a single Employee
+//FindEmployee will get a single Employee with their tax declaration, bank accounts, super memberships, leave balances and pay template
 func FindEmployee(provider *xerogolang.Provider, session goth.Session, employeeID string) (*Employees, error) {
+	return FindEmployeeCtx(context.Background(), provider, session, employeeID)
+}
+
+//FindEmployeeCtx is FindEmployee with a context that can cancel the request or set its deadline
+func FindEmployeeCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, employeeID string) (*Employees, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	log.Printf("Calling FindEmployee: %s\n", employeeID)
-
-	employeeResponseBytes, err := provider.FindWithEndpoint(session, "https://api.xero.com/payroll.xro/1.0/", "Employees/"+employeeID, additionalHeaders, nil)
+	employeeResponseBytes, err := provider.FindWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "Employees/"+employeeID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,119 @@
+package payroll
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opensimsim/xerogolang"
+	"github.com/stretchr/testify/assert"
+)
+
+//xmlBody joins the lines of a request body as the payroll package indents it
+func xmlBody(lines ...string) string {
+	return strings.Join(lines, "\n")
+}
+
+func Test_Employees_Create(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	response := `{"Employees":[{"EmployeeID":"e-1","FirstName":"Art","LastName":"Vandelay","Status":"ACTIVE"}]}`
+	mockPayroll([]string{response}, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		employees := &Employees{Employees: []Employee{{
+			FirstName:   "Art",
+			LastName:    "Vandelay",
+			DateOfBirth: xerogolang.NewDate(1970, time.January, 31),
+			HomeAddress: &HomeAddress{AddressLine1: "1 Main St", City: "Sydney", Region: "NSW", PostalCode: "2000"},
+			BankAccounts: &[]BankAccount{
+				{AccountName: "Savings", BSB: "062000", AccountNumber: "1234", Amount: xerogolang.MustParseDecimal("100.00")},
+				{AccountName: "Everyday", BSB: "062000", AccountNumber: "5678", Remainder: true},
+			},
+			TaxDeclaration: &TaxDeclaration{EmploymentBasis: EmploymentBasisFullTime, TaxFreeThresholdClaimed: true},
+		}}}
+
+		created, err := employees.Create(provider, session)
+		a.NoError(err)
+		a.Equal("e-1", created.Employees[0].EmployeeID)
+		a.Equal(EmployeeStatusActive, created.Employees[0].Status)
+
+		//the AU Payroll API creates employees with a POST and nests lists in their own elements
+		a.Equal([]string{"POST /Employees " + xmlBody(
+			"  <Employees>",
+			"     <Employee>",
+			"        <FirstName>Art</FirstName>",
+			"        <LastName>Vandelay</LastName>",
+			"        <DateOfBirth>1970-01-31T00:00:00</DateOfBirth>",
+			"        <HomeAddress>",
+			"           <AddressLine1>1 Main St</AddressLine1>",
+			"           <City>Sydney</City>",
+			"           <Region>NSW</Region>",
+			"           <PostalCode>2000</PostalCode>",
+			"        </HomeAddress>",
+			"        <BankAccounts>",
+			"           <BankAccount>",
+			"              <AccountName>Savings</AccountName>",
+			"              <BSB>062000</BSB>",
+			"              <AccountNumber>1234</AccountNumber>",
+			"              <Amount>100.00</Amount>",
+			"           </BankAccount>",
+			"           <BankAccount>",
+			"              <AccountName>Everyday</AccountName>",
+			"              <BSB>062000</BSB>",
+			"              <AccountNumber>5678</AccountNumber>",
+			"              <Remainder>true</Remainder>",
+			"           </BankAccount>",
+			"        </BankAccounts>",
+			"        <TaxDeclaration>",
+			"           <EmploymentBasis>FULLTIME</EmploymentBasis>",
+			"           <TaxFreeThresholdClaimed>true</TaxFreeThresholdClaimed>",
+			"        </TaxDeclaration>",
+			"     </Employee>",
+			"  </Employees>",
+		)}, *requests)
+	})
+}
+
+func Test_Employees_Update(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockPayroll([]string{`{"Employees":[{"EmployeeID":"e-1"}]}`}, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		employees := &Employees{Employees: []Employee{{EmployeeID: "e-1", Email: "art@vandelay.com"}}}
+		_, err := employees.Update(provider, session)
+		a.NoError(err)
+		a.Equal([]string{"POST /Employees/e-1 " + xmlBody(
+			"  <Employees>",
+			"     <Employee>",
+			"        <EmployeeID>e-1</EmployeeID>",
+			"        <Email>art@vandelay.com</Email>",
+			"     </Employee>",
+			"  </Employees>",
+		)}, *requests)
+	})
+}
+
+func Test_FindEmployee(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	response := `{"Employees":[{
+		"EmployeeID":"e-1",
+		"FirstName":"Art",
+		"DateOfBirth":"/Date(33436800000+0000)/",
+		"LeaveBalances":[{"LeaveName":"Annual Leave","LeaveTypeID":"lt-1","NumberOfUnits":76.5,"TypeOfUnits":"Hours"}],
+		"BankAccounts":[{"AccountName":"Everyday","Remainder":true}],
+		"PayTemplate":{"EarningsLines":[{"EarningsRateID":"er-1","CalculationType":"USEEARNINGSRATE","NumberOfUnitsPerWeek":38}]}
+	}]}`
+	mockPayroll([]string{response}, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		employees, err := FindEmployee(provider, session, "e-1")
+		a.NoError(err)
+		employee := employees.Employees[0]
+		a.Equal("Art", employee.FirstName)
+		a.Equal(xerogolang.NewDate(1971, time.January, 23), employee.DateOfBirth)
+		a.Equal("76.5", (*employee.LeaveBalances)[0].NumberOfUnits.String())
+		a.True((*employee.BankAccounts)[0].Remainder)
+		a.Equal("38", (*employee.PayTemplate.EarningsLines)[0].NumberOfUnitsPerWeek.String())
+		a.Equal([]string{"GET /Employees/e-1 "}, *requests)
+	})
+}
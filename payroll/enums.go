@@ -0,0 +1,159 @@
+package payroll
+
+import "fmt"
+
+//The types below are the fixed sets of codes the AU Payroll API accepts. As in the accounting
+//package, encoding a value that isn't one of the constants fails before the request is sent but
+//any value is kept when decoding. An empty value means the field has not been set
+
+//EmployeeStatus is the Status of an Employee
+type EmployeeStatus string
+
+const (
+	EmployeeStatusActive     EmployeeStatus = "ACTIVE"
+	EmployeeStatusTerminated EmployeeStatus = "TERMINATED"
+)
+
+//IsValid reports whether s is one of the EmployeeStatus constants
+func (s EmployeeStatus) IsValid() bool {
+	switch s {
+	case EmployeeStatusActive, EmployeeStatusTerminated:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown employee statuses
+func (s EmployeeStatus) MarshalText() ([]byte, error) {
+	return marshalEnum("EmployeeStatus", string(s), s.IsValid())
+}
+
+//EmploymentBasis is how an employee is employed, as declared on their TaxDeclaration
+type EmploymentBasis string
+
+const (
+	EmploymentBasisFullTime          EmploymentBasis = "FULLTIME"
+	EmploymentBasisPartTime          EmploymentBasis = "PARTTIME"
+	EmploymentBasisCasual            EmploymentBasis = "CASUAL"
+	EmploymentBasisLabourHire        EmploymentBasis = "LABOURHIRE"
+	EmploymentBasisSuperIncomeStream EmploymentBasis = "SUPERINCOMESTREAM"
+)
+
+//IsValid reports whether b is one of the EmploymentBasis constants
+func (b EmploymentBasis) IsValid() bool {
+	switch b {
+	case EmploymentBasisFullTime, EmploymentBasisPartTime, EmploymentBasisCasual, EmploymentBasisLabourHire, EmploymentBasisSuperIncomeStream:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown employment bases
+func (b EmploymentBasis) MarshalText() ([]byte, error) {
+	return marshalEnum("EmploymentBasis", string(b), b.IsValid())
+}
+
+//CalendarType is how often a PayrollCalendar pays employees
+type CalendarType string
+
+const (
+	CalendarTypeWeekly       CalendarType = "WEEKLY"
+	CalendarTypeFortnightly  CalendarType = "FORTNIGHTLY"
+	CalendarTypeFourWeekly   CalendarType = "FOURWEEKLY"
+	CalendarTypeMonthly      CalendarType = "MONTHLY"
+	CalendarTypeTwiceMonthly CalendarType = "TWICEMONTHLY"
+	CalendarTypeQuarterly    CalendarType = "QUARTERLY"
+)
+
+//IsValid reports whether t is one of the CalendarType constants
+func (t CalendarType) IsValid() bool {
+	switch t {
+	case CalendarTypeWeekly, CalendarTypeFortnightly, CalendarTypeFourWeekly, CalendarTypeMonthly, CalendarTypeTwiceMonthly, CalendarTypeQuarterly:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown calendar types
+func (t CalendarType) MarshalText() ([]byte, error) {
+	return marshalEnum("CalendarType", string(t), t.IsValid())
+}
+
+//PayRunStatus is the PayRunStatus of a PayRun
+type PayRunStatus string
+
+const (
+	PayRunStatusDraft  PayRunStatus = "DRAFT"
+	PayRunStatusPosted PayRunStatus = "POSTED"
+)
+
+//IsValid reports whether s is one of the PayRunStatus constants
+func (s PayRunStatus) IsValid() bool {
+	switch s {
+	case PayRunStatusDraft, PayRunStatusPosted:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown pay run statuses
+func (s PayRunStatus) MarshalText() ([]byte, error) {
+	return marshalEnum("PayRunStatus", string(s), s.IsValid())
+}
+
+//TimesheetStatus is the Status of a Timesheet
+type TimesheetStatus string
+
+const (
+	TimesheetStatusDraft     TimesheetStatus = "DRAFT"
+	TimesheetStatusApproved  TimesheetStatus = "APPROVED"
+	TimesheetStatusProcessed TimesheetStatus = "PROCESSED"
+	TimesheetStatusRejected  TimesheetStatus = "REJECTED"
+	TimesheetStatusRequested TimesheetStatus = "REQUESTED"
+)
+
+//IsValid reports whether s is one of the TimesheetStatus constants
+func (s TimesheetStatus) IsValid() bool {
+	switch s {
+	case TimesheetStatusDraft, TimesheetStatusApproved, TimesheetStatusProcessed, TimesheetStatusRejected, TimesheetStatusRequested:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown timesheet statuses
+func (s TimesheetStatus) MarshalText() ([]byte, error) {
+	return marshalEnum("TimesheetStatus", string(s), s.IsValid())
+}
+
+//SuperFundType is the Type of a SuperFund
+type SuperFundType string
+
+const (
+	//SuperFundTypeRegulated is an APRA regulated fund, found by its USI
+	SuperFundTypeRegulated SuperFundType = "REGULATED"
+	//SuperFundTypeSMSF is a self managed super fund, paid by bank account
+	SuperFundTypeSMSF SuperFundType = "SMSF"
+)
+
+//IsValid reports whether t is one of the SuperFundType constants
+func (t SuperFundType) IsValid() bool {
+	switch t {
+	case SuperFundTypeRegulated, SuperFundTypeSMSF:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown super fund types
+func (t SuperFundType) MarshalText() ([]byte, error) {
+	return marshalEnum("SuperFundType", string(t), t.IsValid())
+}
+
+//marshalEnum returns the value to encode, or an error if it is set but not valid
+func marshalEnum(typeName string, value string, valid bool) ([]byte, error) {
+	if value != "" && !valid {
+		return nil, fmt.Errorf("%q is not a valid %s", value, typeName)
+	}
+	return []byte(value), nil
+}
@@ -0,0 +1,23 @@
+package payroll
+
+//HomeAddress is where an employee lives
+type HomeAddress struct {
+
+	// Address line 1 for employee home address
+	AddressLine1 string `json:"AddressLine1,omitempty" xml:"AddressLine1,omitempty"`
+
+	// Address line 2 for employee home address
+	AddressLine2 string `json:"AddressLine2,omitempty" xml:"AddressLine2,omitempty"`
+
+	// Suburb for employee home address
+	City string `json:"City,omitempty" xml:"City,omitempty"`
+
+	// State abbreviation for employee home address e.g. NSW, QLD or VIC
+	Region string `json:"Region,omitempty" xml:"Region,omitempty"`
+
+	// PostCode for employee home address
+	PostalCode string `json:"PostalCode,omitempty" xml:"PostalCode,omitempty"`
+
+	// Country of HomeAddress
+	Country string `json:"Country,omitempty" xml:"Country,omitempty"`
+}
@@ -0,0 +1,124 @@
+package payrollv2
+
+import (
+	"context"
+
+	"github.com/markbates/goth"
+	"github.com/opensimsim/xerogolang"
+)
+
+//EmployeeLeave is leave an employee has taken or will take, split into the pay periods it falls in
+type EmployeeLeave struct {
+
+	// The Xero identifier for the leave
+	LeaveID string `json:"leaveID,omitempty"`
+
+	// The Xero identifier for LeaveType
+	LeaveTypeID string `json:"leaveTypeID,omitempty"`
+
+	// The description of the leave (max length = 50)
+	Description string `json:"description,omitempty"`
+
+	// Start date of the leave (YYYY-MM-DD)
+	StartDate xerogolang.Date `json:"startDate,omitzero"`
+
+	// End date of the leave (YYYY-MM-DD)
+	EndDate xerogolang.Date `json:"endDate,omitzero"`
+
+	// The leave period information. Xero works these out from the dates when they are left out
+	Periods []LeavePeriod `json:"periods,omitempty"`
+
+	// UTC timestamp of last update to the leave type note
+	UpdatedDateUTC xerogolang.DateTime `json:"updatedDateUTC,omitzero"`
+}
+
+//EmployeeLeaves contains the leave of one employee
+type EmployeeLeaves struct {
+	Leave []EmployeeLeave `json:"leave"`
+}
+
+//unmarshalEmployeeLeave reads leave, which is a list when all of it is asked for and a single
+//object when one leave is asked for or saved
+func unmarshalEmployeeLeave(employeeLeaveResponseBytes []byte) (*EmployeeLeaves, error) {
+	_, leave, err := Unmarshal[EmployeeLeave](employeeLeaveResponseBytes, "leave", "leave")
+	if err != nil {
+		return nil, err
+	}
+
+	return &EmployeeLeaves{Leave: leave}, nil
+}
+
+//Create will create EmployeeLeave for the employee with employeeID
+func (l *EmployeeLeave) Create(provider *xerogolang.Provider, session goth.Session, employeeID string) (*EmployeeLeaves, error) {
+	return l.CreateCtx(context.Background(), provider, session, employeeID)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (l *EmployeeLeave) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, employeeID string) (*EmployeeLeaves, error) {
+	employeeLeaveResponseBytes, err := Post(ctx, provider, session, "Employees/"+employeeID+"/Leave", l)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalEmployeeLeave(employeeLeaveResponseBytes)
+}
+
+//Update will update the EmployeeLeave with l's LeaveID for the employee with employeeID
+func (l *EmployeeLeave) Update(provider *xerogolang.Provider, session goth.Session, employeeID string) (*EmployeeLeaves, error) {
+	return l.UpdateCtx(context.Background(), provider, session, employeeID)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (l *EmployeeLeave) UpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, employeeID string) (*EmployeeLeaves, error) {
+	employeeLeaveResponseBytes, err := Put(ctx, provider, session, "Employees/"+employeeID+"/Leave/"+l.LeaveID, l)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalEmployeeLeave(employeeLeaveResponseBytes)
+}
+
+//FindEmployeeLeaves will get all the leave of the employee with employeeID
+func FindEmployeeLeaves(provider *xerogolang.Provider, session goth.Session, employeeID string) (*EmployeeLeaves, error) {
+	return FindEmployeeLeavesCtx(context.Background(), provider, session, employeeID)
+}
+
+//FindEmployeeLeavesCtx is FindEmployeeLeaves with a context that can cancel the request or set its deadline
+func FindEmployeeLeavesCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, employeeID string) (*EmployeeLeaves, error) {
+	employeeLeaveResponseBytes, err := Get(ctx, provider, session, "Employees/"+employeeID+"/Leave", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalEmployeeLeave(employeeLeaveResponseBytes)
+}
+
+//FindEmployeeLeave will get a single EmployeeLeave of the employee with employeeID
+func FindEmployeeLeave(provider *xerogolang.Provider, session goth.Session, employeeID string, leaveID string) (*EmployeeLeaves, error) {
+	return FindEmployeeLeaveCtx(context.Background(), provider, session, employeeID, leaveID)
+}
+
+//FindEmployeeLeaveCtx is FindEmployeeLeave with a context that can cancel the request or set its deadline
+func FindEmployeeLeaveCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, employeeID string, leaveID string) (*EmployeeLeaves, error) {
+	employeeLeaveResponseBytes, err := Get(ctx, provider, session, "Employees/"+employeeID+"/Leave/"+leaveID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalEmployeeLeave(employeeLeaveResponseBytes)
+}
+
+//RemoveEmployeeLeave will delete a single EmployeeLeave of the employee with employeeID
+func RemoveEmployeeLeave(provider *xerogolang.Provider, session goth.Session, employeeID string, leaveID string) (*EmployeeLeaves, error) {
+	return RemoveEmployeeLeaveCtx(context.Background(), provider, session, employeeID, leaveID)
+}
+
+//RemoveEmployeeLeaveCtx is RemoveEmployeeLeave with a context that can cancel the request or set its deadline
+func RemoveEmployeeLeaveCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, employeeID string, leaveID string) (*EmployeeLeaves, error) {
+	employeeLeaveResponseBytes, err := Delete(ctx, provider, session, "Employees/"+employeeID+"/Leave/"+leaveID)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalEmployeeLeave(employeeLeaveResponseBytes)
+}
@@ -0,0 +1,62 @@
+package payrollv2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/opensimsim/xerogolang"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EmployeeLeave_Create(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	response := `{"leave":{"leaveID":"l-1","leaveTypeID":"lt-1","periods":[{"periodStartDate":"2020-03-02T00:00:00","periodEndDate":"2020-03-08T00:00:00","numberOfUnits":15,"periodStatus":"Approved"}]}}`
+	mockPayroll(response, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]recordedRequest) {
+		leave := &EmployeeLeave{
+			LeaveTypeID: "lt-1",
+			Description: "Holiday",
+			StartDate:   xerogolang.NewDate(2020, time.March, 3),
+			EndDate:     xerogolang.NewDate(2020, time.March, 4),
+		}
+
+		leaves, err := leave.Create(provider, session, "e-1")
+		a.NoError(err)
+		a.Len(leaves.Leave, 1)
+		a.Equal("l-1", leaves.Leave[0].LeaveID)
+		a.Equal(LeavePeriodStatusApproved, leaves.Leave[0].Periods[0].PeriodStatus)
+		a.Equal("15", leaves.Leave[0].Periods[0].NumberOfUnits.String())
+
+		a.Equal("POST", (*requests)[0].Method)
+		a.Equal("/payroll.xro/2.0/Employees/e-1/Leave", (*requests)[0].Path)
+		a.JSONEq(`{"leaveTypeID":"lt-1","description":"Holiday","startDate":"2020-03-03T00:00:00","endDate":"2020-03-04T00:00:00"}`, (*requests)[0].Body)
+
+		leave.LeaveID = "l-1"
+		_, err = leave.Update(provider, session, "e-1")
+		a.NoError(err)
+		a.Equal("PUT", (*requests)[1].Method)
+		a.Equal("/payroll.xro/2.0/Employees/e-1/Leave/l-1", (*requests)[1].Path)
+	})
+}
+
+func Test_FindEmployeeLeaves(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	response := `{"leave":[{"leaveID":"l-1"},{"leaveID":"l-2"}]}`
+	mockPayroll(response, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]recordedRequest) {
+		leaves, err := FindEmployeeLeaves(provider, session, "e-1")
+		a.NoError(err)
+		a.Len(leaves.Leave, 2)
+		a.Equal("l-2", leaves.Leave[1].LeaveID)
+
+		_, err = RemoveEmployeeLeave(provider, session, "e-1", "l-1")
+		a.NoError(err)
+
+		a.Equal([]recordedRequest{
+			{Method: "GET", Path: "/payroll.xro/2.0/Employees/e-1/Leave"},
+			{Method: "DELETE", Path: "/payroll.xro/2.0/Employees/e-1/Leave/l-1"},
+		}, *requests)
+	})
+}
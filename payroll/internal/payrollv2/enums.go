@@ -0,0 +1,108 @@
+package payrollv2
+
+import "fmt"
+
+//The types below are the codes that are the same in the UK and NZ Payroll APIs. Encoding a value that
+//isn't one of the constants fails before the request is sent but any value is kept when decoding.
+//An empty value means the field has not been set
+
+//CalendarType is how often the payroll calendar of a pay run pays employees
+type CalendarType string
+
+const (
+	CalendarTypeWeekly      CalendarType = "Weekly"
+	CalendarTypeFortnightly CalendarType = "Fortnightly"
+	CalendarTypeFourWeekly  CalendarType = "FourWeekly"
+	CalendarTypeMonthly     CalendarType = "Monthly"
+	CalendarTypeAnnual      CalendarType = "Annual"
+	CalendarTypeQuarterly   CalendarType = "Quarterly"
+)
+
+//IsValid reports whether t is one of the CalendarType constants
+func (t CalendarType) IsValid() bool {
+	switch t {
+	case CalendarTypeWeekly, CalendarTypeFortnightly, CalendarTypeFourWeekly, CalendarTypeMonthly, CalendarTypeAnnual, CalendarTypeQuarterly:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown calendar types
+func (t CalendarType) MarshalText() ([]byte, error) {
+	return MarshalEnum("CalendarType", string(t), t.IsValid())
+}
+
+//PayRunStatus is the PayRunStatus of a pay run
+type PayRunStatus string
+
+const (
+	PayRunStatusDraft  PayRunStatus = "Draft"
+	PayRunStatusPosted PayRunStatus = "Posted"
+)
+
+//IsValid reports whether s is one of the PayRunStatus constants
+func (s PayRunStatus) IsValid() bool {
+	switch s {
+	case PayRunStatusDraft, PayRunStatusPosted:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown pay run statuses
+func (s PayRunStatus) MarshalText() ([]byte, error) {
+	return MarshalEnum("PayRunStatus", string(s), s.IsValid())
+}
+
+//TimesheetStatus is the Status of a Timesheet
+type TimesheetStatus string
+
+const (
+	TimesheetStatusDraft     TimesheetStatus = "Draft"
+	TimesheetStatusApproved  TimesheetStatus = "Approved"
+	TimesheetStatusCompleted TimesheetStatus = "Completed"
+)
+
+//IsValid reports whether s is one of the TimesheetStatus constants
+func (s TimesheetStatus) IsValid() bool {
+	switch s {
+	case TimesheetStatusDraft, TimesheetStatusApproved, TimesheetStatusCompleted:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown timesheet statuses
+func (s TimesheetStatus) MarshalText() ([]byte, error) {
+	return MarshalEnum("TimesheetStatus", string(s), s.IsValid())
+}
+
+//LeavePeriodStatus is the PeriodStatus of a LeavePeriod
+type LeavePeriodStatus string
+
+const (
+	LeavePeriodStatusApproved  LeavePeriodStatus = "Approved"
+	LeavePeriodStatusCompleted LeavePeriodStatus = "Completed"
+)
+
+//IsValid reports whether s is one of the LeavePeriodStatus constants
+func (s LeavePeriodStatus) IsValid() bool {
+	switch s {
+	case LeavePeriodStatusApproved, LeavePeriodStatusCompleted:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown leave period statuses
+func (s LeavePeriodStatus) MarshalText() ([]byte, error) {
+	return MarshalEnum("LeavePeriodStatus", string(s), s.IsValid())
+}
+
+//MarshalEnum returns the value to encode, or an error if it is set but not valid
+func MarshalEnum(typeName string, value string, valid bool) ([]byte, error) {
+	if value != "" && !valid {
+		return nil, fmt.Errorf("%q is not a valid %s", value, typeName)
+	}
+	return []byte(value), nil
+}
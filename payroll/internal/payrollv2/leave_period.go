@@ -0,0 +1,19 @@
+package payrollv2
+
+import "github.com/opensimsim/xerogolang"
+
+//LeavePeriod is the leave an employee takes in one pay period of an EmployeeLeave
+type LeavePeriod struct {
+
+	// The Pay Period Start Date (YYYY-MM-DD)
+	PeriodStartDate xerogolang.Date `json:"periodStartDate,omitzero"`
+
+	// The Pay Period End Date (YYYY-MM-DD)
+	PeriodEndDate xerogolang.Date `json:"periodEndDate,omitzero"`
+
+	// The Number of Units for the leave
+	NumberOfUnits xerogolang.Decimal `json:"numberOfUnits,omitzero"`
+
+	// See LeavePeriodStatus
+	PeriodStatus LeavePeriodStatus `json:"periodStatus,omitempty"`
+}
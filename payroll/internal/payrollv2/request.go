@@ -0,0 +1,139 @@
+//Package payrollv2 holds what the uk and nz packages share: the requests to payroll.xro/2.0 and the
+//models that are the same in both countries, which uk and nz re-export as their own
+package payrollv2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/markbates/goth"
+	"github.com/opensimsim/xerogolang"
+)
+
+//Get fetches path, a page at a time for lists
+func Get(ctx context.Context, provider *xerogolang.Provider, session goth.Session, path string, querystringParameters map[string]string) ([]byte, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	return provider.FindWithEndpointCtx(ctx, session, provider.PayrollV2Endpoint(), path, additionalHeaders, querystringParameters)
+}
+
+//Post sends record to path as JSON. The API creates with POST, and record may be nil for actions
+//such as approving a timesheet
+func Post(ctx context.Context, provider *xerogolang.Provider, session goth.Session, path string, record interface{}) ([]byte, error) {
+	additionalHeaders, body, err := jsonBody(record)
+	if err != nil {
+		return nil, err
+	}
+
+	return provider.UpdateWithEndpointCtx(ctx, session, provider.PayrollV2Endpoint(), path, additionalHeaders, body)
+}
+
+//Put sends record to path as JSON. The API updates with PUT
+func Put(ctx context.Context, provider *xerogolang.Provider, session goth.Session, path string, record interface{}) ([]byte, error) {
+	additionalHeaders, body, err := jsonBody(record)
+	if err != nil {
+		return nil, err
+	}
+
+	return provider.CreateWithEndpointCtx(ctx, session, provider.PayrollV2Endpoint(), path, additionalHeaders, body)
+}
+
+//Delete removes the record at path
+func Delete(ctx context.Context, provider *xerogolang.Provider, session goth.Session, path string) ([]byte, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	return provider.RemoveWithEndpointCtx(ctx, session, provider.PayrollV2Endpoint(), path, additionalHeaders)
+}
+
+func jsonBody(record interface{}) (map[string]string, []byte, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+	if record == nil {
+		return additionalHeaders, nil, nil
+	}
+
+	body, err := json.Marshal(record)
+	if err != nil {
+		return nil, nil, err
+	}
+	additionalHeaders["Content-Type"] = "application/json"
+	return additionalHeaders, body, nil
+}
+
+//Unmarshal reads the records in a response along with its pagination. A page of records is a list
+//under plural and a single record is an object under singular. Some resources, such as leave, use
+//the same key for both
+func Unmarshal[T any](responseBytes []byte, singular string, plural string) (*xerogolang.Pagination, []T, error) {
+	var response map[string]json.RawMessage
+	err := json.Unmarshal(responseBytes, &response)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var pagination *xerogolang.Pagination
+	if isSet(response["pagination"]) {
+		err = json.Unmarshal(response["pagination"], &pagination)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	keys := []string{plural, singular}
+	if singular == plural {
+		keys = keys[:1]
+	}
+	var records []T
+	for _, key := range keys {
+		raw := bytes.TrimSpace(response[key])
+		if !isSet(raw) {
+			continue
+		}
+		if raw[0] == '[' {
+			var page []T
+			err = json.Unmarshal(raw, &page)
+			records = append(records, page...)
+		} else {
+			var record T
+			err = json.Unmarshal(raw, &record)
+			records = append(records, record)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return pagination, records, nil
+}
+
+//isSet reports whether a value is present and not null
+func isSet(raw json.RawMessage) bool {
+	raw = bytes.TrimSpace(raw)
+	return len(raw) > 0 && !bytes.Equal(raw, []byte("null"))
+}
+
+//Each calls fn with every record returned by find, a page at a time, until the pagination says it
+//was the last page or a page comes back empty. Return xerogolang.ErrStop from fn to stop early
+func Each[T any](ctx context.Context, querystringParameters map[string]string, find func(pageParameters map[string]string) (*xerogolang.Pagination, []T, error), fn func(record T) error) error {
+	return xerogolang.EachPage(ctx, querystringParameters, func(pageParameters map[string]string) (int, error) {
+		pagination, records, err := find(pageParameters)
+		if err != nil {
+			return 0, err
+		}
+		for _, record := range records {
+			err = fn(record)
+			if err != nil {
+				return 0, err
+			}
+		}
+		if pagination.Last() {
+			return 0, xerogolang.ErrStop
+		}
+		return len(records), nil
+	})
+}
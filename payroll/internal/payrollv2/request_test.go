@@ -0,0 +1,200 @@
+package payrollv2
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opensimsim/xerogolang"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+//recordedRequest is what the fake payroll API was sent
+type recordedRequest struct {
+	Method      string
+	Path        string
+	Query       string
+	ContentType string
+	Body        string
+}
+
+//mockPayroll answers every request with response and records what was sent
+func mockPayroll(response string, f func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]recordedRequest)) {
+	var requests []recordedRequest
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		requests = append(requests, recordedRequest{
+			Method:      req.Method,
+			Path:        req.URL.Path,
+			Query:       req.URL.Query().Encode(),
+			ContentType: req.Header.Get("Content-Type"),
+			Body:        string(body),
+		})
+		fmt.Fprint(res, response)
+	}))
+	defer ts.Close()
+
+	provider := xerogolang.NewOAuth2("CLIENT", "SECRET", "/foo")
+	provider.Endpoints = xerogolang.Endpoints{PayrollV2: ts.URL + "/payroll.xro/2.0/"}
+	session := &xerogolang.Session{OAuth2Token: &oauth2.Token{AccessToken: "ACCESSTOKEN", RefreshToken: "REFRESHTOKEN", TokenType: "Bearer"}}
+	f(provider, session, &requests)
+}
+
+func Test_Requests(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockPayroll(`{}`, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]recordedRequest) {
+		ctx := context.Background()
+		record := map[string]string{"name": "Kramer"}
+
+		_, err := Get(ctx, provider, session, "Employees", map[string]string{"page": "2"})
+		a.NoError(err)
+		_, err = Post(ctx, provider, session, "Employees", record)
+		a.NoError(err)
+		_, err = Post(ctx, provider, session, "Timesheets/t-1/Approve", nil)
+		a.NoError(err)
+		_, err = Put(ctx, provider, session, "Employees/e-1", record)
+		a.NoError(err)
+		_, err = Delete(ctx, provider, session, "Timesheets/t-1")
+		a.NoError(err)
+
+		a.Equal([]recordedRequest{
+			{Method: "GET", Path: "/payroll.xro/2.0/Employees", Query: "page=2"},
+			{Method: "POST", Path: "/payroll.xro/2.0/Employees", ContentType: "application/json", Body: `{"name":"Kramer"}`},
+			{Method: "POST", Path: "/payroll.xro/2.0/Timesheets/t-1/Approve"},
+			{Method: "PUT", Path: "/payroll.xro/2.0/Employees/e-1", ContentType: "application/json", Body: `{"name":"Kramer"}`},
+			{Method: "DELETE", Path: "/payroll.xro/2.0/Timesheets/t-1"},
+		}, *requests)
+	})
+}
+
+func Test_Unmarshal(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	type record struct {
+		ID string `json:"id"`
+	}
+
+	testCases := []struct {
+		name       string
+		response   string
+		singular   string
+		plural     string
+		pagination *xerogolang.Pagination
+		records    []record
+	}{
+		{
+			name:       "page",
+			response:   `{"pagination":{"page":2,"pageSize":100,"pageCount":3,"itemCount":201},"records":[{"id":"a"},{"id":"b"}]}`,
+			singular:   "record",
+			plural:     "records",
+			pagination: &xerogolang.Pagination{Page: 2, PageSize: 100, PageCount: 3, ItemCount: 201},
+			records:    []record{{ID: "a"}, {ID: "b"}},
+		},
+		{
+			name:     "single record",
+			response: `{"problem":null,"record":{"id":"a"}}`,
+			singular: "record",
+			plural:   "records",
+			records:  []record{{ID: "a"}},
+		},
+		{
+			name:     "same key holding a list",
+			response: `{"leave":[{"id":"a"},{"id":"b"}]}`,
+			singular: "leave",
+			plural:   "leave",
+			records:  []record{{ID: "a"}, {ID: "b"}},
+		},
+		{
+			name:     "same key holding an object",
+			response: `{"leave":{"id":"a"}}`,
+			singular: "leave",
+			plural:   "leave",
+			records:  []record{{ID: "a"}},
+		},
+		{
+			name:     "null and missing",
+			response: `{"pagination":null,"records":null}`,
+			singular: "record",
+			plural:   "records",
+		},
+	}
+
+	for _, testCase := range testCases {
+		pagination, records, err := Unmarshal[record]([]byte(testCase.response), testCase.singular, testCase.plural)
+		a.NoError(err, testCase.name)
+		a.Equal(testCase.pagination, pagination, testCase.name)
+		a.Equal(testCase.records, records, testCase.name)
+	}
+
+	_, _, err := Unmarshal[record]([]byte(`{"records":{"id":1}}`), "record", "records")
+	a.Error(err)
+	_, _, err = Unmarshal[record]([]byte(`not json`), "record", "records")
+	a.Error(err)
+}
+
+func Test_Each(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	pages := [][]string{{"a", "b"}, {"c"}}
+	var requested []string
+	var seen []string
+	err := Each(context.Background(), map[string]string{"filter": "x"}, func(pageParameters map[string]string) (*xerogolang.Pagination, []string, error) {
+		requested = append(requested, pageParameters["page"]+"/"+pageParameters["filter"])
+		page := len(requested)
+		return &xerogolang.Pagination{Page: page, PageCount: len(pages)}, pages[page-1], nil
+	}, func(record string) error {
+		seen = append(seen, record)
+		return nil
+	})
+	a.NoError(err)
+	//the second page is the last so a third is never asked for
+	a.Equal([]string{"1/x", "2/x"}, requested)
+	a.Equal([]string{"a", "b", "c"}, seen)
+
+	seen = nil
+	err = Each(context.Background(), nil, func(pageParameters map[string]string) (*xerogolang.Pagination, []string, error) {
+		return &xerogolang.Pagination{Page: 1, PageCount: 5}, []string{"a", "b"}, nil
+	}, func(record string) error {
+		seen = append(seen, record)
+		return xerogolang.ErrStop
+	})
+	a.NoError(err)
+	a.Equal([]string{"a"}, seen)
+
+	//without pagination it stops at the first empty page
+	calls := 0
+	err = Each(context.Background(), nil, func(pageParameters map[string]string) (*xerogolang.Pagination, []string, error) {
+		calls++
+		if calls > 1 {
+			return nil, nil, nil
+		}
+		return nil, []string{"a"}, nil
+	}, func(record string) error { return nil })
+	a.NoError(err)
+	a.Equal(2, calls)
+}
+
+func Test_MarshalEnum(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	text, err := TimesheetStatusApproved.MarshalText()
+	a.NoError(err)
+	a.Equal("Approved", string(text))
+
+	text, err = CalendarType("").MarshalText()
+	a.NoError(err)
+	a.Equal("", string(text))
+
+	_, err = PayRunStatus("Paid").MarshalText()
+	a.EqualError(err, `"Paid" is not a valid PayRunStatus`)
+	a.False(LeavePeriodStatus("approved").IsValid())
+}
@@ -0,0 +1,157 @@
+package payrollv2
+
+import (
+	"context"
+
+	"github.com/markbates/goth"
+	"github.com/opensimsim/xerogolang"
+)
+
+//Timesheet is the time an employee worked in one pay period, which is paid once it is approved
+type Timesheet struct {
+
+	// The Xero identifier for a Timesheet
+	TimesheetID string `json:"timesheetID,omitempty"`
+
+	// The Xero identifier for the Payroll Calendar that the Timesheet applies to
+	PayrollCalendarID string `json:"payrollCalendarID,omitempty"`
+
+	// The Xero identifier for the Employee that the Timesheet is for
+	EmployeeID string `json:"employeeID,omitempty"`
+
+	// The Start Date of the Timesheet period (YYYY-MM-DD)
+	StartDate xerogolang.Date `json:"startDate,omitzero"`
+
+	// The End Date of the Timesheet period (YYYY-MM-DD)
+	EndDate xerogolang.Date `json:"endDate,omitzero"`
+
+	// See TimesheetStatus
+	Status TimesheetStatus `json:"status,omitempty"`
+
+	// The Total Hours of the Timesheet
+	TotalHours xerogolang.Decimal `json:"totalHours,omitzero"`
+
+	// The UTC date time that the Timesheet was last updated
+	UpdatedDateUTC xerogolang.DateTime `json:"updatedDateUTC,omitzero"`
+
+	// The units worked each day
+	TimesheetLines []TimesheetLine `json:"timesheetLines,omitempty"`
+}
+
+//Timesheets contains a page of Timesheets
+type Timesheets struct {
+	Pagination *xerogolang.Pagination `json:"pagination,omitempty"`
+	Timesheets []Timesheet            `json:"timesheets"`
+}
+
+func unmarshalTimesheet(timesheetResponseBytes []byte) (*Timesheets, error) {
+	pagination, timesheets, err := Unmarshal[Timesheet](timesheetResponseBytes, "timesheet", "timesheets")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Timesheets{Pagination: pagination, Timesheets: timesheets}, nil
+}
+
+//Create will create a draft Timesheet along with its TimesheetLines
+func (t *Timesheet) Create(provider *xerogolang.Provider, session goth.Session) (*Timesheets, error) {
+	return t.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (t *Timesheet) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Timesheets, error) {
+	timesheetResponseBytes, err := Post(ctx, provider, session, "Timesheets", t)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalTimesheet(timesheetResponseBytes)
+}
+
+//ApproveTimesheet will approve a draft Timesheet so it is paid in the next pay run
+func ApproveTimesheet(provider *xerogolang.Provider, session goth.Session, timesheetID string) (*Timesheets, error) {
+	return ApproveTimesheetCtx(context.Background(), provider, session, timesheetID)
+}
+
+//ApproveTimesheetCtx is ApproveTimesheet with a context that can cancel the request or set its deadline
+func ApproveTimesheetCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, timesheetID string) (*Timesheets, error) {
+	timesheetResponseBytes, err := Post(ctx, provider, session, "Timesheets/"+timesheetID+"/Approve", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalTimesheet(timesheetResponseBytes)
+}
+
+//RevertTimesheet will move an approved Timesheet back to draft so it can be changed
+func RevertTimesheet(provider *xerogolang.Provider, session goth.Session, timesheetID string) (*Timesheets, error) {
+	return RevertTimesheetCtx(context.Background(), provider, session, timesheetID)
+}
+
+//RevertTimesheetCtx is RevertTimesheet with a context that can cancel the request or set its deadline
+func RevertTimesheetCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, timesheetID string) (*Timesheets, error) {
+	timesheetResponseBytes, err := Post(ctx, provider, session, "Timesheets/"+timesheetID+"/RevertToDraft", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalTimesheet(timesheetResponseBytes)
+}
+
+//RemoveTimesheet will delete a draft Timesheet
+func RemoveTimesheet(provider *xerogolang.Provider, session goth.Session, timesheetID string) (*Timesheets, error) {
+	return RemoveTimesheetCtx(context.Background(), provider, session, timesheetID)
+}
+
+//RemoveTimesheetCtx is RemoveTimesheet with a context that can cancel the request or set its deadline
+func RemoveTimesheetCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, timesheetID string) (*Timesheets, error) {
+	timesheetResponseBytes, err := Delete(ctx, provider, session, "Timesheets/"+timesheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalTimesheet(timesheetResponseBytes)
+}
+
+//FindTimesheets will get a page of Timesheets.
+//additional querystringParameters such as page and filter (e.g. employeeId==...) can be added as a map
+func FindTimesheets(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Timesheets, error) {
+	return FindTimesheetsCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindTimesheetsCtx is FindTimesheets with a context that can cancel the request or set its deadline
+func FindTimesheetsCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Timesheets, error) {
+	timesheetResponseBytes, err := Get(ctx, provider, session, "Timesheets", querystringParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalTimesheet(timesheetResponseBytes)
+}
+
+//EachTimesheet calls fn with every timesheet, a page at a time, until the last page. A filter
+//querystringParameter is sent with every page. Return xerogolang.ErrStop from fn to stop early
+func EachTimesheet(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string, fn func(timesheet Timesheet) error) error {
+	return Each(ctx, querystringParameters, func(pageParameters map[string]string) (*xerogolang.Pagination, []Timesheet, error) {
+		timesheets, err := FindTimesheetsCtx(ctx, provider, session, pageParameters)
+		if err != nil {
+			return nil, nil, err
+		}
+		return timesheets.Pagination, timesheets.Timesheets, nil
+	}, fn)
+}
+
+//FindTimesheet will get a single Timesheet along with its TimesheetLines
+func FindTimesheet(provider *xerogolang.Provider, session goth.Session, timesheetID string) (*Timesheets, error) {
+	return FindTimesheetCtx(context.Background(), provider, session, timesheetID)
+}
+
+//FindTimesheetCtx is FindTimesheet with a context that can cancel the request or set its deadline
+func FindTimesheetCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, timesheetID string) (*Timesheets, error) {
+	timesheetResponseBytes, err := Get(ctx, provider, session, "Timesheets/"+timesheetID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalTimesheet(timesheetResponseBytes)
+}
@@ -0,0 +1,22 @@
+package payrollv2
+
+import "github.com/opensimsim/xerogolang"
+
+//TimesheetLine is the units worked at one earnings rate on one day of a Timesheet
+type TimesheetLine struct {
+
+	// The Xero identifier for a Timesheet Line
+	TimesheetLineID string `json:"timesheetLineID,omitempty"`
+
+	// The Date that this Timesheet Line is for (YYYY-MM-DD)
+	Date xerogolang.Date `json:"date,omitzero"`
+
+	// The Xero identifier for the Earnings Rate that the Timesheet is for
+	EarningsRateID string `json:"earningsRateID,omitempty"`
+
+	// The Xero identifier for the Tracking Item that the Timesheet is for
+	TrackingItemID string `json:"trackingItemID,omitempty"`
+
+	// The Number of Units of the Timesheet Line
+	NumberOfUnits xerogolang.Decimal `json:"numberOfUnits,omitzero"`
+}
@@ -0,0 +1,102 @@
+package payrollv2
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/opensimsim/xerogolang"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Timesheet_Create(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	response := `{"timesheet":{"timesheetID":"t-1","employeeID":"e-1","status":"Draft","totalHours":7.5}}`
+	mockPayroll(response, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]recordedRequest) {
+		timesheet := &Timesheet{
+			PayrollCalendarID: "c-1",
+			EmployeeID:        "e-1",
+			StartDate:         xerogolang.NewDate(2020, time.March, 2),
+			EndDate:           xerogolang.NewDate(2020, time.March, 8),
+			TimesheetLines: []TimesheetLine{
+				{Date: xerogolang.NewDate(2020, time.March, 2), EarningsRateID: "r-1", NumberOfUnits: xerogolang.MustParseDecimal("7.5")},
+			},
+		}
+
+		timesheets, err := timesheet.Create(provider, session)
+		a.NoError(err)
+		a.Len(timesheets.Timesheets, 1)
+		a.Equal("t-1", timesheets.Timesheets[0].TimesheetID)
+		a.Equal(TimesheetStatusDraft, timesheets.Timesheets[0].Status)
+		a.Equal("7.5", timesheets.Timesheets[0].TotalHours.String())
+
+		//the unset id, status, total and update time are left out rather than sent as zeros or nulls
+		a.Len(*requests, 1)
+		a.Equal("POST", (*requests)[0].Method)
+		a.Equal("/payroll.xro/2.0/Timesheets", (*requests)[0].Path)
+		a.JSONEq(`{
+			"payrollCalendarID":"c-1",
+			"employeeID":"e-1",
+			"startDate":"2020-03-02T00:00:00",
+			"endDate":"2020-03-08T00:00:00",
+			"timesheetLines":[{"date":"2020-03-02T00:00:00","earningsRateID":"r-1","numberOfUnits":7.5}]
+		}`, (*requests)[0].Body)
+	})
+}
+
+func Test_Timesheet_Actions(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	response := `{"timesheet":{"timesheetID":"t-1","status":"Approved"}}`
+	mockPayroll(response, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]recordedRequest) {
+		timesheets, err := ApproveTimesheet(provider, session, "t-1")
+		a.NoError(err)
+		a.Equal(TimesheetStatusApproved, timesheets.Timesheets[0].Status)
+
+		_, err = RevertTimesheet(provider, session, "t-1")
+		a.NoError(err)
+		_, err = RemoveTimesheet(provider, session, "t-1")
+		a.NoError(err)
+		_, err = FindTimesheet(provider, session, "t-1")
+		a.NoError(err)
+
+		a.Equal([]recordedRequest{
+			{Method: "POST", Path: "/payroll.xro/2.0/Timesheets/t-1/Approve"},
+			{Method: "POST", Path: "/payroll.xro/2.0/Timesheets/t-1/RevertToDraft"},
+			{Method: "DELETE", Path: "/payroll.xro/2.0/Timesheets/t-1"},
+			{Method: "GET", Path: "/payroll.xro/2.0/Timesheets/t-1"},
+		}, *requests)
+	})
+}
+
+func Test_EachTimesheet(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	response := `{"pagination":{"page":1,"pageSize":100,"pageCount":1,"itemCount":2},"timesheets":[{"timesheetID":"t-1"},{"timesheetID":"t-2"}]}`
+	mockPayroll(response, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]recordedRequest) {
+		var timesheetIDs []string
+		err := EachTimesheet(context.Background(), provider, session, map[string]string{"filter": "employeeId==e-1"}, func(timesheet Timesheet) error {
+			timesheetIDs = append(timesheetIDs, timesheet.TimesheetID)
+			return nil
+		})
+		a.NoError(err)
+		a.Equal([]string{"t-1", "t-2"}, timesheetIDs)
+		a.Len(*requests, 1)
+		a.Equal("filter=employeeId%3D%3De-1&page=1", (*requests)[0].Query)
+	})
+}
+
+func Test_TimesheetLine_ZeroUnits(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	//a line without units is left out of the body in full, Xero reads a missing value as zero
+	body, err := json.Marshal(TimesheetLine{EarningsRateID: "r-1"})
+	a.NoError(err)
+	a.Equal(`{"earningsRateID":"r-1"}`, string(body))
+}
@@ -0,0 +1,16 @@
+package payroll
+
+import "github.com/opensimsim/xerogolang"
+
+//LeaveAccrualLine is the leave an employee accrues in a pay run, on a payslip
+type LeaveAccrualLine struct {
+
+	// Xero identifier for the Leave type (see PayItems)
+	LeaveTypeID string `json:"LeaveTypeID,omitempty" xml:"LeaveTypeID,omitempty"`
+
+	// Leave Accrual number of units
+	NumberOfUnits xerogolang.Decimal `json:"NumberOfUnits,omitzero" xml:"NumberOfUnits,omitempty"`
+
+	// If you want to auto calculate leave
+	AutoCalculate bool `json:"AutoCalculate,omitempty" xml:"AutoCalculate,omitempty"`
+}
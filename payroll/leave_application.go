@@ -0,0 +1,183 @@
+package payroll
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"time"
+
+	"github.com/markbates/goth"
+	"github.com/opensimsim/xerogolang"
+)
+
+//LeaveApplication is a request by an employee to take leave
+type LeaveApplication struct {
+
+	// The Xero identifier for the leave application
+	LeaveApplicationID string `json:"LeaveApplicationID,omitempty" xml:"LeaveApplicationID,omitempty"`
+
+	// The Xero identifier for the employee taking leave
+	EmployeeID string `json:"EmployeeID,omitempty" xml:"EmployeeID,omitempty"`
+
+	// The Xero identifier for the leave type (see PayItems)
+	LeaveTypeID string `json:"LeaveTypeID,omitempty" xml:"LeaveTypeID,omitempty"`
+
+	// The title of the leave (max length = 50)
+	Title string `json:"Title,omitempty" xml:"Title,omitempty"`
+
+	// Start date of the leave (YYYY-MM-DD)
+	StartDate xerogolang.Date `json:"StartDate,omitzero" xml:"StartDate,omitempty"`
+
+	// End date of the leave (YYYY-MM-DD)
+	EndDate xerogolang.Date `json:"EndDate,omitzero" xml:"EndDate,omitempty"`
+
+	// The Description of the Leave (max length = 200)
+	Description string `json:"Description,omitempty" xml:"Description,omitempty"`
+
+	// The leave taken in each pay period. Xero works them out from StartDate and EndDate if they are not given
+	LeavePeriods *[]LeavePeriod `json:"LeavePeriods,omitempty" xml:"LeavePeriods>LeavePeriod,omitempty"`
+
+	// Last modified timestamp
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"-"`
+
+	// Why the leave application could not be saved
+	ValidationErrors []xerogolang.ValidationError `json:"ValidationErrors,omitempty" xml:"-"`
+}
+
+//LeaveApplications contains a collection of LeaveApplications
+type LeaveApplications struct {
+	LeaveApplications []LeaveApplication `json:"LeaveApplications" xml:"LeaveApplication"`
+}
+
+func unmarshalLeaveApplication(leaveApplicationResponseBytes []byte) (*LeaveApplications, error) {
+	var leaveApplicationResponse *LeaveApplications
+	err := json.Unmarshal(leaveApplicationResponseBytes, &leaveApplicationResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return leaveApplicationResponse, err
+}
+
+//Create will create LeaveApplications given a LeaveApplications struct
+func (l *LeaveApplications) Create(provider *xerogolang.Provider, session goth.Session) (*LeaveApplications, error) {
+	return l.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (l *LeaveApplications) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*LeaveApplications, error) {
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/xml",
+	}
+
+	body, err := xml.MarshalIndent(l, "  ", "   ")
+	if err != nil {
+		return nil, err
+	}
+
+	leaveApplicationResponseBytes, err := provider.UpdateWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "LeaveApplications", additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalLeaveApplication(leaveApplicationResponseBytes)
+}
+
+//Update will update a LeaveApplication given a LeaveApplications struct
+//This will only handle single LeaveApplication - you cannot update multiple LeaveApplications in a single call
+func (l *LeaveApplications) Update(provider *xerogolang.Provider, session goth.Session) (*LeaveApplications, error) {
+	return l.UpdateCtx(context.Background(), provider, session)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (l *LeaveApplications) UpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*LeaveApplications, error) {
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/xml",
+	}
+
+	body, err := xml.MarshalIndent(l, "  ", "   ")
+	if err != nil {
+		return nil, err
+	}
+
+	leaveApplicationResponseBytes, err := provider.UpdateWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "LeaveApplications/"+l.LeaveApplications[0].LeaveApplicationID, additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalLeaveApplication(leaveApplicationResponseBytes)
+}
+
+//FindLeaveApplicationsModifiedSince will get all LeaveApplications modified after a specified date.
+//additional querystringParameters such as where, page, order can be added as a map
+func FindLeaveApplicationsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*LeaveApplications, error) {
+	return FindLeaveApplicationsModifiedSinceCtx(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindLeaveApplicationsModifiedSinceCtx is FindLeaveApplicationsModifiedSince with a context that can cancel the request or set its deadline
+func FindLeaveApplicationsModifiedSinceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*LeaveApplications, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	if !modifiedSince.Equal(dayZero) {
+		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
+	}
+
+	leaveApplicationResponseBytes, err := provider.FindWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "LeaveApplications", additionalHeaders, querystringParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalLeaveApplication(leaveApplicationResponseBytes)
+}
+
+//FindLeaveApplications will get all LeaveApplications.
+func FindLeaveApplications(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*LeaveApplications, error) {
+	return FindLeaveApplicationsCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindLeaveApplicationsCtx is FindLeaveApplications with a context that can cancel the request or set its deadline
+func FindLeaveApplicationsCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*LeaveApplications, error) {
+	return FindLeaveApplicationsModifiedSinceCtx(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//EachLeaveApplication calls fn with every leave application modified since modifiedSince, fetching them 100 at a time
+//until Xero returns an empty page. Pass a zero time.Time to get all leave applications. where and order
+//querystringParameters are sent with every page. Return xerogolang.ErrStop from fn to stop early
+func EachLeaveApplication(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string, fn func(leaveApplication LeaveApplication) error) error {
+	return xerogolang.EachPage(ctx, querystringParameters, func(pageParameters map[string]string) (int, error) {
+		leaveApplications, err := FindLeaveApplicationsModifiedSinceCtx(ctx, provider, session, modifiedSince, pageParameters)
+		if err != nil {
+			return 0, err
+		}
+		for _, leaveApplication := range leaveApplications.LeaveApplications {
+			err = fn(leaveApplication)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return len(leaveApplications.LeaveApplications), nil
+	})
+}
+
+//FindLeaveApplication will get a single LeaveApplication
+func FindLeaveApplication(provider *xerogolang.Provider, session goth.Session, leaveApplicationID string) (*LeaveApplications, error) {
+	return FindLeaveApplicationCtx(context.Background(), provider, session, leaveApplicationID)
+}
+
+//FindLeaveApplicationCtx is FindLeaveApplication with a context that can cancel the request or set its deadline
+func FindLeaveApplicationCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, leaveApplicationID string) (*LeaveApplications, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	leaveApplicationResponseBytes, err := provider.FindWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "LeaveApplications/"+leaveApplicationID, additionalHeaders, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalLeaveApplication(leaveApplicationResponseBytes)
+}
@@ -0,0 +1,19 @@
+package payroll
+
+import "github.com/opensimsim/xerogolang"
+
+//LeaveBalance is how much of a type of leave an employee has available
+type LeaveBalance struct {
+
+	// The name of the leave type
+	LeaveName string `json:"LeaveName,omitempty" xml:"LeaveName,omitempty"`
+
+	// Identifier of the leave type (see PayItems)
+	LeaveTypeID string `json:"LeaveTypeID,omitempty" xml:"LeaveTypeID,omitempty"`
+
+	// The balance of the leave available
+	NumberOfUnits xerogolang.Decimal `json:"NumberOfUnits,omitzero" xml:"NumberOfUnits,omitempty"`
+
+	// The type of units as specified by the LeaveType (see PayItems)
+	TypeOfUnits string `json:"TypeOfUnits,omitempty" xml:"TypeOfUnits,omitempty"`
+}
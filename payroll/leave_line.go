@@ -0,0 +1,31 @@
+package payroll
+
+import "github.com/opensimsim/xerogolang"
+
+//LeaveLine is how an employee accrues a type of leave, on their pay template
+type LeaveLine struct {
+
+	// Xero leave type identifier (see PayItems)
+	LeaveTypeID string `json:"LeaveTypeID,omitempty" xml:"LeaveTypeID,omitempty"`
+
+	// FIXEDAMOUNTEACHPERIOD, ENTERRATEINPAYTEMPLATE, BASEDONORDINARYEARNINGS or NOCALCULATIONREQUIRED
+	CalculationType string `json:"CalculationType,omitempty" xml:"CalculationType,omitempty"`
+
+	// PAYOUT or NOTPAIDOUT - how the balance is treated when the employee is terminated
+	EntitlementFinalPayPayoutType string `json:"EntitlementFinalPayPayoutType,omitempty" xml:"EntitlementFinalPayPayoutType,omitempty"`
+
+	// O or R - the employment termination payment type
+	EmploymentTerminationPaymentType string `json:"EmploymentTerminationPaymentType,omitempty" xml:"EmploymentTerminationPaymentType,omitempty"`
+
+	// Whether super guarantee is paid on the leave when it is paid out
+	IncludeSuperannuationGuaranteeContribution bool `json:"IncludeSuperannuationGuaranteeContribution,omitempty" xml:"IncludeSuperannuationGuaranteeContribution,omitempty"`
+
+	// Number of units accrued each pay period when CalculationType is FIXEDAMOUNTEACHPERIOD
+	NumberOfUnits xerogolang.Decimal `json:"NumberOfUnits,omitzero" xml:"NumberOfUnits,omitempty"`
+
+	// Hours of leave accrued each year when CalculationType is ENTERRATEINPAYTEMPLATE
+	AnnualNumberOfUnits xerogolang.Decimal `json:"AnnualNumberOfUnits,omitzero" xml:"AnnualNumberOfUnits,omitempty"`
+
+	// Normal ordinary earnings number of units for the leave line when CalculationType is ENTERRATEINPAYTEMPLATE
+	FullTimeNumberOfUnitsPerPeriod xerogolang.Decimal `json:"FullTimeNumberOfUnitsPerPeriod,omitzero" xml:"FullTimeNumberOfUnitsPerPeriod,omitempty"`
+}
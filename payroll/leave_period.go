@@ -0,0 +1,19 @@
+package payroll
+
+import "github.com/opensimsim/xerogolang"
+
+//LeavePeriod is the part of a leave application that falls in one pay period
+type LeavePeriod struct {
+
+	// The Number of Units for the leave
+	NumberOfUnits xerogolang.Decimal `json:"NumberOfUnits,omitzero" xml:"NumberOfUnits,omitempty"`
+
+	// The Pay Period Start Date (YYYY-MM-DD)
+	PayPeriodStartDate xerogolang.Date `json:"PayPeriodStartDate,omitzero" xml:"PayPeriodStartDate,omitempty"`
+
+	// The Pay Period End Date (YYYY-MM-DD)
+	PayPeriodEndDate xerogolang.Date `json:"PayPeriodEndDate,omitzero" xml:"PayPeriodEndDate,omitempty"`
+
+	// SCHEDULED or PROCESSED
+	LeavePeriodStatus string `json:"LeavePeriodStatus,omitempty" xml:"-"`
+}
@@ -0,0 +1,34 @@
+package payroll
+
+import "github.com/opensimsim/xerogolang"
+
+//LeaveType is a type of leave an employee can accrue and take, such as annual or personal leave
+type LeaveType struct {
+
+	// Xero identifier
+	LeaveTypeID string `json:"LeaveTypeID,omitempty" xml:"LeaveTypeID,omitempty"`
+
+	// Name of the leave type (max length = 50)
+	Name string `json:"Name,omitempty" xml:"Name,omitempty"`
+
+	// The type of units by which leave entitlements are normally tracked e.g. Hours
+	TypeOfUnits string `json:"TypeOfUnits,omitempty" xml:"TypeOfUnits,omitempty"`
+
+	// The number of units the employee is entitled to each year
+	NormalEntitlement xerogolang.Decimal `json:"NormalEntitlement,omitzero" xml:"NormalEntitlement,omitempty"`
+
+	// Enter an amount here if your organisation pays an additional percentage on top of ordinary earnings when your employees take leave (typically 17.5%)
+	LeaveLoadingRate xerogolang.Decimal `json:"LeaveLoadingRate,omitzero" xml:"LeaveLoadingRate,omitempty"`
+
+	// Set this to indicate that an employee will be paid when taking this type of leave
+	IsPaidLeave bool `json:"IsPaidLeave,omitempty" xml:"IsPaidLeave,omitempty"`
+
+	// Set this if you want a balance for this leave type to be shown on your employee’s payslips
+	ShowOnPayslip bool `json:"ShowOnPayslip,omitempty" xml:"ShowOnPayslip,omitempty"`
+
+	// Is the current record
+	CurrentRecord bool `json:"CurrentRecord,omitempty" xml:"CurrentRecord,omitempty"`
+
+	// Last modified timestamp
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"-"`
+}
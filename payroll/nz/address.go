@@ -0,0 +1,23 @@
+package nz
+
+//Address is the home address of an Employee
+type Address struct {
+
+	// Address line 1 for employee home address
+	AddressLine1 string `json:"addressLine1,omitempty"`
+
+	// Address line 2 for employee home address
+	AddressLine2 string `json:"addressLine2,omitempty"`
+
+	// Town or city for employee home address
+	City string `json:"city,omitempty"`
+
+	// Suburb for employee home address
+	Suburb string `json:"suburb,omitempty"`
+
+	// PostCode for employee home address
+	PostCode string `json:"postCode,omitempty"`
+
+	// Country of HomeAddress
+	CountryName string `json:"countryName,omitempty"`
+}
@@ -0,0 +1,150 @@
+//Package nz is a client for the NZ Payroll API (payroll.xro/2.0). Unlike the AU API in the payroll
+//package it speaks JSON, pages with a pagination summary and reports errors as problems, which come
+//back as a *xerogolang.APIError. Requests go to provider.Endpoints.PayrollV2. Timesheets and leave
+//work the same way as in the uk package
+package nz
+
+import (
+	"context"
+
+	"github.com/markbates/goth"
+	"github.com/opensimsim/xerogolang"
+	"github.com/opensimsim/xerogolang/payroll/internal/payrollv2"
+)
+
+//Employee is a person paid through Xero Payroll
+type Employee struct {
+
+	// Xero unique identifier for the employee
+	EmployeeID string `json:"employeeID,omitempty"`
+
+	// Title of the employee
+	Title string `json:"title,omitempty"`
+
+	// First name of employee
+	FirstName string `json:"firstName,omitempty"`
+
+	// Last name of employee
+	LastName string `json:"lastName,omitempty"`
+
+	// Date of birth of the employee (YYYY-MM-DD)
+	DateOfBirth xerogolang.Date `json:"dateOfBirth,omitzero"`
+
+	// The employee's home address
+	Address *Address `json:"address,omitempty"`
+
+	// The email address for the employee
+	Email string `json:"email,omitempty"`
+
+	// The employee’s gender
+	Gender Gender `json:"gender,omitempty"`
+
+	// Employee phone number
+	PhoneNumber string `json:"phoneNumber,omitempty"`
+
+	// Employment start date of the employee at the time it was requested
+	StartDate xerogolang.Date `json:"startDate,omitzero"`
+
+	// Employment end date of the employee at the time it was requested
+	EndDate xerogolang.Date `json:"endDate,omitzero"`
+
+	// Xero unique identifier for the payroll calendar of the employee
+	PayrollCalendarID string `json:"payrollCalendarID,omitempty"`
+
+	// The employee's job title
+	JobTitle string `json:"jobTitle,omitempty"`
+
+	// UTC timestamp of last update to the employee
+	UpdatedDateUTC xerogolang.DateTime `json:"updatedDateUTC,omitzero"`
+
+	// UTC timestamp when the employee was created in Xero
+	CreatedDateUTC xerogolang.DateTime `json:"createdDateUTC,omitzero"`
+}
+
+//Employees contains a page of Employees
+type Employees struct {
+	Pagination *xerogolang.Pagination `json:"pagination,omitempty"`
+	Employees  []Employee             `json:"employees"`
+}
+
+func unmarshalEmployee(employeeResponseBytes []byte) (*Employees, error) {
+	pagination, employees, err := payrollv2.Unmarshal[Employee](employeeResponseBytes, "employee", "employees")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Employees{Pagination: pagination, Employees: employees}, nil
+}
+
+//Create will create an Employee
+func (e *Employee) Create(provider *xerogolang.Provider, session goth.Session) (*Employees, error) {
+	return e.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (e *Employee) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Employees, error) {
+	employeeResponseBytes, err := payrollv2.Post(ctx, provider, session, "Employees", e)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalEmployee(employeeResponseBytes)
+}
+
+//Update will update the Employee with e's EmployeeID
+func (e *Employee) Update(provider *xerogolang.Provider, session goth.Session) (*Employees, error) {
+	return e.UpdateCtx(context.Background(), provider, session)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (e *Employee) UpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Employees, error) {
+	employeeResponseBytes, err := payrollv2.Put(ctx, provider, session, "Employees/"+e.EmployeeID, e)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalEmployee(employeeResponseBytes)
+}
+
+//FindEmployees will get a page of Employees.
+//additional querystringParameters such as page and filter can be added as a map
+func FindEmployees(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Employees, error) {
+	return FindEmployeesCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindEmployeesCtx is FindEmployees with a context that can cancel the request or set its deadline
+func FindEmployeesCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Employees, error) {
+	employeeResponseBytes, err := payrollv2.Get(ctx, provider, session, "Employees", querystringParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalEmployee(employeeResponseBytes)
+}
+
+//EachEmployee calls fn with every employee, a page at a time, until the last page. A filter
+//querystringParameter is sent with every page. Return xerogolang.ErrStop from fn to stop early
+func EachEmployee(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string, fn func(employee Employee) error) error {
+	return payrollv2.Each(ctx, querystringParameters, func(pageParameters map[string]string) (*xerogolang.Pagination, []Employee, error) {
+		employees, err := FindEmployeesCtx(ctx, provider, session, pageParameters)
+		if err != nil {
+			return nil, nil, err
+		}
+		return employees.Pagination, employees.Employees, nil
+	}, fn)
+}
+
+//FindEmployee will get a single Employee
+func FindEmployee(provider *xerogolang.Provider, session goth.Session, employeeID string) (*Employees, error) {
+	return FindEmployeeCtx(context.Background(), provider, session, employeeID)
+}
+
+//FindEmployeeCtx is FindEmployee with a context that can cancel the request or set its deadline
+func FindEmployeeCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, employeeID string) (*Employees, error) {
+	employeeResponseBytes, err := payrollv2.Get(ctx, provider, session, "Employees/"+employeeID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalEmployee(employeeResponseBytes)
+}
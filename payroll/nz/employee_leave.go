@@ -0,0 +1,30 @@
+package nz
+
+import "github.com/opensimsim/xerogolang/payroll/internal/payrollv2"
+
+//EmployeeLeave is leave an employee has taken or will take, split into the pay periods it falls in.
+//Save it for an employee using Create or Update and their Ctx variants
+type EmployeeLeave = payrollv2.EmployeeLeave
+
+//LeavePeriod is the leave an employee takes in one pay period of an EmployeeLeave
+type LeavePeriod = payrollv2.LeavePeriod
+
+//EmployeeLeaves contains the leave of one employee
+type EmployeeLeaves = payrollv2.EmployeeLeaves
+
+var (
+	//FindEmployeeLeaves will get all the leave of the employee with employeeID
+	FindEmployeeLeaves = payrollv2.FindEmployeeLeaves
+	//FindEmployeeLeavesCtx is FindEmployeeLeaves with a context that can cancel the request or set its deadline
+	FindEmployeeLeavesCtx = payrollv2.FindEmployeeLeavesCtx
+
+	//FindEmployeeLeave will get a single EmployeeLeave of the employee with employeeID
+	FindEmployeeLeave = payrollv2.FindEmployeeLeave
+	//FindEmployeeLeaveCtx is FindEmployeeLeave with a context that can cancel the request or set its deadline
+	FindEmployeeLeaveCtx = payrollv2.FindEmployeeLeaveCtx
+
+	//RemoveEmployeeLeave will delete a single EmployeeLeave of the employee with employeeID
+	RemoveEmployeeLeave = payrollv2.RemoveEmployeeLeave
+	//RemoveEmployeeLeaveCtx is RemoveEmployeeLeave with a context that can cancel the request or set its deadline
+	RemoveEmployeeLeaveCtx = payrollv2.RemoveEmployeeLeaveCtx
+)
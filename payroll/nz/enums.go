@@ -0,0 +1,89 @@
+package nz
+
+import "github.com/opensimsim/xerogolang/payroll/internal/payrollv2"
+
+//The types below are the fixed sets of codes the NZ Payroll API accepts. Encoding a value that
+//isn't one of the constants fails before the request is sent but any value is kept when decoding.
+//An empty value means the field has not been set
+
+//Gender is the Gender of an Employee
+type Gender string
+
+const (
+	GenderMale          Gender = "M"
+	GenderFemale        Gender = "F"
+	GenderIndeterminate Gender = "I"
+)
+
+//IsValid reports whether g is one of the Gender constants
+func (g Gender) IsValid() bool {
+	switch g {
+	case GenderMale, GenderFemale, GenderIndeterminate:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown genders
+func (g Gender) MarshalText() ([]byte, error) {
+	return payrollv2.MarshalEnum("Gender", string(g), g.IsValid())
+}
+
+//PayRunType is the PayRunType of a PayRun
+type PayRunType string
+
+const (
+	PayRunTypeScheduled   PayRunType = "Scheduled"
+	PayRunTypeUnscheduled PayRunType = "Unscheduled"
+)
+
+//IsValid reports whether t is one of the PayRunType constants
+func (t PayRunType) IsValid() bool {
+	switch t {
+	case PayRunTypeScheduled, PayRunTypeUnscheduled:
+		return true
+	}
+	return false
+}
+
+//MarshalText rejects unknown pay run types
+func (t PayRunType) MarshalText() ([]byte, error) {
+	return payrollv2.MarshalEnum("PayRunType", string(t), t.IsValid())
+}
+
+//CalendarType is how often the payroll calendar of a PayRun pays employees
+type CalendarType = payrollv2.CalendarType
+
+const (
+	CalendarTypeWeekly      = payrollv2.CalendarTypeWeekly
+	CalendarTypeFortnightly = payrollv2.CalendarTypeFortnightly
+	CalendarTypeFourWeekly  = payrollv2.CalendarTypeFourWeekly
+	CalendarTypeMonthly     = payrollv2.CalendarTypeMonthly
+	CalendarTypeAnnual      = payrollv2.CalendarTypeAnnual
+	CalendarTypeQuarterly   = payrollv2.CalendarTypeQuarterly
+)
+
+//PayRunStatus is the PayRunStatus of a PayRun
+type PayRunStatus = payrollv2.PayRunStatus
+
+const (
+	PayRunStatusDraft  = payrollv2.PayRunStatusDraft
+	PayRunStatusPosted = payrollv2.PayRunStatusPosted
+)
+
+//TimesheetStatus is the Status of a Timesheet
+type TimesheetStatus = payrollv2.TimesheetStatus
+
+const (
+	TimesheetStatusDraft     = payrollv2.TimesheetStatusDraft
+	TimesheetStatusApproved  = payrollv2.TimesheetStatusApproved
+	TimesheetStatusCompleted = payrollv2.TimesheetStatusCompleted
+)
+
+//LeavePeriodStatus is the PeriodStatus of a LeavePeriod
+type LeavePeriodStatus = payrollv2.LeavePeriodStatus
+
+const (
+	LeavePeriodStatusApproved  = payrollv2.LeavePeriodStatusApproved
+	LeavePeriodStatusCompleted = payrollv2.LeavePeriodStatusCompleted
+)
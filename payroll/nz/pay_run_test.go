@@ -0,0 +1,63 @@
+package nz
+
+import (
+	"testing"
+
+	"github.com/opensimsim/xerogolang"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PayRun_Create(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	response := `{"payRun":{"payRunID":"p-1","payRunStatus":"Draft","payRunType":"Scheduled","calendarType":"Fortnightly","totalPay":1234.56,"paySlips":[{"paySlipID":"s-1","totalStatutoryDeductions":100,"totalSuperannuation":37.04}]}}`
+	mockPayroll(response, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		payRun := &PayRun{PayrollCalendarID: "c-1", PayRunType: PayRunTypeScheduled}
+
+		payRuns, err := payRun.Create(provider, session)
+		a.NoError(err)
+		created := payRuns.PayRuns[0]
+		a.Equal(PayRunStatusDraft, created.PayRunStatus)
+		a.Equal(CalendarTypeFortnightly, created.CalendarType)
+		a.Equal("1234.56", created.TotalPay.String())
+		a.Equal("100", created.PaySlips[0].TotalStatutoryDeductions.String())
+		a.Equal("37.04", created.PaySlips[0].TotalSuperannuation.String())
+
+		//the unset totals and dates are left out rather than sent as zeros and nulls
+		a.Equal([]string{`POST /PayRuns {"payrollCalendarID":"c-1","payRunType":"Scheduled"}`}, *requests)
+	})
+}
+
+func Test_PayRun_InvalidType(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockPayroll(`{}`, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		//earlier year updates are only reported to HMRC in the UK
+		payRun := &PayRun{PayrollCalendarID: "c-1", PayRunType: "EarlierYearUpdate"}
+		_, err := payRun.Create(provider, session)
+		a.Error(err)
+		a.Empty(*requests)
+	})
+}
+
+func Test_EmployeeLeave_Reexported(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockPayroll(`{"leave":[{"leaveID":"l-1","periods":[{"numberOfUnits":8,"periodStatus":"Completed"}]}]}`, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		leaves, err := FindEmployeeLeaves(provider, session, "e-1")
+		a.NoError(err)
+		a.Equal([]LeavePeriod{{NumberOfUnits: xerogolang.MustParseDecimal("8"), PeriodStatus: LeavePeriodStatusCompleted}}, leaves.Leave[0].Periods)
+
+		leave := &EmployeeLeave{LeaveTypeID: "lt-1"}
+		_, err = leave.Create(provider, session, "e-1")
+		a.NoError(err)
+
+		a.Equal([]string{
+			`GET /Employees/e-1/Leave `,
+			`POST /Employees/e-1/Leave {"leaveTypeID":"lt-1"}`,
+		}, *requests)
+	})
+}
@@ -0,0 +1,45 @@
+package nz
+
+import "github.com/opensimsim/xerogolang/payroll/internal/payrollv2"
+
+//Timesheet is the time an employee worked in one pay period, which is paid once it is approved.
+//Create it with its TimesheetLines using Create or CreateCtx
+type Timesheet = payrollv2.Timesheet
+
+//TimesheetLine is the units worked at one earnings rate on one day of a Timesheet
+type TimesheetLine = payrollv2.TimesheetLine
+
+//Timesheets contains a page of Timesheets
+type Timesheets = payrollv2.Timesheets
+
+var (
+	//ApproveTimesheet will approve a draft Timesheet so it is paid in the next pay run
+	ApproveTimesheet = payrollv2.ApproveTimesheet
+	//ApproveTimesheetCtx is ApproveTimesheet with a context that can cancel the request or set its deadline
+	ApproveTimesheetCtx = payrollv2.ApproveTimesheetCtx
+
+	//RevertTimesheet will move an approved Timesheet back to draft so it can be changed
+	RevertTimesheet = payrollv2.RevertTimesheet
+	//RevertTimesheetCtx is RevertTimesheet with a context that can cancel the request or set its deadline
+	RevertTimesheetCtx = payrollv2.RevertTimesheetCtx
+
+	//RemoveTimesheet will delete a draft Timesheet
+	RemoveTimesheet = payrollv2.RemoveTimesheet
+	//RemoveTimesheetCtx is RemoveTimesheet with a context that can cancel the request or set its deadline
+	RemoveTimesheetCtx = payrollv2.RemoveTimesheetCtx
+
+	//FindTimesheets will get a page of Timesheets.
+	//additional querystringParameters such as page and filter (e.g. employeeId==...) can be added as a map
+	FindTimesheets = payrollv2.FindTimesheets
+	//FindTimesheetsCtx is FindTimesheets with a context that can cancel the request or set its deadline
+	FindTimesheetsCtx = payrollv2.FindTimesheetsCtx
+
+	//EachTimesheet calls fn with every timesheet, a page at a time, until the last page. A filter
+	//querystringParameter is sent with every page. Return xerogolang.ErrStop from fn to stop early
+	EachTimesheet = payrollv2.EachTimesheet
+
+	//FindTimesheet will get a single Timesheet along with its TimesheetLines
+	FindTimesheet = payrollv2.FindTimesheet
+	//FindTimesheetCtx is FindTimesheet with a context that can cancel the request or set its deadline
+	FindTimesheetCtx = payrollv2.FindTimesheetCtx
+)
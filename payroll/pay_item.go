@@ -0,0 +1,120 @@
+package payroll
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"time"
+
+	"github.com/markbates/goth"
+	"github.com/opensimsim/xerogolang"
+)
+
+//PayItems are the earnings rates, deduction types, leave types and reimbursement types an organisation pays employees with
+type PayItems struct {
+
+	// See EarningsRates
+	EarningsRates *[]EarningsRate `json:"EarningsRates,omitempty" xml:"EarningsRates>EarningsRate,omitempty"`
+
+	// See DeductionTypes
+	DeductionTypes *[]DeductionType `json:"DeductionTypes,omitempty" xml:"DeductionTypes>DeductionType,omitempty"`
+
+	// See LeaveTypes
+	LeaveTypes *[]LeaveType `json:"LeaveTypes,omitempty" xml:"LeaveTypes>LeaveType,omitempty"`
+
+	// See ReimbursementTypes
+	ReimbursementTypes *[]ReimbursementType `json:"ReimbursementTypes,omitempty" xml:"ReimbursementTypes>ReimbursementType,omitempty"`
+}
+
+//payItemsResponse is how Xero wraps PayItems in a response
+type payItemsResponse struct {
+	PayItems *PayItems `json:"PayItems"`
+}
+
+func unmarshalPayItems(payItemsResponseBytes []byte) (*PayItems, error) {
+	var response *payItemsResponse
+	err := json.Unmarshal(payItemsResponseBytes, &response)
+	if err != nil {
+		return nil, err
+	}
+	if response == nil || response.PayItems == nil {
+		return &PayItems{}, nil
+	}
+
+	return response.PayItems, err
+}
+
+//Create will add the pay items given in a PayItems struct. Only the kinds of pay item that are set are sent
+func (p *PayItems) Create(provider *xerogolang.Provider, session goth.Session) (*PayItems, error) {
+	return p.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (p *PayItems) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*PayItems, error) {
+	return p.save(ctx, provider, session)
+}
+
+//Update will change the pay items given in a PayItems struct, matching them by their IDs.
+//Pay items of the same kind that are left out are deleted, unless they are in use
+func (p *PayItems) Update(provider *xerogolang.Provider, session goth.Session) (*PayItems, error) {
+	return p.UpdateCtx(context.Background(), provider, session)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (p *PayItems) UpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*PayItems, error) {
+	return p.save(ctx, provider, session)
+}
+
+//save posts the pay items as Xero creates and updates them through the same endpoint
+func (p *PayItems) save(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*PayItems, error) {
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/xml",
+	}
+
+	body, err := xml.MarshalIndent(p, "  ", "   ")
+	if err != nil {
+		return nil, err
+	}
+
+	payItemsResponseBytes, err := provider.UpdateWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "PayItems", additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalPayItems(payItemsResponseBytes)
+}
+
+//FindPayItemsModifiedSince will get the pay items modified after a specified date.
+//additional querystringParameters such as where and order can be added as a map
+func FindPayItemsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*PayItems, error) {
+	return FindPayItemsModifiedSinceCtx(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindPayItemsModifiedSinceCtx is FindPayItemsModifiedSince with a context that can cancel the request or set its deadline
+func FindPayItemsModifiedSinceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*PayItems, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	if !modifiedSince.Equal(dayZero) {
+		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
+	}
+
+	payItemsResponseBytes, err := provider.FindWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "PayItems", additionalHeaders, querystringParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalPayItems(payItemsResponseBytes)
+}
+
+//FindPayItems will get all the pay items of an organisation
+func FindPayItems(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*PayItems, error) {
+	return FindPayItemsCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindPayItemsCtx is FindPayItems with a context that can cancel the request or set its deadline
+func FindPayItemsCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*PayItems, error) {
+	return FindPayItemsModifiedSinceCtx(ctx, provider, session, dayZero, querystringParameters)
+}
@@ -0,0 +1,70 @@
+package payroll
+
+import (
+	"testing"
+
+	"github.com/opensimsim/xerogolang"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PayItems_Create(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	response := `{"PayItems":{
+		"EarningsRates":[{"EarningsRateID":"er-1","Name":"Overtime","RateType":"MULTIPLE","Multiplier":1.5}],
+		"LeaveTypes":[{"LeaveTypeID":"lt-1","Name":"Annual Leave","IsPaidLeave":true}]
+	}}`
+	mockPayroll([]string{response}, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		payItems := &PayItems{
+			EarningsRates: &[]EarningsRate{
+				{Name: "Overtime", AccountCode: "477", EarningsType: "OVERTIMEEARNINGS", RateType: "MULTIPLE", Multiplier: xerogolang.MustParseDecimal("1.5")},
+			},
+		}
+		saved, err := payItems.Create(provider, session)
+		a.NoError(err)
+
+		//the response is unwrapped from its PayItems element
+		a.Equal("er-1", (*saved.EarningsRates)[0].EarningsRateID)
+		a.Equal("1.5", (*saved.EarningsRates)[0].Multiplier.String())
+		a.True((*saved.LeaveTypes)[0].IsPaidLeave)
+		a.Nil(saved.DeductionTypes)
+
+		//only the kinds of pay item that are set are sent
+		a.Equal([]string{"POST /PayItems " + xmlBody(
+			"  <PayItems>",
+			"     <EarningsRates>",
+			"        <EarningsRate>",
+			"           <Name>Overtime</Name>",
+			"           <AccountCode>477</AccountCode>",
+			"           <EarningsType>OVERTIMEEARNINGS</EarningsType>",
+			"           <RateType>MULTIPLE</RateType>",
+			"           <Multiplier>1.5</Multiplier>",
+			"        </EarningsRate>",
+			"     </EarningsRates>",
+			"  </PayItems>",
+		)}, *requests)
+	})
+}
+
+func Test_FindPayItems(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	responses := []string{
+		`{"PayItems":{"DeductionTypes":[{"DeductionTypeID":"dt-1","Name":"Union fees"}]}}`,
+		`{}`,
+	}
+	mockPayroll(responses, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		payItems, err := FindPayItems(provider, session, nil)
+		a.NoError(err)
+		a.Equal("Union fees", (*payItems.DeductionTypes)[0].Name)
+
+		//a response without PayItems gives no pay items rather than nil
+		payItems, err = FindPayItems(provider, session, nil)
+		a.NoError(err)
+		a.Equal(&PayItems{}, payItems)
+
+		a.Equal([]string{"GET /PayItems ", "GET /PayItems "}, *requests)
+	})
+}
@@ -0,0 +1,220 @@
+package payroll
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"time"
+
+	"github.com/markbates/goth"
+	"github.com/opensimsim/xerogolang"
+)
+
+//PayRun pays the employees on a payroll calendar for one pay period
+type PayRun struct {
+
+	// Xero identifier for pay run
+	PayRunID string `json:"PayRunID,omitempty" xml:"PayRunID,omitempty"`
+
+	// Xero identifier for the payroll calendar the pay run is for
+	PayrollCalendarID string `json:"PayrollCalendarID,omitempty" xml:"PayrollCalendarID,omitempty"`
+
+	// Period Start Date for the PayRun (YYYY-MM-DD)
+	PayRunPeriodStartDate xerogolang.Date `json:"PayRunPeriodStartDate,omitzero" xml:"PayRunPeriodStartDate,omitempty"`
+
+	// Period End Date for the PayRun (YYYY-MM-DD)
+	PayRunPeriodEndDate xerogolang.Date `json:"PayRunPeriodEndDate,omitzero" xml:"PayRunPeriodEndDate,omitempty"`
+
+	// See PayRunStatus
+	PayRunStatus PayRunStatus `json:"PayRunStatus,omitempty" xml:"PayRunStatus,omitempty"`
+
+	// Payment Date for the PayRun (YYYY-MM-DD)
+	PaymentDate xerogolang.Date `json:"PaymentDate,omitzero" xml:"PaymentDate,omitempty"`
+
+	// Payslip message for the PayRun
+	PayslipMessage string `json:"PayslipMessage,omitempty" xml:"PayslipMessage,omitempty"`
+
+	// The payslips in the pay run, without their lines. See FindPayslip
+	Payslips []Payslip `json:"Payslips,omitempty" xml:"-"`
+
+	// The total Wages for the Payrun
+	Wages xerogolang.Decimal `json:"Wages,omitzero" xml:"-"`
+
+	// The total Deductions for the Payrun
+	Deductions xerogolang.Decimal `json:"Deductions,omitzero" xml:"-"`
+
+	// The total Tax for the Payrun
+	Tax xerogolang.Decimal `json:"Tax,omitzero" xml:"-"`
+
+	// The total Super for the Payrun
+	Super xerogolang.Decimal `json:"Super,omitzero" xml:"-"`
+
+	// The total Reimbursements for the Payrun
+	Reimbursement xerogolang.Decimal `json:"Reimbursement,omitzero" xml:"-"`
+
+	// The total NetPay for the Payrun
+	NetPay xerogolang.Decimal `json:"NetPay,omitzero" xml:"-"`
+
+	// Last modified timestamp
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"-"`
+
+	// Why the pay run could not be saved
+	ValidationErrors []xerogolang.ValidationError `json:"ValidationErrors,omitempty" xml:"-"`
+}
+
+//PayRuns contains a collection of PayRuns
+type PayRuns struct {
+	PayRuns []PayRun `json:"PayRuns" xml:"PayRun"`
+}
+
+func unmarshalPayRun(payRunResponseBytes []byte) (*PayRuns, error) {
+	var payRunResponse *PayRuns
+	err := json.Unmarshal(payRunResponseBytes, &payRunResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return payRunResponse, err
+}
+
+//Create will create a draft PayRun for the next pay period of each PayrollCalendarID given in a PayRuns struct
+func (p *PayRuns) Create(provider *xerogolang.Provider, session goth.Session) (*PayRuns, error) {
+	return p.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (p *PayRuns) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*PayRuns, error) {
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/xml",
+	}
+
+	body, err := xml.MarshalIndent(p, "  ", "   ")
+	if err != nil {
+		return nil, err
+	}
+
+	payRunResponseBytes, err := provider.UpdateWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "PayRuns", additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalPayRun(payRunResponseBytes)
+}
+
+//Update will update a PayRun given a PayRuns struct, e.g. to change its PayslipMessage or to post it
+//This will only handle single PayRun - you cannot update multiple PayRuns in a single call
+func (p *PayRuns) Update(provider *xerogolang.Provider, session goth.Session) (*PayRuns, error) {
+	return p.UpdateCtx(context.Background(), provider, session)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (p *PayRuns) UpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*PayRuns, error) {
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/xml",
+	}
+
+	body, err := xml.MarshalIndent(p, "  ", "   ")
+	if err != nil {
+		return nil, err
+	}
+
+	payRunResponseBytes, err := provider.UpdateWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "PayRuns/"+p.PayRuns[0].PayRunID, additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalPayRun(payRunResponseBytes)
+}
+
+//PostPayRun will post a draft PayRun, paying its employees. A posted pay run cannot be changed
+func PostPayRun(provider *xerogolang.Provider, session goth.Session, payRunID string) (*PayRuns, error) {
+	return PostPayRunCtx(context.Background(), provider, session, payRunID)
+}
+
+//PostPayRunCtx is PostPayRun with a context that can cancel the request or set its deadline
+func PostPayRunCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, payRunID string) (*PayRuns, error) {
+	payRuns := &PayRuns{
+		PayRuns: []PayRun{
+			{
+				PayRunID:     payRunID,
+				PayRunStatus: PayRunStatusPosted,
+			},
+		},
+	}
+
+	return payRuns.UpdateCtx(ctx, provider, session)
+}
+
+//FindPayRunsModifiedSince will get all PayRuns modified after a specified date.
+//additional querystringParameters such as where, page, order can be added as a map
+func FindPayRunsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*PayRuns, error) {
+	return FindPayRunsModifiedSinceCtx(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindPayRunsModifiedSinceCtx is FindPayRunsModifiedSince with a context that can cancel the request or set its deadline
+func FindPayRunsModifiedSinceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*PayRuns, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	if !modifiedSince.Equal(dayZero) {
+		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
+	}
+
+	payRunResponseBytes, err := provider.FindWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "PayRuns", additionalHeaders, querystringParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalPayRun(payRunResponseBytes)
+}
+
+//FindPayRuns will get all PayRuns.
+func FindPayRuns(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*PayRuns, error) {
+	return FindPayRunsCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindPayRunsCtx is FindPayRuns with a context that can cancel the request or set its deadline
+func FindPayRunsCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*PayRuns, error) {
+	return FindPayRunsModifiedSinceCtx(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//EachPayRun calls fn with every pay run modified since modifiedSince, fetching them 100 at a time
+//until Xero returns an empty page. Pass a zero time.Time to get all pay runs. where and order
+//querystringParameters are sent with every page. Return xerogolang.ErrStop from fn to stop early
+func EachPayRun(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string, fn func(payRun PayRun) error) error {
+	return xerogolang.EachPage(ctx, querystringParameters, func(pageParameters map[string]string) (int, error) {
+		payRuns, err := FindPayRunsModifiedSinceCtx(ctx, provider, session, modifiedSince, pageParameters)
+		if err != nil {
+			return 0, err
+		}
+		for _, payRun := range payRuns.PayRuns {
+			err = fn(payRun)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return len(payRuns.PayRuns), nil
+	})
+}
+
+//FindPayRun will get a single PayRun along with a summary of each of its payslips
+func FindPayRun(provider *xerogolang.Provider, session goth.Session, payRunID string) (*PayRuns, error) {
+	return FindPayRunCtx(context.Background(), provider, session, payRunID)
+}
+
+//FindPayRunCtx is FindPayRun with a context that can cancel the request or set its deadline
+func FindPayRunCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, payRunID string) (*PayRuns, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	payRunResponseBytes, err := provider.FindWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "PayRuns/"+payRunID, additionalHeaders, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalPayRun(payRunResponseBytes)
+}
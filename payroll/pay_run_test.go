@@ -0,0 +1,80 @@
+package payroll
+
+import (
+	"testing"
+	"time"
+
+	"github.com/opensimsim/xerogolang"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PayRuns_Create(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	response := `{"PayRuns":[{"PayRunID":"pr-1","PayrollCalendarID":"pc-1","PayRunStatus":"DRAFT","PayRunPeriodStartDate":"2020-03-02T00:00:00","PayRunPeriodEndDate":"2020-03-08T00:00:00"}]}`
+	mockPayroll([]string{response}, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		payRuns := &PayRuns{PayRuns: []PayRun{{PayrollCalendarID: "pc-1"}}}
+		created, err := payRuns.Create(provider, session)
+		a.NoError(err)
+		a.Equal("pr-1", created.PayRuns[0].PayRunID)
+		a.Equal(PayRunStatusDraft, created.PayRuns[0].PayRunStatus)
+		a.Equal(xerogolang.NewDate(2020, time.March, 2), created.PayRuns[0].PayRunPeriodStartDate)
+		a.Equal(xerogolang.NewDate(2020, time.March, 8), created.PayRuns[0].PayRunPeriodEndDate)
+
+		//only the calendar is sent, the totals are left to Xero
+		a.Equal([]string{"POST /PayRuns " + xmlBody(
+			"  <PayRuns>",
+			"     <PayRun>",
+			"        <PayrollCalendarID>pc-1</PayrollCalendarID>",
+			"     </PayRun>",
+			"  </PayRuns>",
+		)}, *requests)
+	})
+}
+
+func Test_PostPayRun(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	response := `{"PayRuns":[{
+		"PayRunID":"pr-1",
+		"PayRunStatus":"POSTED",
+		"Wages":1500.00,
+		"Tax":300.50,
+		"NetPay":1199.50,
+		"Payslips":[{"EmployeeID":"e-1","PayslipID":"ps-1","FirstName":"Art","NetPay":1199.50}]
+	}]}`
+	mockPayroll([]string{response}, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		posted, err := PostPayRun(provider, session, "pr-1")
+		a.NoError(err)
+		payRun := posted.PayRuns[0]
+		a.Equal(PayRunStatusPosted, payRun.PayRunStatus)
+		a.Equal("1500.00", payRun.Wages.String())
+		a.Equal("300.50", payRun.Tax.String())
+		a.Len(payRun.Payslips, 1)
+		a.Equal("ps-1", payRun.Payslips[0].PayslipID)
+		a.Equal("1199.50", payRun.Payslips[0].NetPay.String())
+
+		a.Equal([]string{"POST /PayRuns/pr-1 " + xmlBody(
+			"  <PayRuns>",
+			"     <PayRun>",
+			"        <PayRunID>pr-1</PayRunID>",
+			"        <PayRunStatus>POSTED</PayRunStatus>",
+			"     </PayRun>",
+			"  </PayRuns>",
+		)}, *requests)
+	})
+}
+
+func Test_FindPayRuns(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockPayroll([]string{`{"PayRuns":[{"PayRunID":"pr-1"},{"PayRunID":"pr-2"}]}`}, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		payRuns, err := FindPayRuns(provider, session, map[string]string{"where": `PayRunStatus=="DRAFT"`})
+		a.NoError(err)
+		a.Len(payRuns.PayRuns, 2)
+		a.Equal([]string{"GET /PayRuns?where=PayRunStatus%3D%3D%22DRAFT%22 "}, *requests)
+	})
+}
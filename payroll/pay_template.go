@@ -0,0 +1,20 @@
+package payroll
+
+//PayTemplate is what an employee is paid, and what is deducted, in every pay run unless a payslip says otherwise
+type PayTemplate struct {
+
+	// See EarningsLines
+	EarningsLines *[]EarningsLine `json:"EarningsLines,omitempty" xml:"EarningsLines>EarningsLine,omitempty"`
+
+	// See DeductionLines
+	DeductionLines *[]DeductionLine `json:"DeductionLines,omitempty" xml:"DeductionLines>DeductionLine,omitempty"`
+
+	// See SuperLines
+	SuperLines *[]SuperLine `json:"SuperLines,omitempty" xml:"SuperLines>SuperLine,omitempty"`
+
+	// See ReimbursementLines
+	ReimbursementLines *[]ReimbursementLine `json:"ReimbursementLines,omitempty" xml:"ReimbursementLines>ReimbursementLine,omitempty"`
+
+	// See LeaveLines
+	LeaveLines *[]LeaveLine `json:"LeaveLines,omitempty" xml:"LeaveLines>LeaveLine,omitempty"`
+}
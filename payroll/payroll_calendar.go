@@ -0,0 +1,193 @@
+package payroll
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/markbates/goth"
+	"github.com/opensimsim/xerogolang"
+)
+
+//PayrollCalendar sets how often, and on which day, the employees on it are paid
+type PayrollCalendar struct {
+
+	// Xero identifier
+	PayrollCalendarID string `json:"PayrollCalendarID,omitempty" xml:"PayrollCalendarID,omitempty"`
+
+	// Name of the Payroll Calendar
+	Name string `json:"Name,omitempty" xml:"Name,omitempty"`
+
+	// See CalendarType
+	CalendarType CalendarType `json:"CalendarType,omitempty" xml:"CalendarType,omitempty"`
+
+	// The start date of the upcoming pay period. The end date will be calculated based upon this date, and the calendar type selected (YYYY-MM-DD)
+	StartDate xerogolang.Date `json:"StartDate,omitzero" xml:"StartDate,omitempty"`
+
+	// The date on which employees will be paid for the upcoming pay period (YYYY-MM-DD)
+	PaymentDate xerogolang.Date `json:"PaymentDate,omitzero" xml:"PaymentDate,omitempty"`
+
+	// Last modified timestamp
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"-"`
+
+	// Why the payroll calendar could not be saved
+	ValidationErrors []xerogolang.ValidationError `json:"ValidationErrors,omitempty" xml:"-"`
+}
+
+//PayrollCalendars contains a collection of PayrollCalendars
+type PayrollCalendars struct {
+	PayrollCalendars []PayrollCalendar `json:"PayrollCalendars" xml:"PayrollCalendar"`
+}
+
+func unmarshalPayrollCalendar(payrollCalendarResponseBytes []byte) (*PayrollCalendars, error) {
+	var payrollCalendarResponse *PayrollCalendars
+	err := json.Unmarshal(payrollCalendarResponseBytes, &payrollCalendarResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return payrollCalendarResponse, err
+}
+
+//PayPeriod returns the first and last day of the pay period that date falls in, counting whole periods
+//forwards or backwards from the calendar's StartDate. Twice monthly calendars pay the 1st to the 15th
+//and the 16th to the end of each month
+func (p *PayrollCalendar) PayPeriod(date time.Time) (time.Time, time.Time, error) {
+	day := truncateToDay(date)
+	origin := truncateToDay(p.StartDate.Time)
+	if p.StartDate.IsZero() && p.CalendarType != CalendarTypeTwiceMonthly {
+		return time.Time{}, time.Time{}, fmt.Errorf("payroll calendar %q has no StartDate", p.Name)
+	}
+
+	switch p.CalendarType {
+	case CalendarTypeWeekly, CalendarTypeFortnightly, CalendarTypeFourWeekly:
+		length := map[CalendarType]int{CalendarTypeWeekly: 7, CalendarTypeFortnightly: 14, CalendarTypeFourWeekly: 28}[p.CalendarType]
+		periods := floorDiv(int(day.Sub(origin).Hours()/24), length)
+		start := origin.AddDate(0, 0, periods*length)
+		return start, start.AddDate(0, 0, length-1), nil
+	case CalendarTypeMonthly, CalendarTypeQuarterly:
+		length := 1
+		if p.CalendarType == CalendarTypeQuarterly {
+			length = 3
+		}
+		months := (day.Year()-origin.Year())*12 + int(day.Month()-origin.Month())
+		periods := floorDiv(months, length)
+		for addMonths(origin, periods*length).After(day) {
+			periods--
+		}
+		for !addMonths(origin, (periods+1)*length).After(day) {
+			periods++
+		}
+		return addMonths(origin, periods*length), addMonths(origin, (periods+1)*length).AddDate(0, 0, -1), nil
+	case CalendarTypeTwiceMonthly:
+		if day.Day() <= 15 {
+			return day.AddDate(0, 0, 1-day.Day()), day.AddDate(0, 0, 15-day.Day()), nil
+		}
+		return day.AddDate(0, 0, 16-day.Day()), addMonths(day.AddDate(0, 0, 1-day.Day()), 1).AddDate(0, 0, -1), nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("payroll calendar %q has an unknown CalendarType %q", p.Name, p.CalendarType)
+}
+
+//truncateToDay drops the time of day and zone so that dates can be compared and counted in whole days
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+//addMonths moves t by months, keeping its day of the month unless that month is shorter
+func addMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, time.UTC)
+}
+
+//floorDiv divides rounding towards minus infinity, so days before a calendar's StartDate fall in earlier periods
+func floorDiv(a int, b int) int {
+	if a%b != 0 && (a < 0) != (b < 0) {
+		return a/b - 1
+	}
+	return a / b
+}
+
+//Create will create PayrollCalendars given a PayrollCalendars struct. Payroll calendars cannot be changed once they are created
+func (p *PayrollCalendars) Create(provider *xerogolang.Provider, session goth.Session) (*PayrollCalendars, error) {
+	return p.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (p *PayrollCalendars) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*PayrollCalendars, error) {
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/xml",
+	}
+
+	body, err := xml.MarshalIndent(p, "  ", "   ")
+	if err != nil {
+		return nil, err
+	}
+
+	payrollCalendarResponseBytes, err := provider.UpdateWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "PayrollCalendars", additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalPayrollCalendar(payrollCalendarResponseBytes)
+}
+
+//FindPayrollCalendarsModifiedSince will get all PayrollCalendars modified after a specified date.
+//additional querystringParameters such as where and order can be added as a map
+func FindPayrollCalendarsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*PayrollCalendars, error) {
+	return FindPayrollCalendarsModifiedSinceCtx(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindPayrollCalendarsModifiedSinceCtx is FindPayrollCalendarsModifiedSince with a context that can cancel the request or set its deadline
+func FindPayrollCalendarsModifiedSinceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*PayrollCalendars, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	if !modifiedSince.Equal(dayZero) {
+		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
+	}
+
+	payrollCalendarResponseBytes, err := provider.FindWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "PayrollCalendars", additionalHeaders, querystringParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalPayrollCalendar(payrollCalendarResponseBytes)
+}
+
+//FindPayrollCalendars will get all PayrollCalendars.
+func FindPayrollCalendars(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*PayrollCalendars, error) {
+	return FindPayrollCalendarsCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindPayrollCalendarsCtx is FindPayrollCalendars with a context that can cancel the request or set its deadline
+func FindPayrollCalendarsCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*PayrollCalendars, error) {
+	return FindPayrollCalendarsModifiedSinceCtx(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//FindPayrollCalendar will get a single PayrollCalendar
+func FindPayrollCalendar(provider *xerogolang.Provider, session goth.Session, payrollCalendarID string) (*PayrollCalendars, error) {
+	return FindPayrollCalendarCtx(context.Background(), provider, session, payrollCalendarID)
+}
+
+//FindPayrollCalendarCtx is FindPayrollCalendar with a context that can cancel the request or set its deadline
+func FindPayrollCalendarCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, payrollCalendarID string) (*PayrollCalendars, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	payrollCalendarResponseBytes, err := provider.FindWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "PayrollCalendars/"+payrollCalendarID, additionalHeaders, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalPayrollCalendar(payrollCalendarResponseBytes)
+}
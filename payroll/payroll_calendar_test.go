@@ -0,0 +1,113 @@
+package payroll
+
+import (
+	"testing"
+	"time"
+
+	"github.com/opensimsim/xerogolang"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func Test_PayPeriod(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	testCases := []struct {
+		name         string
+		calendarType CalendarType
+		startDate    time.Time
+		date         time.Time
+		start        time.Time
+		end          time.Time
+	}{
+		{"weekly on the start date", CalendarTypeWeekly, date(2020, 3, 2), date(2020, 3, 2), date(2020, 3, 2), date(2020, 3, 8)},
+		{"weekly on the last day", CalendarTypeWeekly, date(2020, 3, 2), date(2020, 3, 8), date(2020, 3, 2), date(2020, 3, 8)},
+		{"weekly later", CalendarTypeWeekly, date(2020, 3, 2), date(2020, 3, 25), date(2020, 3, 23), date(2020, 3, 29)},
+		{"weekly the day before the start", CalendarTypeWeekly, date(2020, 3, 2), date(2020, 3, 1), date(2020, 2, 24), date(2020, 3, 1)},
+		{"weekly before the start", CalendarTypeWeekly, date(2020, 3, 2), date(2020, 2, 20), date(2020, 2, 17), date(2020, 2, 23)},
+		{"fortnightly", CalendarTypeFortnightly, date(2020, 3, 2), date(2020, 3, 20), date(2020, 3, 16), date(2020, 3, 29)},
+		{"fortnightly before the start", CalendarTypeFortnightly, date(2020, 3, 2), date(2020, 2, 16), date(2020, 2, 3), date(2020, 2, 16)},
+		{"four weekly", CalendarTypeFourWeekly, date(2020, 3, 2), date(2020, 4, 1), date(2020, 3, 30), date(2020, 4, 26)},
+		{"four weekly before the start", CalendarTypeFourWeekly, date(2020, 3, 2), date(2020, 2, 3), date(2020, 2, 3), date(2020, 3, 1)},
+		{"monthly", CalendarTypeMonthly, date(2020, 1, 15), date(2020, 3, 20), date(2020, 3, 15), date(2020, 4, 14)},
+		{"monthly on the day before a period", CalendarTypeMonthly, date(2020, 1, 15), date(2020, 3, 14), date(2020, 2, 15), date(2020, 3, 14)},
+		{"monthly before the start", CalendarTypeMonthly, date(2020, 1, 15), date(2019, 12, 1), date(2019, 11, 15), date(2019, 12, 14)},
+		//a calendar starting on the 31st starts on the last day of shorter months
+		{"monthly from the 31st into February", CalendarTypeMonthly, date(2020, 1, 31), date(2020, 2, 10), date(2020, 1, 31), date(2020, 2, 28)},
+		{"monthly from the 31st after February", CalendarTypeMonthly, date(2020, 1, 31), date(2020, 2, 29), date(2020, 2, 29), date(2020, 3, 30)},
+		{"monthly from the 31st in April", CalendarTypeMonthly, date(2020, 1, 31), date(2020, 4, 30), date(2020, 4, 30), date(2020, 5, 30)},
+		{"monthly from the 31st before the start", CalendarTypeMonthly, date(2020, 1, 31), date(2019, 12, 15), date(2019, 11, 30), date(2019, 12, 30)},
+		{"quarterly", CalendarTypeQuarterly, date(2020, 1, 1), date(2020, 5, 20), date(2020, 4, 1), date(2020, 6, 30)},
+		{"quarterly before the start", CalendarTypeQuarterly, date(2020, 1, 1), date(2019, 12, 31), date(2019, 10, 1), date(2019, 12, 31)},
+		{"quarterly from the 31st", CalendarTypeQuarterly, date(2020, 1, 31), date(2020, 5, 1), date(2020, 4, 30), date(2020, 7, 30)},
+		{"twice monthly first half", CalendarTypeTwiceMonthly, time.Time{}, date(2020, 2, 15), date(2020, 2, 1), date(2020, 2, 15)},
+		{"twice monthly second half", CalendarTypeTwiceMonthly, time.Time{}, date(2020, 2, 16), date(2020, 2, 16), date(2020, 2, 29)},
+		{"twice monthly end of a long month", CalendarTypeTwiceMonthly, date(2020, 1, 1), date(2020, 1, 31), date(2020, 1, 16), date(2020, 1, 31)},
+	}
+
+	for _, testCase := range testCases {
+		calendar := &PayrollCalendar{Name: testCase.name, CalendarType: testCase.calendarType, StartDate: xerogolang.Date{Time: testCase.startDate}}
+		start, end, err := calendar.PayPeriod(testCase.date)
+		a.NoError(err, testCase.name)
+		a.Equal(testCase.start, start, testCase.name)
+		a.Equal(testCase.end, end, testCase.name)
+	}
+}
+
+func Test_PayPeriod_IgnoresTimeOfDay(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	auckland := time.FixedZone("NZDT", 13*60*60)
+	calendar := &PayrollCalendar{CalendarType: CalendarTypeWeekly, StartDate: xerogolang.Date{Time: time.Date(2020, 3, 2, 9, 0, 0, 0, auckland)}}
+	start, end, err := calendar.PayPeriod(time.Date(2020, 3, 8, 23, 59, 0, 0, auckland))
+	a.NoError(err)
+	a.Equal(date(2020, 3, 2), start)
+	a.Equal(date(2020, 3, 8), end)
+}
+
+func Test_PayPeriod_Errors(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	_, _, err := (&PayrollCalendar{Name: "Staff", CalendarType: CalendarTypeWeekly}).PayPeriod(date(2020, 3, 2))
+	a.EqualError(err, `payroll calendar "Staff" has no StartDate`)
+
+	_, _, err = (&PayrollCalendar{Name: "Staff", CalendarType: "DAILY", StartDate: xerogolang.NewDate(2020, 3, 2)}).PayPeriod(date(2020, 3, 2))
+	a.EqualError(err, `payroll calendar "Staff" has an unknown CalendarType "DAILY"`)
+}
+
+func Test_AddMonths(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	a.Equal(date(2020, 2, 29), addMonths(date(2020, 1, 31), 1))
+	a.Equal(date(2021, 2, 28), addMonths(date(2020, 1, 31), 13))
+	a.Equal(date(2020, 3, 31), addMonths(date(2020, 1, 31), 2))
+	a.Equal(date(2019, 11, 30), addMonths(date(2020, 1, 31), -2))
+	a.Equal(date(2019, 12, 15), addMonths(date(2020, 3, 15), -3))
+	a.Equal(date(2020, 1, 31), addMonths(date(2020, 1, 31), 0))
+}
+
+func Test_FloorDiv(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	testCases := []struct{ a, b, want int }{
+		{7, 7, 1},
+		{6, 7, 0},
+		{0, 7, 0},
+		{-1, 7, -1},
+		{-7, 7, -1},
+		{-8, 7, -2},
+		{8, -7, -2},
+		{-8, -7, 1},
+	}
+	for _, testCase := range testCases {
+		a.Equal(testCase.want, floorDiv(testCase.a, testCase.b), "%d/%d", testCase.a, testCase.b)
+	}
+}
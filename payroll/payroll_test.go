@@ -0,0 +1,36 @@
+package payroll
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/opensimsim/xerogolang"
+	"golang.org/x/oauth2"
+)
+
+//mockPayroll answers each request with the next of responses and records the method, path and body of each
+func mockPayroll(responses []string, f func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string)) {
+	var mutex sync.Mutex
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		uri := req.URL.EscapedPath()
+		if req.URL.RawQuery != "" {
+			uri += "?" + req.URL.Query().Encode()
+		}
+		mutex.Lock()
+		requests = append(requests, fmt.Sprintf("%s %s %s", req.Method, uri, body))
+		response := responses[len(requests)-1]
+		mutex.Unlock()
+		fmt.Fprint(res, response)
+	}))
+	defer ts.Close()
+
+	provider := xerogolang.NewOAuth2("CLIENT", "SECRET", "/foo")
+	provider.Endpoints = xerogolang.Endpoints{Payroll: ts.URL + "/"}
+	session := &xerogolang.Session{OAuth2Token: &oauth2.Token{AccessToken: "ACCESSTOKEN", RefreshToken: "REFRESHTOKEN", TokenType: "Bearer"}}
+	f(provider, session, &requests)
+}
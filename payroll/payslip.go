@@ -0,0 +1,139 @@
+package payroll
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+
+	"github.com/markbates/goth"
+	"github.com/opensimsim/xerogolang"
+)
+
+//Payslip is what an employee is paid in a pay run. The lines are only included when a single payslip is found
+type Payslip struct {
+
+	// The Xero identifier for an employee
+	EmployeeID string `json:"EmployeeID,omitempty" xml:"-"`
+
+	// Xero identifier for the payslip
+	PayslipID string `json:"PayslipID,omitempty" xml:"-"`
+
+	// First name of employee
+	FirstName string `json:"FirstName,omitempty" xml:"-"`
+
+	// Last name of employee
+	LastName string `json:"LastName,omitempty" xml:"-"`
+
+	// The Wages for the Payslip
+	Wages xerogolang.Decimal `json:"Wages,omitzero" xml:"-"`
+
+	// The Deductions for the Payslip
+	Deductions xerogolang.Decimal `json:"Deductions,omitzero" xml:"-"`
+
+	// The Tax for the Payslip
+	Tax xerogolang.Decimal `json:"Tax,omitzero" xml:"-"`
+
+	// The Super for the Payslip
+	Super xerogolang.Decimal `json:"Super,omitzero" xml:"-"`
+
+	// The Reimbursements for the Payslip
+	Reimbursements xerogolang.Decimal `json:"Reimbursements,omitzero" xml:"-"`
+
+	// The NetPay for the Payslip
+	NetPay xerogolang.Decimal `json:"NetPay,omitzero" xml:"-"`
+
+	// See EarningsLines
+	EarningsLines *[]EarningsLine `json:"EarningsLines,omitempty" xml:"EarningsLines>EarningsLine,omitempty"`
+
+	// The earnings for leave taken in the pay period
+	LeaveEarningsLines *[]EarningsLine `json:"LeaveEarningsLines,omitempty" xml:"LeaveEarningsLines>LeaveEarningsLine,omitempty"`
+
+	// The earnings from timesheets in the pay period
+	TimesheetEarningsLines *[]EarningsLine `json:"TimesheetEarningsLines,omitempty" xml:"TimesheetEarningsLines>TimesheetEarningsLine,omitempty"`
+
+	// See DeductionLines
+	DeductionLines *[]DeductionLine `json:"DeductionLines,omitempty" xml:"DeductionLines>DeductionLine,omitempty"`
+
+	// See LeaveAccrualLines
+	LeaveAccrualLines *[]LeaveAccrualLine `json:"LeaveAccrualLines,omitempty" xml:"LeaveAccrualLines>LeaveAccrualLine,omitempty"`
+
+	// See ReimbursementLines
+	ReimbursementLines *[]ReimbursementLine `json:"ReimbursementLines,omitempty" xml:"ReimbursementLines>ReimbursementLine,omitempty"`
+
+	// See SuperLines
+	SuperannuationLines *[]SuperLine `json:"SuperannuationLines,omitempty" xml:"SuperannuationLines>SuperannuationLine,omitempty"`
+
+	// See TaxLines
+	TaxLines *[]TaxLine `json:"TaxLines,omitempty" xml:"TaxLines>TaxLine,omitempty"`
+
+	// Last modified timestamp
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"-"`
+}
+
+//Payslips contains a collection of Payslips
+type Payslips struct {
+	Payslips []Payslip `json:"Payslips" xml:"Payslip"`
+}
+
+func unmarshalPayslip(payslipResponseBytes []byte) (*Payslips, error) {
+	//a single payslip is returned on its own rather than in a collection
+	var payslipResponse struct {
+		Payslip  *Payslip
+		Payslips []Payslip
+	}
+	err := json.Unmarshal(payslipResponseBytes, &payslipResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	payslips := &Payslips{Payslips: payslipResponse.Payslips}
+	if payslipResponse.Payslip != nil {
+		payslips.Payslips = append(payslips.Payslips, *payslipResponse.Payslip)
+	}
+	return payslips, err
+}
+
+//Update will change the lines of a payslip in a draft pay run given a Payslips struct. Only the kinds of line
+//that are set are changed. This will only handle single Payslip - you cannot update multiple Payslips in a single call
+func (p *Payslips) Update(provider *xerogolang.Provider, session goth.Session) (*Payslips, error) {
+	return p.UpdateCtx(context.Background(), provider, session)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (p *Payslips) UpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Payslips, error) {
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/xml",
+	}
+
+	body, err := xml.MarshalIndent(p, "  ", "   ")
+	if err != nil {
+		return nil, err
+	}
+
+	payslipResponseBytes, err := provider.UpdateWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "Payslip/"+p.Payslips[0].PayslipID, additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalPayslip(payslipResponseBytes)
+}
+
+//FindPayslip will get a single Payslip with all of its lines. The payslips of a pay run are listed by FindPayRun
+func FindPayslip(provider *xerogolang.Provider, session goth.Session, payslipID string) (*Payslips, error) {
+	return FindPayslipCtx(context.Background(), provider, session, payslipID)
+}
+
+//FindPayslipCtx is FindPayslip with a context that can cancel the request or set its deadline
+func FindPayslipCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, payslipID string) (*Payslips, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	payslipResponseBytes, err := provider.FindWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "Payslip/"+payslipID, additionalHeaders, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalPayslip(payslipResponseBytes)
+}
@@ -0,0 +1,86 @@
+package payroll
+
+import (
+	"testing"
+
+	"github.com/opensimsim/xerogolang"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UnmarshalPayslip(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	//a single payslip comes back on its own
+	payslips, err := unmarshalPayslip([]byte(`{"Payslip":{"PayslipID":"ps-1","NetPay":1199.5,"EarningsLines":[{"EarningsRateID":"er-1","NumberOfUnits":38}]}}`))
+	a.NoError(err)
+	a.Len(payslips.Payslips, 1)
+	a.Equal("ps-1", payslips.Payslips[0].PayslipID)
+	a.Equal("1199.5", payslips.Payslips[0].NetPay.String())
+	a.Equal("38", (*payslips.Payslips[0].EarningsLines)[0].NumberOfUnits.String())
+
+	//and several come back in a collection
+	payslips, err = unmarshalPayslip([]byte(`{"Payslips":[{"PayslipID":"ps-1"},{"PayslipID":"ps-2"}]}`))
+	a.NoError(err)
+	a.Len(payslips.Payslips, 2)
+	a.Equal("ps-2", payslips.Payslips[1].PayslipID)
+
+	payslips, err = unmarshalPayslip([]byte(`{}`))
+	a.NoError(err)
+	a.Empty(payslips.Payslips)
+
+	_, err = unmarshalPayslip([]byte(`{"Payslip":[`))
+	a.Error(err)
+}
+
+func Test_FindPayslip(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockPayroll([]string{`{"Payslip":{"PayslipID":"ps-1","EmployeeID":"e-1"}}`}, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		payslips, err := FindPayslip(provider, session, "ps-1")
+		a.NoError(err)
+		a.Equal("e-1", payslips.Payslips[0].EmployeeID)
+		a.Equal([]string{"GET /Payslip/ps-1 "}, *requests)
+	})
+}
+
+func Test_Payslips_Update(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockPayroll([]string{`{"Payslips":[{"PayslipID":"ps-1"}]}`}, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		payslips := &Payslips{Payslips: []Payslip{{
+			PayslipID: "ps-1",
+			EarningsLines: &[]EarningsLine{
+				{EarningsRateID: "er-1", NumberOfUnits: xerogolang.MustParseDecimal("7.5")},
+			},
+			DeductionLines: &[]DeductionLine{
+				{DeductionTypeID: "dt-1", CalculationType: "FIXEDAMOUNT", Amount: xerogolang.MustParseDecimal("20.00")},
+			},
+		}}}
+		_, err := payslips.Update(provider, session)
+		a.NoError(err)
+
+		//the PayslipID goes in the path, and only the lines that are set are sent
+		a.Equal([]string{"POST /Payslip/ps-1 " + xmlBody(
+			"  <Payslips>",
+			"     <Payslip>",
+			"        <EarningsLines>",
+			"           <EarningsLine>",
+			"              <EarningsRateID>er-1</EarningsRateID>",
+			"              <NumberOfUnits>7.5</NumberOfUnits>",
+			"           </EarningsLine>",
+			"        </EarningsLines>",
+			"        <DeductionLines>",
+			"           <DeductionLine>",
+			"              <DeductionTypeID>dt-1</DeductionTypeID>",
+			"              <CalculationType>FIXEDAMOUNT</CalculationType>",
+			"              <Amount>20.00</Amount>",
+			"           </DeductionLine>",
+			"        </DeductionLines>",
+			"     </Payslip>",
+			"  </Payslips>",
+		)}, *requests)
+	})
+}
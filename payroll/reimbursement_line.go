@@ -0,0 +1,19 @@
+package payroll
+
+import "github.com/opensimsim/xerogolang"
+
+//ReimbursementLine is an amount paid back to an employee for something they paid for, on a pay template or a payslip
+type ReimbursementLine struct {
+
+	// Xero reimbursement type identifier (see PayItems)
+	ReimbursementTypeID string `json:"ReimbursementTypeID,omitempty" xml:"ReimbursementTypeID,omitempty"`
+
+	// Reimbursement line description (max length 50)
+	Description string `json:"Description,omitempty" xml:"Description,omitempty"`
+
+	// Reimbursement type amount
+	Amount xerogolang.Decimal `json:"Amount,omitzero" xml:"Amount,omitempty"`
+
+	// Reimbursement expense account. For posted pay run you should be able to see expense account code
+	ExpenseAccount string `json:"ExpenseAccount,omitempty" xml:"ExpenseAccount,omitempty"`
+}
@@ -0,0 +1,22 @@
+package payroll
+
+import "github.com/opensimsim/xerogolang"
+
+//ReimbursementType is a type of expense an employee can be paid back for, such as travel
+type ReimbursementType struct {
+
+	// Xero identifier
+	ReimbursementTypeID string `json:"ReimbursementTypeID,omitempty" xml:"ReimbursementTypeID,omitempty"`
+
+	// Name of the reimbursement type (max length = 50)
+	Name string `json:"Name,omitempty" xml:"Name,omitempty"`
+
+	// See Accounts
+	AccountCode string `json:"AccountCode,omitempty" xml:"AccountCode,omitempty"`
+
+	// Is the current record
+	CurrentRecord bool `json:"CurrentRecord,omitempty" xml:"CurrentRecord,omitempty"`
+
+	// Last modified timestamp
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"-"`
+}
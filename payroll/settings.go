@@ -0,0 +1,95 @@
+package payroll
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/markbates/goth"
+	"github.com/opensimsim/xerogolang"
+)
+
+//Settings are the accounts and tracking categories an organisation's payroll uses
+type Settings struct {
+
+	// The accounts pay runs are posted to. See Account
+	Accounts []Account `json:"Accounts,omitempty"`
+
+	// The tracking categories employees and timesheets are grouped by
+	TrackingCategories TrackingCategories `json:"TrackingCategories,omitempty"`
+
+	// Number of days in the Payroll year
+	DaysInPayrollYear int `json:"DaysInPayrollYear,omitempty"`
+}
+
+//Account is an account in the chart of accounts that payroll posts to
+type Account struct {
+
+	// Xero identifier for accounts
+	AccountID string `json:"AccountID,omitempty"`
+
+	// BANK, PAYGLIABILITY, SUPERANNUATIONEXPENSE, SUPERANNUATIONLIABILITY or WAGESEXPENSE
+	Type string `json:"Type,omitempty"`
+
+	// Customer defined account code
+	Code string `json:"Code,omitempty"`
+
+	// Name of account
+	Name string `json:"Name,omitempty"`
+}
+
+//TrackingCategories are the tracking categories used for employee groups and timesheet categories
+type TrackingCategories struct {
+
+	// The tracking category an employee's EmployeeGroupName is an option of
+	EmployeeGroups TrackingCategory `json:"EmployeeGroups,omitempty"`
+
+	// The tracking category timesheet lines are assigned to
+	TimesheetCategories TrackingCategory `json:"TimesheetCategories,omitempty"`
+}
+
+//TrackingCategory is a tracking category in the Accounting API
+type TrackingCategory struct {
+
+	// The identifier for the tracking category
+	TrackingCategoryID string `json:"TrackingCategoryID,omitempty"`
+
+	// Name of the tracking category
+	TrackingCategoryName string `json:"TrackingCategoryName,omitempty"`
+}
+
+//settingsResponse is how Xero wraps Settings in a response
+type settingsResponse struct {
+	Settings *Settings `json:"Settings"`
+}
+
+func unmarshalSettings(settingsResponseBytes []byte) (*Settings, error) {
+	var response *settingsResponse
+	err := json.Unmarshal(settingsResponseBytes, &response)
+	if err != nil {
+		return nil, err
+	}
+	if response == nil || response.Settings == nil {
+		return &Settings{}, nil
+	}
+
+	return response.Settings, err
+}
+
+//FindSettings will get the payroll settings of an organisation. They can only be changed in Xero
+func FindSettings(provider *xerogolang.Provider, session goth.Session) (*Settings, error) {
+	return FindSettingsCtx(context.Background(), provider, session)
+}
+
+//FindSettingsCtx is FindSettings with a context that can cancel the request or set its deadline
+func FindSettingsCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Settings, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	settingsResponseBytes, err := provider.FindWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "Settings", additionalHeaders, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalSettings(settingsResponseBytes)
+}
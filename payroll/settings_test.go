@@ -0,0 +1,34 @@
+package payroll
+
+import (
+	"testing"
+
+	"github.com/opensimsim/xerogolang"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FindSettings(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	response := `{"Settings":{
+		"Accounts":[{"AccountID":"a-1","Type":"WAGESEXPENSE","Code":"477","Name":"Wages and Salaries"}],
+		"TrackingCategories":{"EmployeeGroups":{"TrackingCategoryID":"tc-1","TrackingCategoryName":"Region"}},
+		"DaysInPayrollYear":364
+	}}`
+	mockPayroll([]string{response, `{}`}, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		settings, err := FindSettings(provider, session)
+		a.NoError(err)
+		a.Equal(&Settings{
+			Accounts:           []Account{{AccountID: "a-1", Type: "WAGESEXPENSE", Code: "477", Name: "Wages and Salaries"}},
+			TrackingCategories: TrackingCategories{EmployeeGroups: TrackingCategory{TrackingCategoryID: "tc-1", TrackingCategoryName: "Region"}},
+			DaysInPayrollYear:  364,
+		}, settings)
+
+		settings, err = FindSettings(provider, session)
+		a.NoError(err)
+		a.Equal(&Settings{}, settings)
+
+		a.Equal([]string{"GET /Settings ", "GET /Settings "}, *requests)
+	})
+}
@@ -0,0 +1,173 @@
+package payroll
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"time"
+
+	"github.com/markbates/goth"
+	"github.com/opensimsim/xerogolang"
+)
+
+//SuperFund is a superannuation fund that employees' super contributions are paid into
+type SuperFund struct {
+
+	// Xero identifier for a super fund
+	SuperFundID string `json:"SuperFundID,omitempty" xml:"SuperFundID,omitempty"`
+
+	// See SuperFundType
+	Type SuperFundType `json:"Type,omitempty" xml:"Type,omitempty"`
+
+	// Name of the super fund
+	Name string `json:"Name,omitempty" xml:"Name,omitempty"`
+
+	// ABN of the self managed super fund
+	ABN string `json:"ABN,omitempty" xml:"ABN,omitempty"`
+
+	// The unique superannuation identifier of a regulated fund. See SuperFundProducts
+	USI string `json:"USI,omitempty" xml:"USI,omitempty"`
+
+	// Superannuation product identification number of a regulated fund, replaced by USI
+	SPIN string `json:"SPIN,omitempty" xml:"SPIN,omitempty"`
+
+	// BSB of the self managed super fund
+	BSB string `json:"BSB,omitempty" xml:"BSB,omitempty"`
+
+	// The account number for the self managed super fund
+	AccountNumber string `json:"AccountNumber,omitempty" xml:"AccountNumber,omitempty"`
+
+	// The account name for the self managed super fund
+	AccountName string `json:"AccountName,omitempty" xml:"AccountName,omitempty"`
+
+	// The electronic service address for the self managed super fund
+	ElectronicServiceAddress string `json:"ElectronicServiceAddress,omitempty" xml:"ElectronicServiceAddress,omitempty"`
+
+	// Some funds assign a unique number to each employer
+	EmployerNumber string `json:"EmployerNumber,omitempty" xml:"EmployerNumber,omitempty"`
+
+	// Last modified timestamp
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"-"`
+
+	// Why the super fund could not be saved
+	ValidationErrors []xerogolang.ValidationError `json:"ValidationErrors,omitempty" xml:"-"`
+}
+
+//SuperFunds contains a collection of SuperFunds
+type SuperFunds struct {
+	SuperFunds []SuperFund `json:"SuperFunds" xml:"SuperFund"`
+}
+
+func unmarshalSuperFund(superFundResponseBytes []byte) (*SuperFunds, error) {
+	var superFundResponse *SuperFunds
+	err := json.Unmarshal(superFundResponseBytes, &superFundResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return superFundResponse, err
+}
+
+//Create will create SuperFunds given a SuperFunds struct
+func (s *SuperFunds) Create(provider *xerogolang.Provider, session goth.Session) (*SuperFunds, error) {
+	return s.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (s *SuperFunds) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*SuperFunds, error) {
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/xml",
+	}
+
+	body, err := xml.MarshalIndent(s, "  ", "   ")
+	if err != nil {
+		return nil, err
+	}
+
+	superFundResponseBytes, err := provider.UpdateWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "SuperFunds", additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalSuperFund(superFundResponseBytes)
+}
+
+//Update will update a SuperFund given a SuperFunds struct
+//This will only handle single SuperFund - you cannot update multiple SuperFunds in a single call
+func (s *SuperFunds) Update(provider *xerogolang.Provider, session goth.Session) (*SuperFunds, error) {
+	return s.UpdateCtx(context.Background(), provider, session)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (s *SuperFunds) UpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*SuperFunds, error) {
+	additionalHeaders := map[string]string{
+		"Accept":       "application/json",
+		"Content-Type": "application/xml",
+	}
+
+	body, err := xml.MarshalIndent(s, "  ", "   ")
+	if err != nil {
+		return nil, err
+	}
+
+	superFundResponseBytes, err := provider.UpdateWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "SuperFunds/"+s.SuperFunds[0].SuperFundID, additionalHeaders, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalSuperFund(superFundResponseBytes)
+}
+
+//FindSuperFundsModifiedSince will get all SuperFunds modified after a specified date.
+//additional querystringParameters such as where, page, order can be added as a map
+func FindSuperFundsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*SuperFunds, error) {
+	return FindSuperFundsModifiedSinceCtx(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindSuperFundsModifiedSinceCtx is FindSuperFundsModifiedSince with a context that can cancel the request or set its deadline
+func FindSuperFundsModifiedSinceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*SuperFunds, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	if !modifiedSince.Equal(dayZero) {
+		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
+	}
+
+	superFundResponseBytes, err := provider.FindWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "SuperFunds", additionalHeaders, querystringParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalSuperFund(superFundResponseBytes)
+}
+
+//FindSuperFunds will get all SuperFunds.
+func FindSuperFunds(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*SuperFunds, error) {
+	return FindSuperFundsCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindSuperFundsCtx is FindSuperFunds with a context that can cancel the request or set its deadline
+func FindSuperFundsCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*SuperFunds, error) {
+	return FindSuperFundsModifiedSinceCtx(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//FindSuperFund will get a single SuperFund
+func FindSuperFund(provider *xerogolang.Provider, session goth.Session, superFundID string) (*SuperFunds, error) {
+	return FindSuperFundCtx(context.Background(), provider, session, superFundID)
+}
+
+//FindSuperFundCtx is FindSuperFund with a context that can cancel the request or set its deadline
+func FindSuperFundCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, superFundID string) (*SuperFunds, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	superFundResponseBytes, err := provider.FindWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "SuperFunds/"+superFundID, additionalHeaders, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalSuperFund(superFundResponseBytes)
+}
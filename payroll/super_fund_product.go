@@ -0,0 +1,60 @@
+package payroll
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/markbates/goth"
+	"github.com/opensimsim/xerogolang"
+)
+
+//SuperFundProduct is a regulated super fund product listed by the ATO, used to find the USI of a SuperFund
+type SuperFundProduct struct {
+
+	// The ABN of the Regulated SuperFund
+	ABN string `json:"ABN,omitempty"`
+
+	// The USI of the Regulated SuperFund
+	USI string `json:"USI,omitempty"`
+
+	// The SPIN of the Regulated SuperFund. This field has been deprecated
+	SPIN string `json:"SPIN,omitempty"`
+
+	// The name of the Regulated SuperFund
+	ProductName string `json:"ProductName,omitempty"`
+}
+
+//SuperFundProducts contains a collection of SuperFundProducts
+type SuperFundProducts struct {
+	SuperFundProducts []SuperFundProduct `json:"SuperFundProducts"`
+}
+
+func unmarshalSuperFundProduct(superFundProductResponseBytes []byte) (*SuperFundProducts, error) {
+	var superFundProductResponse *SuperFundProducts
+	err := json.Unmarshal(superFundProductResponseBytes, &superFundProductResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return superFundProductResponse, err
+}
+
+//FindSuperFundProducts will get the super fund products with an ABN or USI. Add either as a querystringParameter
+//e.g. map[string]string{"ABN": "40022701955"} or map[string]string{"USI": "OSF0001AU"}
+func FindSuperFundProducts(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*SuperFundProducts, error) {
+	return FindSuperFundProductsCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindSuperFundProductsCtx is FindSuperFundProducts with a context that can cancel the request or set its deadline
+func FindSuperFundProductsCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*SuperFundProducts, error) {
+	additionalHeaders := map[string]string{
+		"Accept": "application/json",
+	}
+
+	superFundProductResponseBytes, err := provider.FindWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "SuperfundProducts", additionalHeaders, querystringParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalSuperFundProduct(superFundProductResponseBytes)
+}
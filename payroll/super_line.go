@@ -0,0 +1,34 @@
+package payroll
+
+import "github.com/opensimsim/xerogolang"
+
+//SuperLine is a contribution to an employee's super fund, on a pay template or a payslip
+type SuperLine struct {
+
+	// Xero super membership identifier (see Employee SuperMemberships)
+	SuperMembershipID string `json:"SuperMembershipID,omitempty" xml:"SuperMembershipID,omitempty"`
+
+	// SGC, SALARYSACRIFICE, EMPLOYERADDITIONAL or EMPLOYEE
+	ContributionType string `json:"ContributionType,omitempty" xml:"ContributionType,omitempty"`
+
+	// FIXEDAMOUNT, PERCENTAGEOFEARNINGS or STATUTORY
+	CalculationType string `json:"CalculationType,omitempty" xml:"CalculationType,omitempty"`
+
+	// Earnings a month below which no SGC contribution is made e.g. 450
+	MinimumMonthlyEarnings xerogolang.Decimal `json:"MinimumMonthlyEarnings,omitzero" xml:"MinimumMonthlyEarnings,omitempty"`
+
+	// Account code the contribution is expensed to
+	ExpenseAccountCode string `json:"ExpenseAccountCode,omitempty" xml:"ExpenseAccountCode,omitempty"`
+
+	// Account code the contribution is owed from until it is paid
+	LiabilityAccountCode string `json:"LiabilityAccountCode,omitempty" xml:"LiabilityAccountCode,omitempty"`
+
+	// Date the contribution for this pay period is paid, only for payslip lines
+	PaymentDateForThisPeriod xerogolang.Date `json:"PaymentDateForThisPeriod,omitzero" xml:"PaymentDateForThisPeriod,omitempty"`
+
+	// Percentage of earnings contributed when CalculationType is PERCENTAGEOFEARNINGS
+	Percentage xerogolang.Decimal `json:"Percentage,omitzero" xml:"Percentage,omitempty"`
+
+	// Amount contributed when CalculationType is FIXEDAMOUNT
+	Amount xerogolang.Decimal `json:"Amount,omitzero" xml:"Amount,omitempty"`
+}
@@ -0,0 +1,14 @@
+package payroll
+
+//SuperMembership links an employee to the super fund their contributions are paid into
+type SuperMembership struct {
+
+	// Xero unique identifier for Super membership
+	SuperMembershipID string `json:"SuperMembershipID,omitempty" xml:"SuperMembershipID,omitempty"`
+
+	// Xero identifier for the super fund. See SuperFunds
+	SuperFundID string `json:"SuperFundID,omitempty" xml:"SuperFundID,omitempty"`
+
+	// The membership number assigned to the employee by the super fund
+	EmployeeNumber string `json:"EmployeeNumber,omitempty" xml:"EmployeeNumber,omitempty"`
+}
@@ -0,0 +1,52 @@
+package payroll
+
+import "github.com/opensimsim/xerogolang"
+
+//TaxDeclaration is what an employee declared on their TFN declaration, which decides how much tax is withheld
+type TaxDeclaration struct {
+
+	// The Xero identifier for the employee the declaration belongs to
+	EmployeeID string `json:"EmployeeID,omitempty" xml:"-"`
+
+	// See EmploymentBasis
+	EmploymentBasis EmploymentBasis `json:"EmploymentBasis,omitempty" xml:"EmploymentBasis,omitempty"`
+
+	// NOTQUOTED, PENDING, PENSIONER or UNDER18 when the employee has not given a tax file number
+	TFNExemptionType string `json:"TFNExemptionType,omitempty" xml:"TFNExemptionType,omitempty"`
+
+	// The tax file number e.g 123123123
+	TaxFileNumber string `json:"TaxFileNumber,omitempty" xml:"TaxFileNumber,omitempty"`
+
+	// If the employee is Australian resident for tax purposes
+	AustralianResidentForTaxPurposes bool `json:"AustralianResidentForTaxPurposes,omitempty" xml:"AustralianResidentForTaxPurposes,omitempty"`
+
+	// AUSTRALIANRESIDENT, FOREIGNRESIDENT or WORKINGHOLIDAYMAKER
+	ResidencyStatus string `json:"ResidencyStatus,omitempty" xml:"ResidencyStatus,omitempty"`
+
+	// If tax free threshold claimed
+	TaxFreeThresholdClaimed bool `json:"TaxFreeThresholdClaimed,omitempty" xml:"TaxFreeThresholdClaimed,omitempty"`
+
+	// If has tax offset estimated then the tax offset estimated amount e.g 100
+	TaxOffsetEstimatedAmount xerogolang.Decimal `json:"TaxOffsetEstimatedAmount,omitzero" xml:"TaxOffsetEstimatedAmount,omitempty"`
+
+	// If employee has HECS or HELP debt
+	HasHELPDebt bool `json:"HasHELPDebt,omitempty" xml:"HasHELPDebt,omitempty"`
+
+	// If employee has financial supplement debt
+	HasSFSSDebt bool `json:"HasSFSSDebt,omitempty" xml:"HasSFSSDebt,omitempty"`
+
+	// If employee has trade support loan
+	HasTradeSupportLoanDebt bool `json:"HasTradeSupportLoanDebt,omitempty" xml:"HasTradeSupportLoanDebt,omitempty"`
+
+	// If the employee has requested that additional tax be withheld each pay run e.g 50
+	UpwardVariationTaxWithholdingAmount xerogolang.Decimal `json:"UpwardVariationTaxWithholdingAmount,omitzero" xml:"UpwardVariationTaxWithholdingAmount,omitempty"`
+
+	// If the employee is eligible to receive an additional percentage on top of ordinary earnings when they take leave
+	EligibleToReceiveLeaveLoading bool `json:"EligibleToReceiveLeaveLoading,omitempty" xml:"EligibleToReceiveLeaveLoading,omitempty"`
+
+	// If the employee has approved withholding variation e.g 0 - 100
+	ApprovedWithholdingVariationPercentage xerogolang.Decimal `json:"ApprovedWithholdingVariationPercentage,omitzero" xml:"ApprovedWithholdingVariationPercentage,omitempty"`
+
+	// Last modified timestamp
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"-"`
+}
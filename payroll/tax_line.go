@@ -0,0 +1,25 @@
+package payroll
+
+import "github.com/opensimsim/xerogolang"
+
+//TaxLine is the tax withheld from an employee's pay, on a payslip
+type TaxLine struct {
+
+	// Xero identifier for payslip tax line ID
+	PayslipTaxLineID string `json:"PayslipTaxLineID,omitempty" xml:"PayslipTaxLineID,omitempty"`
+
+	// Name of the tax type e.g. PAYG Tax
+	TaxTypeName string `json:"TaxTypeName,omitempty" xml:"TaxTypeName,omitempty"`
+
+	// Description of the tax line
+	Description string `json:"Description,omitempty" xml:"Description,omitempty"`
+
+	// The tax line amount
+	Amount xerogolang.Decimal `json:"Amount,omitzero" xml:"Amount,omitempty"`
+
+	// The tax line liability account code. For posted pay run you should be able to see liability account code
+	LiabilityAccount string `json:"LiabilityAccount,omitempty" xml:"LiabilityAccount,omitempty"`
+
+	// PAYGMANUAL, ETPOMANUAL, ETPRMANUAL, SCHEDULE5MANUAL, SCHEDULE5STSLMANUAL or SCHEDULE4MANUAL
+	ManualTaxType string `json:"ManualTaxType,omitempty" xml:"ManualTaxType,omitempty"`
+}
@@ -1,114 +1,148 @@
 package payroll
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
-	"log"
+	"fmt"
 	"time"
 
 	"github.com/markbates/goth"
 	"github.com/opensimsim/xerogolang"
-	"github.com/opensimsim/xerogolang/helpers"
 )
 
-//Timesheet - placeholder waiting for .
+//Timesheet is the units an employee worked at each earnings rate on each day of one pay period
 type Timesheet struct {
 
-	// The Xero identifier for an timesheet e.g. 297c2dc5-cc47-4afd-8ec8-74990b8761e9
-	TimesheetID string `json:"TimesheetID,omitempty" xml:"TimesheetID"`
+	// The Xero identifier for a timesheet e.g. 297c2dc5-cc47-4afd-8ec8-74990b8761e9
+	TimesheetID string `json:"TimesheetID,omitempty" xml:"TimesheetID,omitempty"`
 
-	StartDateUTC string `json:"StartDate,omitempty" xml:"StartDate"`
+	// The Xero identifier for the employee the timesheet is for
+	EmployeeID string `json:"EmployeeID,omitempty" xml:"EmployeeID,omitempty"`
 
-	EndDateUTC string `json:"EndDate,omitempty" xml:"EndDate"`
+	// First day of the pay period the timesheet covers (YYYY-MM-DD)
+	StartDate xerogolang.Date `json:"StartDate,omitzero" xml:"StartDate,omitempty"`
 
-	Status string `json:"Status,omitempty" xml:"Status,omitempty"`
+	// Last day of the pay period the timesheet covers (YYYY-MM-DD)
+	EndDate xerogolang.Date `json:"EndDate,omitzero" xml:"EndDate,omitempty"`
 
-	Hours float64 `json:"Hours,omitempty" xml:"Hours,omitempty"`
+	// See TimesheetStatus
+	Status TimesheetStatus `json:"Status,omitempty" xml:"Status,omitempty"`
 
-	TimesheetLine []TimesheetLine `json:"TimesheetLines,omitempty" xml:"TimesheetLines,omitempty"`
-}
+	// The total hours on the timesheet
+	Hours xerogolang.Decimal `json:"Hours,omitzero" xml:"-"`
 
-type TimesheetLine struct {
-	EarningsRateID string    `json:"EarningsRateID,omitempty" xml:"EarningsRateID"`
-	NumberOfUnits  []float64 `json:"NumberOfUnits,omitempty" xml:"NumberOfUnits,omitempty"`
-}
+	// One line for each earnings rate worked. See AddUnits
+	TimesheetLines *[]TimesheetLine `json:"TimesheetLines,omitempty" xml:"TimesheetLines>TimesheetLine,omitempty"`
+
+	// Last modified timestamp
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"-"`
 
-type NumberOfUnit struct {
-	NumberOfUnit float64 `json:"NumberOfUnit,omitempty" xml:"NumberOfUnit,omitempty"`
+	// Why the timesheet could not be saved
+	ValidationErrors []xerogolang.ValidationError `json:"ValidationErrors,omitempty" xml:"-"`
 }
 
-/*
-<Timesheet>
-    <TimesheetID>5e493b2e-c3ed-4172-95b2-593438101f76</TimesheetID>
-    <StartDate>2018-03-25T00:00:00</StartDate>
-    <EndDate>2018-04-01T00:00:00</EndDate>
-    <Status>Draft</Status>
-    <TimesheetLines>
-        <TimesheetLine>
-            <EarningsRateID>0daff504-2d42-4243-bdac-24f2bae0ce7c</EarningsRateID>
-            <NumberOfUnits>
-                <NumberOfUnit>8</NumberOfUnit>
-                <NumberOfUnit>8</NumberOfUnit>
-                <NumberOfUnit>8</NumberOfUnit>
-                <NumberOfUnit>8</NumberOfUnit>
-                <NumberOfUnit>8</NumberOfUnit>
-                <NumberOfUnit>0</NumberOfUnit>
-                <NumberOfUnit>0</NumberOfUnit>
-            </NumberOfUnits>
-        </TimesheetLine>
-    </TimesheetLines>
-</Timesheet>
-*/
 //Timesheets contains a collection of Timesheets
 type Timesheets struct {
-	ID           string `json:"Id,omitempty" xml:"Timesheet"`
-	Status       string `json:"Status,omitempty" xml:"Status"`
-	ProviderName string `json:"ProviderName,omitempty" xml:"ProviderName"`
-	DateTimeUTC  string `json:"DateTimeUTC,omitempty" xml:"-"`
-
-	Timesheets []Timesheet `json:"Timesheets" xml:"Timesheets"`
+	Timesheets []Timesheet `json:"Timesheets" xml:"Timesheet"`
 }
 
-//The Xero API returns Dates based on the .Net JSON date format available at the time of development
-//We need to convert these to a more usable format - RFC3339 for consistency with what the API expects to recieve
-func (c *Timesheets) convertDates() error {
-
-	var err error
-	c.DateTimeUTC, err = helpers.DotNetJSONTimeToRFC3339(c.DateTimeUTC, true)
+func unmarshalTimesheet(timesheetResponseBytes []byte) (*Timesheets, error) {
+	//a single timesheet is returned on its own rather than in a collection
+	var timesheetResponse struct {
+		Timesheet  *Timesheet
+		Timesheets []Timesheet
+	}
+	err := json.Unmarshal(timesheetResponseBytes, &timesheetResponse)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	/*	var err error
-		for n := len(c.Timesheets) - 1; n >= 0; n-- {
-			c.Timesheets[n].UpdatedDateUTC, err = helpers.DotNetJSONTimeToRFC3339(c.Timesheets[n].UpdatedDateUTC, true)
-			if err != nil {
-				return err
-			}
-		}*/
 
-	return nil
+	timesheets := &Timesheets{Timesheets: timesheetResponse.Timesheets}
+	if timesheetResponse.Timesheet != nil {
+		timesheets.Timesheets = append(timesheets.Timesheets, *timesheetResponse.Timesheet)
+	}
+	return timesheets, err
 }
 
-func unmarshalTimesheet(timesheetResponseBytes []byte) (*Timesheets, error) {
-	var timesheetResponse *Timesheets
+//NewTimesheet starts a draft Timesheet for an employee from startDate to endDate with no lines.
+//Use PayrollCalendar.PayPeriod to find the dates and AddUnits to fill it in
+func NewTimesheet(employeeID string, startDate time.Time, endDate time.Time) *Timesheet {
+	return &Timesheet{
+		EmployeeID: employeeID,
+		StartDate:  xerogolang.Date{Time: truncateToDay(startDate)},
+		EndDate:    xerogolang.Date{Time: truncateToDay(endDate)},
+		Status:     TimesheetStatusDraft,
+	}
+}
 
-	log.Printf("Timesheet: %s\n", string(timesheetResponseBytes))
+//Days is the number of days from StartDate to EndDate, including both
+func (t *Timesheet) Days() int {
+	days := int(truncateToDay(t.EndDate.Time).Sub(truncateToDay(t.StartDate.Time)).Hours()/24) + 1
+	if days < 0 {
+		return 0
+	}
+	return days
+}
 
-	err := json.Unmarshal(timesheetResponseBytes, &timesheetResponse)
-	if err != nil {
-		return nil, err
+//AddUnits adds units worked at earningsRateID on date to the timesheet, starting a line for the
+//earnings rate if it doesn't have one yet
+func (t *Timesheet) AddUnits(earningsRateID string, date time.Time, units xerogolang.Decimal) error {
+	day := int(truncateToDay(date).Sub(truncateToDay(t.StartDate.Time)).Hours() / 24)
+	if day < 0 || day >= t.Days() {
+		return fmt.Errorf("%s is not between the timesheet StartDate %s and EndDate %s", date.Format("2006-01-02"), t.StartDate.Format("2006-01-02"), t.EndDate.Format("2006-01-02"))
 	}
 
-	err = timesheetResponse.convertDates()
-	if err != nil {
-		return nil, err
+	if t.TimesheetLines == nil {
+		t.TimesheetLines = &[]TimesheetLine{}
+	}
+	lines := *t.TimesheetLines
+	n := 0
+	for n < len(lines) && lines[n].EarningsRateID != earningsRateID {
+		n++
 	}
+	if n == len(lines) {
+		lines = append(lines, TimesheetLine{
+			EarningsRateID: earningsRateID,
+			NumberOfUnits:  make(NumberOfUnits, t.Days()),
+		})
+	}
+	for len(lines[n].NumberOfUnits) < t.Days() {
+		lines[n].NumberOfUnits = append(lines[n].NumberOfUnits, xerogolang.Decimal{})
+	}
+	lines[n].NumberOfUnits[day] = lines[n].NumberOfUnits[day].Add(units)
+	*t.TimesheetLines = lines
+	return nil
+}
 
-	return timesheetResponse, err
+//ValidatePeriod checks that the timesheet covers exactly one pay period of calendar, the payroll
+//calendar of its employee, and that every line has units for each day of it
+func (t *Timesheet) ValidatePeriod(calendar *PayrollCalendar) error {
+	start, end, err := calendar.PayPeriod(t.StartDate.Time)
+	if err != nil {
+		return err
+	}
+	if !truncateToDay(t.StartDate.Time).Equal(start) || !truncateToDay(t.EndDate.Time).Equal(end) {
+		return fmt.Errorf("timesheet from %s to %s is not a pay period of payroll calendar %q, which has one from %s to %s",
+			t.StartDate.Format("2006-01-02"), t.EndDate.Format("2006-01-02"), calendar.Name, start.Format("2006-01-02"), end.Format("2006-01-02"))
+	}
+	if t.TimesheetLines != nil {
+		for n, line := range *t.TimesheetLines {
+			if len(line.NumberOfUnits) != t.Days() {
+				return fmt.Errorf("timesheet line %d has units for %d days but the pay period has %d", n, len(line.NumberOfUnits), t.Days())
+			}
+		}
+	}
+	return nil
 }
 
-//Create will create Timesheets given an Timesheets struct
+//Create will create Timesheets given a Timesheets struct
 func (c *Timesheets) Create(provider *xerogolang.Provider, session goth.Session) (*Timesheets, error) {
+	return c.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (c *Timesheets) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Timesheets, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -119,7 +153,7 @@ func (c *Timesheets) Create(provider *xerogolang.Provider, session goth.Session)
 		return nil, err
 	}
 
-	timesheetResponseBytes, err := provider.Create(session, "Timesheets", additionalHeaders, body)
+	timesheetResponseBytes, err := provider.UpdateWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "Timesheets", additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -130,6 +164,11 @@ func (c *Timesheets) Create(provider *xerogolang.Provider, session goth.Session)
 //Update will update a Timesheet given a Timesheets struct
 //This will only handle single Timesheet - you cannot update multiple Timesheets in a single call
 func (c *Timesheets) Update(provider *xerogolang.Provider, session goth.Session) (*Timesheets, error) {
+	return c.UpdateCtx(context.Background(), provider, session)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (c *Timesheets) UpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*Timesheets, error) {
 	additionalHeaders := map[string]string{
 		"Accept":       "application/json",
 		"Content-Type": "application/xml",
@@ -140,7 +179,7 @@ func (c *Timesheets) Update(provider *xerogolang.Provider, session goth.Session)
 		return nil, err
 	}
 
-	timesheetResponseBytes, err := provider.Update(session, "Timesheets/"+c.Timesheets[0].TimesheetID, additionalHeaders, body)
+	timesheetResponseBytes, err := provider.UpdateWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "Timesheets/"+c.Timesheets[0].TimesheetID, additionalHeaders, body)
 	if err != nil {
 		return nil, err
 	}
@@ -148,8 +187,50 @@ func (c *Timesheets) Update(provider *xerogolang.Provider, session goth.Session)
 	return unmarshalTimesheet(timesheetResponseBytes)
 }
 
-//FindTimesheetsModifiedSince
+//ApproveTimesheet will approve a draft Timesheet so it is paid in the employee's next pay run
+func ApproveTimesheet(provider *xerogolang.Provider, session goth.Session, timesheetID string) (*Timesheets, error) {
+	return ApproveTimesheetCtx(context.Background(), provider, session, timesheetID)
+}
+
+//ApproveTimesheetCtx is ApproveTimesheet with a context that can cancel the request or set its deadline
+func ApproveTimesheetCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, timesheetID string) (*Timesheets, error) {
+	return setTimesheetStatus(ctx, provider, session, timesheetID, TimesheetStatusApproved)
+}
+
+//RevertTimesheet will move an approved Timesheet back to draft so it can be changed.
+//A timesheet that has been PROCESSED by a posted pay run cannot be reverted
+func RevertTimesheet(provider *xerogolang.Provider, session goth.Session, timesheetID string) (*Timesheets, error) {
+	return RevertTimesheetCtx(context.Background(), provider, session, timesheetID)
+}
+
+//RevertTimesheetCtx is RevertTimesheet with a context that can cancel the request or set its deadline
+func RevertTimesheetCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, timesheetID string) (*Timesheets, error) {
+	return setTimesheetStatus(ctx, provider, session, timesheetID, TimesheetStatusDraft)
+}
+
+//setTimesheetStatus sends the whole timesheet back, lines included, with its new status
+func setTimesheetStatus(ctx context.Context, provider *xerogolang.Provider, session goth.Session, timesheetID string, status TimesheetStatus) (*Timesheets, error) {
+	timesheets, err := FindTimesheetCtx(ctx, provider, session, timesheetID)
+	if err != nil {
+		return nil, err
+	}
+	if len(timesheets.Timesheets) == 0 {
+		return nil, fmt.Errorf("timesheet %q was not found", timesheetID)
+	}
+
+	timesheets.Timesheets = timesheets.Timesheets[:1]
+	timesheets.Timesheets[0].Status = status
+	return timesheets.UpdateCtx(ctx, provider, session)
+}
+
+//FindTimesheetsModifiedSince will get all Timesheets modified after a specified date.
+//additional querystringParameters such as where, page, order can be added as a map
 func FindTimesheetsModifiedSince(provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Timesheets, error) {
+	return FindTimesheetsModifiedSinceCtx(context.Background(), provider, session, modifiedSince, querystringParameters)
+}
+
+//FindTimesheetsModifiedSinceCtx is FindTimesheetsModifiedSince with a context that can cancel the request or set its deadline
+func FindTimesheetsModifiedSinceCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string) (*Timesheets, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
@@ -158,7 +239,7 @@ func FindTimesheetsModifiedSince(provider *xerogolang.Provider, session goth.Ses
 		additionalHeaders["If-Modified-Since"] = modifiedSince.Format(time.RFC3339)
 	}
 
-	timesheetResponseBytes, err := provider.FindWithEndpoint(session, "https://api.xero.com/payroll.xro/1.0/", "Timesheets", additionalHeaders, querystringParameters)
+	timesheetResponseBytes, err := provider.FindWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "Timesheets", additionalHeaders, querystringParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -168,18 +249,45 @@ func FindTimesheetsModifiedSince(provider *xerogolang.Provider, session goth.Ses
 
 //FindTimesheets will get all Timesheets.
 func FindTimesheets(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Timesheets, error) {
-	return FindTimesheetsModifiedSince(provider, session, dayZero, querystringParameters)
+	return FindTimesheetsCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindTimesheetsCtx is FindTimesheets with a context that can cancel the request or set its deadline
+func FindTimesheetsCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*Timesheets, error) {
+	return FindTimesheetsModifiedSinceCtx(ctx, provider, session, dayZero, querystringParameters)
+}
+
+//EachTimesheet calls fn with every timesheet modified since modifiedSince, fetching them 100 at a time
+//until Xero returns an empty page. Pass a zero time.Time to get all timesheets. where and order
+//querystringParameters are sent with every page. Return xerogolang.ErrStop from fn to stop early
+func EachTimesheet(ctx context.Context, provider *xerogolang.Provider, session goth.Session, modifiedSince time.Time, querystringParameters map[string]string, fn func(timesheet Timesheet) error) error {
+	return xerogolang.EachPage(ctx, querystringParameters, func(pageParameters map[string]string) (int, error) {
+		timesheets, err := FindTimesheetsModifiedSinceCtx(ctx, provider, session, modifiedSince, pageParameters)
+		if err != nil {
+			return 0, err
+		}
+		for _, timesheet := range timesheets.Timesheets {
+			err = fn(timesheet)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return len(timesheets.Timesheets), nil
+	})
 }
 
-//FindTimesheet will get a single Timesheet
+//FindTimesheet will get a single Timesheet along with its lines
 func FindTimesheet(provider *xerogolang.Provider, session goth.Session, timesheetID string) (*Timesheets, error) {
+	return FindTimesheetCtx(context.Background(), provider, session, timesheetID)
+}
+
+//FindTimesheetCtx is FindTimesheet with a context that can cancel the request or set its deadline
+func FindTimesheetCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, timesheetID string) (*Timesheets, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
 
-	log.Printf("Calling FindTimesheet: %s\n", timesheetID)
-
-	timesheetResponseBytes, err := provider.FindWithEndpoint(session, "https://api.xero.com/payroll.xro/1.0/", "Timesheets/"+timesheetID, additionalHeaders, nil)
+	timesheetResponseBytes, err := provider.FindWithEndpointCtx(ctx, session, provider.PayrollEndpoint(), "Timesheets/"+timesheetID, additionalHeaders, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,55 @@
+package payroll
+
+import (
+	"encoding/xml"
+
+	"github.com/opensimsim/xerogolang"
+)
+
+//TimesheetLine is the units worked at one earnings rate on each day of a Timesheet
+type TimesheetLine struct {
+
+	// The Xero identifier for the Earnings Rate (see PayItems)
+	EarningsRateID string `json:"EarningsRateID,omitempty" xml:"EarningsRateID,omitempty"`
+
+	// The Xero identifier for the Tracking Item the units are for (see Settings)
+	TrackingItemID string `json:"TrackingItemID,omitempty" xml:"TrackingItemID,omitempty"`
+
+	// The units worked on each day of the timesheet, starting on its StartDate
+	NumberOfUnits NumberOfUnits `json:"NumberOfUnits" xml:"NumberOfUnits"`
+
+	// Last modified timestamp
+	UpdatedDateUTC xerogolang.DateTime `json:"UpdatedDateUTC,omitzero" xml:"-"`
+}
+
+//NumberOfUnits is the units worked on each day of a timesheet period. Xero reads them by position,
+//so unlike other amounts a day with no units is still sent as 0
+type NumberOfUnits []xerogolang.Decimal
+
+//MarshalXML writes each day as a NumberOfUnit element
+func (n NumberOfUnits) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	err := e.EncodeToken(start)
+	if err != nil {
+		return err
+	}
+	for _, units := range n {
+		err = e.EncodeElement(units.String(), xml.StartElement{Name: xml.Name{Local: "NumberOfUnit"}})
+		if err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+//UnmarshalXML reads each NumberOfUnit element as a day
+func (n *NumberOfUnits) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	var numberOfUnits struct {
+		NumberOfUnit []xerogolang.Decimal `xml:"NumberOfUnit"`
+	}
+	err := decoder.DecodeElement(&numberOfUnits, &start)
+	if err != nil {
+		return err
+	}
+	*n = numberOfUnits.NumberOfUnit
+	return nil
+}
@@ -0,0 +1,137 @@
+package payroll
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/opensimsim/xerogolang"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewTimesheet(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	timesheet := NewTimesheet("e-1", time.Date(2020, 3, 2, 17, 30, 0, 0, time.Local), date(2020, 3, 8))
+	a.Equal("e-1", timesheet.EmployeeID)
+	a.Equal(date(2020, 3, 2), timesheet.StartDate.Time)
+	a.Equal(date(2020, 3, 8), timesheet.EndDate.Time)
+	a.Equal(TimesheetStatusDraft, timesheet.Status)
+	a.Nil(timesheet.TimesheetLines)
+
+	testCases := []struct {
+		start time.Time
+		end   time.Time
+		days  int
+	}{
+		{date(2020, 3, 2), date(2020, 3, 8), 7},
+		{date(2020, 3, 2), date(2020, 3, 2), 1},
+		{date(2020, 2, 1), date(2020, 2, 29), 29},
+		{date(2020, 3, 2), date(2020, 3, 1), 0},
+		{date(2020, 3, 8), date(2020, 3, 2), 0},
+	}
+	for _, testCase := range testCases {
+		a.Equal(testCase.days, NewTimesheet("e-1", testCase.start, testCase.end).Days(), "%s to %s", testCase.start, testCase.end)
+	}
+}
+
+func Test_AddUnits(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	timesheet := NewTimesheet("e-1", date(2020, 3, 2), date(2020, 3, 8))
+	a.NoError(timesheet.AddUnits("ordinary", date(2020, 3, 2), xerogolang.MustParseDecimal("8")))
+	a.NoError(timesheet.AddUnits("ordinary", time.Date(2020, 3, 8, 11, 0, 0, 0, time.UTC), xerogolang.MustParseDecimal("4")))
+	a.NoError(timesheet.AddUnits("overtime", date(2020, 3, 4), xerogolang.MustParseDecimal("1.5")))
+	//units for a day that already has some are added to them
+	a.NoError(timesheet.AddUnits("ordinary", date(2020, 3, 2), xerogolang.MustParseDecimal("0.5")))
+
+	a.Len(*timesheet.TimesheetLines, 2)
+	units := func(n int) []string {
+		var units []string
+		for _, unit := range (*timesheet.TimesheetLines)[n].NumberOfUnits {
+			units = append(units, unit.String())
+		}
+		return units
+	}
+	a.Equal("ordinary", (*timesheet.TimesheetLines)[0].EarningsRateID)
+	a.Equal([]string{"8.5", "0", "0", "0", "0", "0", "4"}, units(0))
+	a.Equal("overtime", (*timesheet.TimesheetLines)[1].EarningsRateID)
+	a.Equal([]string{"0", "0", "1.5", "0", "0", "0", "0"}, units(1))
+
+	err := timesheet.AddUnits("ordinary", date(2020, 3, 1), xerogolang.MustParseDecimal("8"))
+	a.EqualError(err, "2020-03-01 is not between the timesheet StartDate 2020-03-02 and EndDate 2020-03-08")
+	err = timesheet.AddUnits("ordinary", date(2020, 3, 9), xerogolang.MustParseDecimal("8"))
+	a.Error(err)
+
+	//a line read back with fewer days than the timesheet is filled out
+	short := NewTimesheet("e-1", date(2020, 3, 2), date(2020, 3, 8))
+	short.TimesheetLines = &[]TimesheetLine{{EarningsRateID: "ordinary", NumberOfUnits: NumberOfUnits{xerogolang.MustParseDecimal("8")}}}
+	a.NoError(short.AddUnits("ordinary", date(2020, 3, 5), xerogolang.MustParseDecimal("2")))
+	a.Len((*short.TimesheetLines)[0].NumberOfUnits, 7)
+	a.Equal("2", (*short.TimesheetLines)[0].NumberOfUnits[3].String())
+}
+
+func Test_ValidatePeriod(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	weekly := &PayrollCalendar{Name: "Weekly", CalendarType: CalendarTypeWeekly, StartDate: xerogolang.NewDate(2020, 3, 2)}
+	monthly := &PayrollCalendar{Name: "Monthly", CalendarType: CalendarTypeMonthly, StartDate: xerogolang.NewDate(2020, 1, 31)}
+	twiceMonthly := &PayrollCalendar{Name: "Twice monthly", CalendarType: CalendarTypeTwiceMonthly}
+
+	testCases := []struct {
+		name     string
+		calendar *PayrollCalendar
+		start    time.Time
+		end      time.Time
+		err      string
+	}{
+		{"weekly", weekly, date(2020, 3, 9), date(2020, 3, 15), ""},
+		{"weekly before the calendar starts", weekly, date(2020, 2, 24), date(2020, 3, 1), ""},
+		{"weekly starting mid period", weekly, date(2020, 3, 10), date(2020, 3, 16),
+			`timesheet from 2020-03-10 to 2020-03-16 is not a pay period of payroll calendar "Weekly", which has one from 2020-03-09 to 2020-03-15`},
+		{"weekly ending late", weekly, date(2020, 3, 9), date(2020, 3, 16),
+			`timesheet from 2020-03-09 to 2020-03-16 is not a pay period of payroll calendar "Weekly", which has one from 2020-03-09 to 2020-03-15`},
+		{"monthly from the 31st", monthly, date(2020, 2, 29), date(2020, 3, 30), ""},
+		{"monthly from the 31st taking the calendar month", monthly, date(2020, 3, 1), date(2020, 3, 31),
+			`timesheet from 2020-03-01 to 2020-03-31 is not a pay period of payroll calendar "Monthly", which has one from 2020-02-29 to 2020-03-30`},
+		{"twice monthly", twiceMonthly, date(2020, 2, 16), date(2020, 2, 29), ""},
+		{"without a start date", &PayrollCalendar{Name: "New", CalendarType: CalendarTypeFortnightly}, date(2020, 3, 2), date(2020, 3, 15),
+			`payroll calendar "New" has no StartDate`},
+	}
+
+	for _, testCase := range testCases {
+		err := NewTimesheet("e-1", testCase.start, testCase.end).ValidatePeriod(testCase.calendar)
+		if testCase.err == "" {
+			a.NoError(err, testCase.name)
+		} else {
+			a.EqualError(err, testCase.err, testCase.name)
+		}
+	}
+
+	timesheet := NewTimesheet("e-1", date(2020, 3, 9), date(2020, 3, 15))
+	a.NoError(timesheet.AddUnits("ordinary", date(2020, 3, 9), xerogolang.MustParseDecimal("8")))
+	a.NoError(timesheet.ValidatePeriod(weekly))
+	*timesheet.TimesheetLines = append(*timesheet.TimesheetLines, TimesheetLine{EarningsRateID: "overtime", NumberOfUnits: NumberOfUnits{xerogolang.MustParseDecimal("1")}})
+	a.EqualError(timesheet.ValidatePeriod(weekly), "timesheet line 1 has units for 1 days but the pay period has 7")
+}
+
+func Test_NumberOfUnits_XML(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	timesheet := NewTimesheet("e-1", date(2020, 3, 2), date(2020, 3, 4))
+	a.NoError(timesheet.AddUnits("ordinary", date(2020, 3, 3), xerogolang.MustParseDecimal("7.5")))
+
+	body, err := xml.Marshal(timesheet)
+	a.NoError(err)
+	//every day is sent, including days without units, so the units line up with the dates
+	a.Contains(string(body), "<NumberOfUnits><NumberOfUnit>0</NumberOfUnit><NumberOfUnit>7.5</NumberOfUnit><NumberOfUnit>0</NumberOfUnit></NumberOfUnits>")
+
+	var decoded Timesheet
+	a.NoError(xml.Unmarshal(body, &decoded))
+	a.Len((*decoded.TimesheetLines)[0].NumberOfUnits, 3)
+	a.Equal("7.5", (*decoded.TimesheetLines)[0].NumberOfUnits[1].String())
+}
@@ -0,0 +1,99 @@
+package uk
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/opensimsim/xerogolang"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+//mockPayroll answers every request with response and records the method, path and body of each
+func mockPayroll(response string, f func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string)) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		uri := req.URL.Path
+		if req.URL.RawQuery != "" {
+			uri += "?" + req.URL.Query().Encode()
+		}
+		requests = append(requests, fmt.Sprintf("%s %s %s", req.Method, uri, body))
+		fmt.Fprint(res, response)
+	}))
+	defer ts.Close()
+
+	provider := xerogolang.NewOAuth2("CLIENT", "SECRET", "/foo")
+	provider.Endpoints = xerogolang.Endpoints{PayrollV2: ts.URL + "/"}
+	session := &xerogolang.Session{OAuth2Token: &oauth2.Token{AccessToken: "ACCESSTOKEN", RefreshToken: "REFRESHTOKEN", TokenType: "Bearer"}}
+	f(provider, session, &requests)
+}
+
+func Test_Employee_Create(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	response := `{"employee":{"employeeID":"e-1","firstName":"Cosmo","lastName":"Kramer","gender":"M","nationalInsuranceNumber":"AB123456C","dateOfBirth":"1955-07-24T00:00:00","address":{"addressLine1":"129 W 81st St","city":"London","postCode":"W1 1AA"}}}`
+	mockPayroll(response, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		employee := &Employee{
+			FirstName:               "Cosmo",
+			LastName:                "Kramer",
+			Gender:                  GenderMale,
+			NationalInsuranceNumber: "AB123456C",
+		}
+
+		employees, err := employee.Create(provider, session)
+		a.NoError(err)
+		a.Len(employees.Employees, 1)
+		created := employees.Employees[0]
+		a.Equal("e-1", created.EmployeeID)
+		a.Equal(xerogolang.NewDate(1955, time.July, 24), created.DateOfBirth)
+		a.Equal("W1 1AA", created.Address.PostCode)
+
+		//the unset dates are left out rather than sent as nulls
+		a.Equal([]string{
+			`POST /Employees {"firstName":"Cosmo","lastName":"Kramer","gender":"M","nationalInsuranceNumber":"AB123456C"}`,
+		}, *requests)
+
+		created.PhoneNumber = "555-1234"
+		_, err = created.Update(provider, session)
+		a.NoError(err)
+		a.Contains((*requests)[1], "PUT /Employees/e-1 ")
+	})
+}
+
+func Test_Employee_InvalidGender(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockPayroll(`{}`, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		//I is only accepted by the NZ API
+		employee := &Employee{FirstName: "Cosmo", Gender: "I"}
+		_, err := employee.Create(provider, session)
+		a.Error(err)
+		a.Contains(err.Error(), `"I" is not a valid Gender`)
+		a.Empty(*requests)
+	})
+}
+
+func Test_EachEmployee(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	response := `{"pagination":{"page":1,"pageSize":100,"pageCount":1,"itemCount":2},"employees":[{"employeeID":"e-1"},{"employeeID":"e-2"}]}`
+	mockPayroll(response, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		var employeeIDs []string
+		err := EachEmployee(context.Background(), provider, session, nil, func(employee Employee) error {
+			employeeIDs = append(employeeIDs, employee.EmployeeID)
+			return nil
+		})
+		a.NoError(err)
+		a.Equal([]string{"e-1", "e-2"}, employeeIDs)
+		a.Equal([]string{"GET /Employees?page=1 "}, *requests)
+	})
+}
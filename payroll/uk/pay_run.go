@@ -0,0 +1,152 @@
+package uk
+
+import (
+	"context"
+
+	"github.com/markbates/goth"
+	"github.com/opensimsim/xerogolang"
+	"github.com/opensimsim/xerogolang/payroll/internal/payrollv2"
+)
+
+//PayRun pays the employees on a payroll calendar for one pay period
+type PayRun struct {
+
+	// Xero unique identifier for the pay run
+	PayRunID string `json:"payRunID,omitempty"`
+
+	// Xero unique identifier for the payroll calendar
+	PayrollCalendarID string `json:"payrollCalendarID,omitempty"`
+
+	// Period start date of the payroll calendar
+	PeriodStartDate xerogolang.Date `json:"periodStartDate,omitzero"`
+
+	// Period end date of the payroll calendar
+	PeriodEndDate xerogolang.Date `json:"periodEndDate,omitzero"`
+
+	// Payment date of the pay run
+	PaymentDate xerogolang.Date `json:"paymentDate,omitzero"`
+
+	// Total cost of the pay run
+	TotalCost xerogolang.Decimal `json:"totalCost,omitzero"`
+
+	// Total pay of the pay run
+	TotalPay xerogolang.Decimal `json:"totalPay,omitzero"`
+
+	// See PayRunStatus
+	PayRunStatus PayRunStatus `json:"payRunStatus,omitempty"`
+
+	// See PayRunType
+	PayRunType PayRunType `json:"payRunType,omitempty"`
+
+	// See CalendarType
+	CalendarType CalendarType `json:"calendarType,omitempty"`
+
+	// Posted date time of the pay run
+	PostedDateTime xerogolang.DateTime `json:"postedDateTime,omitzero"`
+
+	// The payslips in the pay run
+	PaySlips []PaySlip `json:"paySlips,omitempty"`
+}
+
+//PayRuns contains a page of PayRuns
+type PayRuns struct {
+	Pagination *xerogolang.Pagination `json:"pagination,omitempty"`
+	PayRuns    []PayRun               `json:"payRuns"`
+}
+
+func unmarshalPayRun(payRunResponseBytes []byte) (*PayRuns, error) {
+	pagination, payRuns, err := payrollv2.Unmarshal[PayRun](payRunResponseBytes, "payRun", "payRuns")
+	if err != nil {
+		return nil, err
+	}
+
+	return &PayRuns{Pagination: pagination, PayRuns: payRuns}, nil
+}
+
+//Create will create a draft PayRun for the next pay period of p's PayrollCalendarID
+func (p *PayRun) Create(provider *xerogolang.Provider, session goth.Session) (*PayRuns, error) {
+	return p.CreateCtx(context.Background(), provider, session)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (p *PayRun) CreateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*PayRuns, error) {
+	payRunResponseBytes, err := payrollv2.Post(ctx, provider, session, "PayRuns", p)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalPayRun(payRunResponseBytes)
+}
+
+//Update will update the PayRun with p's PayRunID, e.g. to change its PaymentDate or to post it
+func (p *PayRun) Update(provider *xerogolang.Provider, session goth.Session) (*PayRuns, error) {
+	return p.UpdateCtx(context.Background(), provider, session)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (p *PayRun) UpdateCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session) (*PayRuns, error) {
+	payRunResponseBytes, err := payrollv2.Put(ctx, provider, session, "PayRuns/"+p.PayRunID, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalPayRun(payRunResponseBytes)
+}
+
+//PostPayRun will post a draft PayRun, paying its employees. A posted pay run cannot be changed
+func PostPayRun(provider *xerogolang.Provider, session goth.Session, payRunID string) (*PayRuns, error) {
+	return PostPayRunCtx(context.Background(), provider, session, payRunID)
+}
+
+//PostPayRunCtx is PostPayRun with a context that can cancel the request or set its deadline
+func PostPayRunCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, payRunID string) (*PayRuns, error) {
+	payRun := &PayRun{
+		PayRunID:     payRunID,
+		PayRunStatus: PayRunStatusPosted,
+	}
+
+	return payRun.UpdateCtx(ctx, provider, session)
+}
+
+//FindPayRuns will get a page of PayRuns, without their payslips.
+//additional querystringParameters such as page and status can be added as a map
+func FindPayRuns(provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*PayRuns, error) {
+	return FindPayRunsCtx(context.Background(), provider, session, querystringParameters)
+}
+
+//FindPayRunsCtx is FindPayRuns with a context that can cancel the request or set its deadline
+func FindPayRunsCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string) (*PayRuns, error) {
+	payRunResponseBytes, err := payrollv2.Get(ctx, provider, session, "PayRuns", querystringParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalPayRun(payRunResponseBytes)
+}
+
+//EachPayRun calls fn with every pay run, a page at a time, until the last page. A status
+//querystringParameter is sent with every page. Return xerogolang.ErrStop from fn to stop early
+func EachPayRun(ctx context.Context, provider *xerogolang.Provider, session goth.Session, querystringParameters map[string]string, fn func(payRun PayRun) error) error {
+	return payrollv2.Each(ctx, querystringParameters, func(pageParameters map[string]string) (*xerogolang.Pagination, []PayRun, error) {
+		payRuns, err := FindPayRunsCtx(ctx, provider, session, pageParameters)
+		if err != nil {
+			return nil, nil, err
+		}
+		return payRuns.Pagination, payRuns.PayRuns, nil
+	}, fn)
+}
+
+//FindPayRun will get a single PayRun along with a summary of each of its payslips
+func FindPayRun(provider *xerogolang.Provider, session goth.Session, payRunID string) (*PayRuns, error) {
+	return FindPayRunCtx(context.Background(), provider, session, payRunID)
+}
+
+//FindPayRunCtx is FindPayRun with a context that can cancel the request or set its deadline
+func FindPayRunCtx(ctx context.Context, provider *xerogolang.Provider, session goth.Session, payRunID string) (*PayRuns, error) {
+	payRunResponseBytes, err := payrollv2.Get(ctx, provider, session, "PayRuns/"+payRunID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalPayRun(payRunResponseBytes)
+}
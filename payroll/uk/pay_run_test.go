@@ -0,0 +1,62 @@
+package uk
+
+import (
+	"testing"
+
+	"github.com/opensimsim/xerogolang"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PayRun_Create(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	response := `{"payRun":{"payRunID":"p-1","payRunStatus":"Draft","payRunType":"EarlierYearUpdate","calendarType":"Monthly","totalPay":1234.56,"paySlips":[{"paySlipID":"s-1","totalCourtOrders":100,"totalBenefits":25.5}]}}`
+	mockPayroll(response, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		payRun := &PayRun{PayrollCalendarID: "c-1", PayRunType: PayRunTypeEarlierYearUpdate}
+
+		payRuns, err := payRun.Create(provider, session)
+		a.NoError(err)
+		created := payRuns.PayRuns[0]
+		a.Equal(PayRunStatusDraft, created.PayRunStatus)
+		a.Equal(CalendarTypeMonthly, created.CalendarType)
+		a.Equal("1234.56", created.TotalPay.String())
+		a.Equal("100", created.PaySlips[0].TotalCourtOrders.String())
+		a.Equal("25.5", created.PaySlips[0].TotalBenefits.String())
+
+		//the unset totals and dates are left out rather than sent as zeros and nulls
+		a.Equal([]string{`POST /PayRuns {"payrollCalendarID":"c-1","payRunType":"EarlierYearUpdate"}`}, *requests)
+	})
+}
+
+func Test_PostPayRun(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockPayroll(`{"payRun":{"payRunID":"p-1","payRunStatus":"Posted"}}`, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		payRuns, err := PostPayRun(provider, session, "p-1")
+		a.NoError(err)
+		a.Equal(PayRunStatusPosted, payRuns.PayRuns[0].PayRunStatus)
+		a.Equal([]string{`PUT /PayRuns/p-1 {"payRunID":"p-1","payRunStatus":"Posted"}`}, *requests)
+	})
+}
+
+func Test_Timesheet_Reexported(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockPayroll(`{"timesheet":{"timesheetID":"t-1","status":"Approved"}}`, func(provider *xerogolang.Provider, session *xerogolang.Session, requests *[]string) {
+		timesheet := &Timesheet{EmployeeID: "e-1", TimesheetLines: []TimesheetLine{{EarningsRateID: "r-1", NumberOfUnits: xerogolang.MustParseDecimal("8")}}}
+		_, err := timesheet.Create(provider, session)
+		a.NoError(err)
+
+		timesheets, err := ApproveTimesheet(provider, session, "t-1")
+		a.NoError(err)
+		a.Equal(TimesheetStatusApproved, timesheets.Timesheets[0].Status)
+
+		a.Equal([]string{
+			`POST /Timesheets {"employeeID":"e-1","timesheetLines":[{"earningsRateID":"r-1","numberOfUnits":8}]}`,
+			`POST /Timesheets/t-1/Approve `,
+		}, *requests)
+	})
+}
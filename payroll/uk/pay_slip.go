@@ -0,0 +1,55 @@
+package uk
+
+import "github.com/opensimsim/xerogolang"
+
+//PaySlip is the summary of what one employee is paid in a PayRun
+type PaySlip struct {
+
+	// The Xero identifier for a Payslip
+	PaySlipID string `json:"paySlipID,omitempty"`
+
+	// The Xero identifier for payroll employee
+	EmployeeID string `json:"employeeID,omitempty"`
+
+	// The Xero identifier for the associated payrun
+	PayRunID string `json:"payRunID,omitempty"`
+
+	// The date payslip was last updated
+	LastEdited xerogolang.DateTime `json:"lastEdited,omitzero"`
+
+	// Employee first name
+	FirstName string `json:"firstName,omitempty"`
+
+	// Employee last name
+	LastName string `json:"lastName,omitempty"`
+
+	// Total earnings before any deductions. Same as gross earnings for UK
+	TotalEarnings xerogolang.Decimal `json:"totalEarnings,omitzero"`
+
+	// Total earnings before any deductions. Same as total earnings for UK
+	GrossEarnings xerogolang.Decimal `json:"grossEarnings,omitzero"`
+
+	// The employee net pay
+	TotalPay xerogolang.Decimal `json:"totalPay,omitzero"`
+
+	// The employer's tax obligation
+	TotalEmployerTaxes xerogolang.Decimal `json:"totalEmployerTaxes,omitzero"`
+
+	// The part of an employee's earnings that is deducted for tax purposes
+	TotalEmployeeTaxes xerogolang.Decimal `json:"totalEmployeeTaxes,omitzero"`
+
+	// Total amount subtracted from an employee's earnings to reach total pay
+	TotalDeductions xerogolang.Decimal `json:"totalDeductions,omitzero"`
+
+	// Total reimbursements are nontaxable payments to an employee used to repay out-of-pocket expenses
+	TotalReimbursements xerogolang.Decimal `json:"totalReimbursements,omitzero"`
+
+	// Total amounts required by law to subtract from the employee's earnings
+	TotalCourtOrders xerogolang.Decimal `json:"totalCourtOrders,omitzero"`
+
+	// Benefits (also called fringe benefits, perquisites or perks) are various non-earnings compensations
+	TotalBenefits xerogolang.Decimal `json:"totalBenefits,omitzero"`
+
+	// The payment method code
+	PaymentMethod string `json:"paymentMethod,omitempty"`
+}
@@ -0,0 +1,191 @@
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/XeroAPI/xerogolang"
+)
+
+//Field is a property of the entity being found, such as Status or Contact.Name, that a filter compares
+type Field string
+
+//Guid is an identifier such as a ContactID. It is written as Guid("...") in a filter
+type Guid string
+
+//Filter is a condition in a where clause. Build one from a Field and combine filters with And and Or
+type Filter struct {
+	expression string
+
+	// The operator joining the parts of the filter, "AND" or "OR", if it has more than one
+	operator string
+}
+
+//String returns the filter as Xero's where clause grammar
+func (f Filter) String() string {
+	return f.expression
+}
+
+//IsZero reports whether the filter is empty
+func (f Filter) IsZero() bool {
+	return f.expression == ""
+}
+
+//Eq matches entities where the field equals value
+func (f Field) Eq(value interface{}) Filter {
+	return f.compare("==", value)
+}
+
+//NotEq matches entities where the field does not equal value
+func (f Field) NotEq(value interface{}) Filter {
+	return f.compare("!=", value)
+}
+
+//Gt matches entities where the field is greater than value
+func (f Field) Gt(value interface{}) Filter {
+	return f.compare(">", value)
+}
+
+//GtEq matches entities where the field is greater than or equal to value
+func (f Field) GtEq(value interface{}) Filter {
+	return f.compare(">=", value)
+}
+
+//Lt matches entities where the field is less than value
+func (f Field) Lt(value interface{}) Filter {
+	return f.compare("<", value)
+}
+
+//LtEq matches entities where the field is less than or equal to value
+func (f Field) LtEq(value interface{}) Filter {
+	return f.compare("<=", value)
+}
+
+//IsNull matches entities where the field is not set
+func (f Field) IsNull() Filter {
+	return Filter{expression: string(f) + "==null"}
+}
+
+//IsNotNull matches entities where the field is set
+func (f Field) IsNotNull() Filter {
+	return Filter{expression: string(f) + "!=null"}
+}
+
+//Contains matches entities where the field contains value
+func (f Field) Contains(value string) Filter {
+	return f.call("Contains", value)
+}
+
+//StartsWith matches entities where the field starts with value
+func (f Field) StartsWith(value string) Filter {
+	return f.call("StartsWith", value)
+}
+
+//EndsWith matches entities where the field ends with value
+func (f Field) EndsWith(value string) Filter {
+	return f.call("EndsWith", value)
+}
+
+func (f Field) compare(operator string, value interface{}) Filter {
+	return Filter{expression: string(f) + operator + literal(value)}
+}
+
+func (f Field) call(method string, value string) Filter {
+	return Filter{expression: string(f) + "." + method + "(" + quote(value) + ")"}
+}
+
+//And matches entities that match f and all of the others
+func (f Filter) And(others ...Filter) Filter {
+	return join("AND", append([]Filter{f}, others...))
+}
+
+//Or matches entities that match f or any of the others
+func (f Filter) Or(others ...Filter) Filter {
+	return join("OR", append([]Filter{f}, others...))
+}
+
+//And matches entities that match all of the filters
+func And(filters ...Filter) Filter {
+	return join("AND", filters)
+}
+
+//Or matches entities that match any of the filters
+func Or(filters ...Filter) Filter {
+	return join("OR", filters)
+}
+
+//join combines filters with an operator, putting brackets around any part joined by the other operator
+func join(operator string, filters []Filter) Filter {
+	var parts []string
+	var last Filter
+	for _, filter := range filters {
+		if filter.IsZero() {
+			continue
+		}
+		last = filter
+		if filter.operator != "" && filter.operator != operator {
+			parts = append(parts, "("+filter.expression+")")
+		} else {
+			parts = append(parts, filter.expression)
+		}
+	}
+
+	switch len(parts) {
+	case 0:
+		return Filter{}
+	case 1:
+		//a filter on its own keeps its operator so it is still bracketed when it is joined later
+		return last
+	}
+	return Filter{expression: strings.Join(parts, " "+operator+" "), operator: operator}
+}
+
+//literal writes a value the way the where clause grammar expects it
+func literal(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case Guid:
+		return "Guid(" + quote(string(v)) + ")"
+	case time.Time:
+		return dateTime(v)
+	case xerogolang.Date:
+		return dateTime(v.Time)
+	case xerogolang.DateTime:
+		return dateTime(v.Time)
+	case xerogolang.Decimal:
+		return v.String()
+	}
+
+	//named types such as accounting.InvoiceStatus are written according to their underlying kind
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.String:
+		return quote(reflected.String())
+	case reflect.Bool:
+		return strconv.FormatBool(reflected.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(reflected.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(reflected.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(reflected.Float(), 'f', -1, 64)
+	}
+	return quote(fmt.Sprint(value))
+}
+
+//dateTime writes DateTime(2017, 05, 08), adding the time of day if there is one
+func dateTime(t time.Time) string {
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+		return t.Format("DateTime(2006, 01, 02)")
+	}
+	return t.Format("DateTime(2006, 01, 02, 15, 04, 05)")
+}
+
+//quote puts a string in double quotes, escaping any backslashes and double quotes in it
+func quote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
@@ -0,0 +1,115 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XeroAPI/xerogolang"
+	"github.com/stretchr/testify/assert"
+)
+
+type status string
+
+func Test_Field_Operators(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	field := Field("Status")
+	testCases := []struct {
+		filter Filter
+		want   string
+	}{
+		{field.Eq("AUTHORISED"), `Status=="AUTHORISED"`},
+		{field.NotEq("VOIDED"), `Status!="VOIDED"`},
+		{Field("Total").Gt(100), `Total>100`},
+		{Field("Total").GtEq(100), `Total>=100`},
+		{Field("Total").Lt(100), `Total<100`},
+		{Field("Total").LtEq(100), `Total<=100`},
+		{field.IsNull(), `Status==null`},
+		{field.IsNotNull(), `Status!=null`},
+		{Field("Name").Contains("Van"), `Name.Contains("Van")`},
+		{Field("Name").StartsWith("Van"), `Name.StartsWith("Van")`},
+		{Field("Name").EndsWith("Ltd"), `Name.EndsWith("Ltd")`},
+	}
+
+	for _, testCase := range testCases {
+		a.Equal(testCase.want, testCase.filter.String())
+	}
+}
+
+func Test_Literals(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	testCases := []struct {
+		value interface{}
+		want  string
+	}{
+		{nil, `null`},
+		{"Kramer", `"Kramer"`},
+		{status("PAID"), `"PAID"`},
+		{true, `true`},
+		{int64(-42), `-42`},
+		{uint8(7), `7`},
+		{12.5, `12.5`},
+		{xerogolang.MustParseDecimal("1234.50"), `1234.50`},
+		{Guid("297c2dc5-cc47-4afd-8ec8-74990b8761e9"), `Guid("297c2dc5-cc47-4afd-8ec8-74990b8761e9")`},
+		{time.Date(2017, time.May, 8, 0, 0, 0, 0, time.UTC), `DateTime(2017, 05, 08)`},
+		{time.Date(2017, time.May, 8, 13, 4, 5, 0, time.UTC), `DateTime(2017, 05, 08, 13, 04, 05)`},
+		{xerogolang.NewDate(2017, time.May, 8), `DateTime(2017, 05, 08)`},
+		{xerogolang.NewDateTime(time.Date(2017, time.May, 8, 9, 30, 0, 0, time.UTC)), `DateTime(2017, 05, 08, 09, 30, 00)`},
+		{struct{ A int }{1}, `"{1}"`},
+	}
+
+	for _, testCase := range testCases {
+		a.Equal(testCase.want, literal(testCase.value), "%#v", testCase.value)
+	}
+}
+
+func Test_Quote(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	a.Equal(`""`, quote(""))
+	a.Equal(`"O'Brien"`, quote("O'Brien"))
+	a.Equal(`"Say \"hi\""`, quote(`Say "hi"`))
+	a.Equal(`"C:\\temp\\\"x\""`, quote(`C:\temp\"x"`))
+	a.Equal(`Name=="\"Quoted\" \\ Ltd"`, Field("Name").Eq(`"Quoted" \ Ltd`).String())
+	a.Equal(`Name.Contains("a\"b")`, Field("Name").Contains(`a"b`).String())
+}
+
+func Test_Grouping(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	paid := Field("Status").Eq("PAID")
+	voided := Field("Status").Eq("VOIDED")
+	big := Field("Total").Gt(100)
+	kramer := Field("Contact.Name").Eq("Kramer")
+
+	testCases := []struct {
+		filter Filter
+		want   string
+	}{
+		{paid.And(big), `Status=="PAID" AND Total>100`},
+		{paid.Or(voided), `Status=="PAID" OR Status=="VOIDED"`},
+		{paid.Or(voided).And(big), `(Status=="PAID" OR Status=="VOIDED") AND Total>100`},
+		{big.And(paid.Or(voided)), `Total>100 AND (Status=="PAID" OR Status=="VOIDED")`},
+		{Or(paid.And(big), voided.And(kramer)), `(Status=="PAID" AND Total>100) OR (Status=="VOIDED" AND Contact.Name=="Kramer")`},
+		//filters joined by the same operator don't need brackets
+		{And(paid.And(big), kramer), `Status=="PAID" AND Total>100 AND Contact.Name=="Kramer"`},
+		{Or(paid, Or(voided, kramer)), `Status=="PAID" OR Status=="VOIDED" OR Contact.Name=="Kramer"`},
+		//a group on its own is still bracketed when joined later
+		{Or(paid.Or(voided)).And(big), `(Status=="PAID" OR Status=="VOIDED") AND Total>100`},
+		{And(Filter{}, paid.Or(voided), Filter{}).And(big), `(Status=="PAID" OR Status=="VOIDED") AND Total>100`},
+		//empty filters are left out
+		{And(Filter{}, paid), `Status=="PAID"`},
+		{Or(), ``},
+	}
+
+	for _, testCase := range testCases {
+		a.Equal(testCase.want, testCase.filter.String())
+	}
+	a.True(And().IsZero())
+	a.False(paid.IsZero())
+}
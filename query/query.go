@@ -0,0 +1,83 @@
+//Package query builds the where, order and page querystring parameters accepted by the Find functions
+//in the accounting and payroll packages:
+//
+//	q := query.Where(query.Field("Status").Eq(accounting.InvoiceStatusAuthorised).
+//		And(query.Field("Contact.Name").StartsWith("Vanderlay"))).
+//		OrderByDesc("DueDate").
+//		Page(2)
+//	invoices, err := accounting.FindInvoices(provider, session, q.Params())
+package query
+
+import (
+	"strconv"
+	"strings"
+)
+
+//Query holds a where clause, the order to sort by and a page, along with any other querystring parameters
+type Query struct {
+	where      Filter
+	order      []string
+	page       int
+	parameters map[string]string
+}
+
+//New returns an empty query
+func New() *Query {
+	return &Query{}
+}
+
+//Where returns a query for the entities matching filter
+func Where(filter Filter) *Query {
+	return New().Where(filter)
+}
+
+//Where adds a filter to the query. Calling it more than once matches entities that match every filter
+func (q *Query) Where(filter Filter) *Query {
+	q.where = And(q.where, filter)
+	return q
+}
+
+//OrderBy sorts by field in ascending order. Calling it more than once sorts by each field in turn
+func (q *Query) OrderBy(field Field) *Query {
+	q.order = append(q.order, string(field))
+	return q
+}
+
+//OrderByDesc sorts by field in descending order
+func (q *Query) OrderByDesc(field Field) *Query {
+	q.order = append(q.order, string(field)+" DESC")
+	return q
+}
+
+//Page asks for one page of up to 100 entities, starting from page 1
+func (q *Query) Page(page int) *Query {
+	q.page = page
+	return q
+}
+
+//Set adds any other querystring parameter, such as includeArchived or IDs
+func (q *Query) Set(key string, value string) *Query {
+	if q.parameters == nil {
+		q.parameters = map[string]string{}
+	}
+	q.parameters[key] = value
+	return q
+}
+
+//Params returns the querystring parameters to pass to a Find function
+func (q *Query) Params() map[string]string {
+	querystringParameters := map[string]string{}
+	for key, value := range q.parameters {
+		querystringParameters[key] = value
+	}
+	if !q.where.IsZero() {
+		querystringParameters["where"] = q.where.String()
+	}
+	if len(q.order) > 0 {
+		querystringParameters["order"] = strings.Join(q.order, ",")
+	}
+	if q.page > 0 {
+		querystringParameters["page"] = strconv.Itoa(q.page)
+	}
+	return querystringParameters
+}
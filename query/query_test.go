@@ -0,0 +1,44 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Params(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	a.Equal(map[string]string{}, New().Params())
+
+	q := Where(Field("Status").Eq("AUTHORISED")).
+		Where(Field("Contact.Name").StartsWith("Vanderlay").Or(Field("Contact.Name").StartsWith("Kramerica"))).
+		OrderByDesc("DueDate").
+		OrderBy("InvoiceNumber").
+		Page(2).
+		Set("includeArchived", "true")
+	a.Equal(map[string]string{
+		"where":           `Status=="AUTHORISED" AND (Contact.Name.StartsWith("Vanderlay") OR Contact.Name.StartsWith("Kramerica"))`,
+		"order":           "DueDate DESC,InvoiceNumber",
+		"page":            "2",
+		"includeArchived": "true",
+	}, q.Params())
+
+	//the map returned is a copy, changing it doesn't change the query
+	params := q.Params()
+	params["includeArchived"] = "false"
+	a.Equal("true", q.Params()["includeArchived"])
+}
+
+func Test_Params_BuiltParametersReplaceSetOnes(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	q := New().Set("page", "9").Set("where", `Name=="x"`).Page(3)
+	a.Equal(map[string]string{"page": "3", "where": `Name=="x"`}, q.Params())
+
+	//a where clause set by hand is replaced by the one built with Where
+	q.Where(Field("Name").Eq("y"))
+	a.Equal(`Name=="y"`, q.Params()["where"])
+}
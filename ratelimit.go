@@ -0,0 +1,303 @@
+package xerogolang
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	//defaultMinuteLimit is the number of calls Xero allows per tenant per minute
+	defaultMinuteLimit = 60
+	//defaultDayLimit is the number of calls Xero allows per tenant per day
+	defaultDayLimit = 5000
+	//defaultConcurrentLimit is the number of calls Xero allows to be in progress per tenant at once
+	defaultConcurrentLimit = 5
+	//defaultMaxRateLimitRetries is how many times a request that receives a 429 is sent again
+	defaultMaxRateLimitRetries = 3
+	//defaultMaxRetryAfter is the longest Retry-After that will be waited out before giving up
+	defaultMaxRetryAfter = 2 * time.Minute
+)
+
+//Quota is the state of the Xero rate limits for a tenant
+type Quota struct {
+	// Calls left this minute, from X-MinLimit-Remaining or the limiter's own count
+	MinuteRemaining int
+
+	// Calls left today, from X-DayLimit-Remaining or the limiter's own count
+	DayRemaining int
+
+	// Calls the app has left this minute across all tenants, from X-AppMinLimit-Remaining. -1 until Xero has reported it
+	AppMinuteRemaining int
+
+	// Calls to the tenant will be held back until this time after a 429. Zero when not blocked
+	RetryAfter time.Time
+
+	// The last time Xero reported the limits for this tenant
+	UpdatedAt time.Time
+}
+
+//RateLimiter keeps a minute and a day token bucket for each tenant and delays calls that would
+//exceed them. It also limits the number of concurrent calls per tenant and holds calls back after
+//Xero responds with a 429. Requests made without a tenant (OAuth 1.0a) share a single bucket.
+type RateLimiter struct {
+	// Calls allowed per tenant per minute
+	MinuteLimit int
+
+	// Calls allowed per tenant per day
+	DayLimit int
+
+	// Calls allowed to be in progress per tenant at once
+	ConcurrentLimit int
+
+	// How many times a request that receives a 429 is sent again. Uploads whose body cannot be read
+	// again, see Provider.Upload, are not
+	MaxRetries int
+
+	// 429s asking for a longer wait than this are returned as an error instead of being retried
+	MaxRetryAfter time.Duration
+
+	mutex   sync.Mutex
+	tenants map[string]*tenantLimit
+	now     func() time.Time
+	sleep   func(time.Duration)
+}
+
+//tenantLimit is the state kept for a single tenant
+type tenantLimit struct {
+	minute     bucket
+	day        bucket
+	inProgress int
+	retryAfter time.Time
+	quota      Quota
+}
+
+//bucket is a token bucket that refills continuously at rate tokens per second
+type bucket struct {
+	capacity float64
+	tokens   float64
+	rate     float64
+	last     time.Time
+}
+
+//NewRateLimiter creates a RateLimiter using the limits Xero applies to each tenant
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		MinuteLimit:     defaultMinuteLimit,
+		DayLimit:        defaultDayLimit,
+		ConcurrentLimit: defaultConcurrentLimit,
+		MaxRetries:      defaultMaxRateLimitRetries,
+		MaxRetryAfter:   defaultMaxRetryAfter,
+	}
+}
+
+func (r *RateLimiter) clock() time.Time {
+	if r.now != nil {
+		return r.now()
+	}
+	return time.Now()
+}
+
+//pause waits for d or until the context is done
+func (r *RateLimiter) pause(ctx context.Context, d time.Duration) error {
+	if r.sleep != nil {
+		r.sleep(d)
+		return ctx.Err()
+	}
+	return sleepCtx(ctx, d)
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+//tenant returns the state for a tenant, creating full buckets the first time it is seen.
+//The mutex must be held
+func (r *RateLimiter) tenant(tenantID string) *tenantLimit {
+	if r.tenants == nil {
+		r.tenants = map[string]*tenantLimit{}
+	}
+	limit, ok := r.tenants[tenantID]
+	if !ok {
+		now := r.clock()
+		limit = &tenantLimit{
+			minute: newBucket(r.MinuteLimit, time.Minute, now),
+			day:    newBucket(r.DayLimit, 24*time.Hour, now),
+			quota:  Quota{AppMinuteRemaining: -1},
+		}
+		r.tenants[tenantID] = limit
+	}
+	return limit
+}
+
+func newBucket(limit int, period time.Duration, now time.Time) bucket {
+	return bucket{
+		capacity: float64(limit),
+		tokens:   float64(limit),
+		rate:     float64(limit) / period.Seconds(),
+		last:     now,
+	}
+}
+
+//refill adds the tokens earned since the bucket was last looked at
+func (b *bucket) refill(now time.Time) {
+	if now.After(b.last) {
+		b.tokens = math.Min(b.capacity, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+	}
+}
+
+//wait is how long until the bucket has a whole token to spend
+func (b *bucket) wait() time.Duration {
+	if b.tokens >= 1 || b.rate <= 0 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+//Wait blocks until a call can be made to the tenant without exceeding its limits and reserves it.
+//The returned function must be called once the response has been received
+func (r *RateLimiter) Wait(tenantID string) func() {
+	release, _ := r.WaitCtx(context.Background(), tenantID)
+	return release
+}
+
+//WaitCtx is Wait but gives up with the context's error if it is done before the call can be made
+func (r *RateLimiter) WaitCtx(ctx context.Context, tenantID string) (func(), error) {
+	for {
+		r.mutex.Lock()
+		limit := r.tenant(tenantID)
+		now := r.clock()
+		limit.minute.refill(now)
+		limit.day.refill(now)
+
+		delay := limit.minute.wait()
+		if dayDelay := limit.day.wait(); dayDelay > delay {
+			delay = dayDelay
+		}
+		if retryDelay := limit.retryAfter.Sub(now); retryDelay > delay {
+			delay = retryDelay
+		}
+		if delay <= 0 && r.ConcurrentLimit > 0 && limit.inProgress >= r.ConcurrentLimit {
+			delay = 50 * time.Millisecond
+		}
+
+		if delay <= 0 {
+			limit.minute.tokens--
+			limit.day.tokens--
+			limit.inProgress++
+			r.mutex.Unlock()
+			return func() { r.done(tenantID) }, nil
+		}
+
+		r.mutex.Unlock()
+		err := r.pause(ctx, delay)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (r *RateLimiter) done(tenantID string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	limit := r.tenant(tenantID)
+	if limit.inProgress > 0 {
+		limit.inProgress--
+	}
+}
+
+//Update records the limits Xero reported in a response. A 429 holds back further calls to the
+//tenant until the Retry-After has passed
+func (r *RateLimiter) Update(tenantID string, response *http.Response) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	limit := r.tenant(tenantID)
+	now := r.clock()
+	limit.minute.refill(now)
+	limit.day.refill(now)
+
+	if remaining, ok := headerInt(response.Header, "X-MinLimit-Remaining"); ok {
+		limit.minute.tokens = math.Min(limit.minute.tokens, float64(remaining))
+		limit.quota.UpdatedAt = now
+	}
+	if remaining, ok := headerInt(response.Header, "X-DayLimit-Remaining"); ok {
+		limit.day.tokens = math.Min(limit.day.tokens, float64(remaining))
+		limit.quota.UpdatedAt = now
+	}
+	if remaining, ok := headerInt(response.Header, "X-AppMinLimit-Remaining"); ok {
+		limit.quota.AppMinuteRemaining = remaining
+		limit.quota.UpdatedAt = now
+	}
+
+	if response.StatusCode == http.StatusTooManyRequests {
+		limit.retryAfter = now.Add(retryAfter(response.Header))
+		switch response.Header.Get("X-Rate-Limit-Problem") {
+		case "minute":
+			limit.minute.tokens = 0
+		case "day":
+			limit.day.tokens = 0
+		}
+	}
+}
+
+//Quota returns the current state of the limits for a tenant
+func (r *RateLimiter) Quota(tenantID string) Quota {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	limit := r.tenant(tenantID)
+	now := r.clock()
+	limit.minute.refill(now)
+	limit.day.refill(now)
+
+	quota := limit.quota
+	quota.MinuteRemaining = int(math.Max(0, math.Floor(limit.minute.tokens)))
+	quota.DayRemaining = int(math.Max(0, math.Floor(limit.day.tokens)))
+	if limit.retryAfter.After(now) {
+		quota.RetryAfter = limit.retryAfter
+	}
+	return quota
+}
+
+//shouldRetry reports whether a 429 response is worth waiting out and sending again. A request whose
+//body has already been read and cannot be read again is never sent twice
+func (r *RateLimiter) shouldRetry(request *http.Request, response *http.Response, attempt int) bool {
+	return response.StatusCode == http.StatusTooManyRequests &&
+		resendable(request) &&
+		attempt < r.MaxRetries &&
+		retryAfter(response.Header) <= r.MaxRetryAfter
+}
+
+//retryAfter reads the number of seconds Xero asked us to wait, defaulting to a second
+func retryAfter(header http.Header) time.Duration {
+	seconds, ok := headerInt(header, "Retry-After")
+	if !ok {
+		return time.Second
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func headerInt(header http.Header, key string) (int, bool) {
+	value := header.Get(key)
+	if value == "" {
+		return 0, false
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+	return number, true
+}
@@ -0,0 +1,193 @@
+package xerogolang
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//fakeClock lets the limiter sleep without the test actually waiting
+type fakeClock struct {
+	mutex sync.Mutex
+	time  time.Time
+	slept []time.Duration
+}
+
+func (c *fakeClock) now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.time
+}
+
+func (c *fakeClock) sleep(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.time = c.time.Add(d)
+	c.slept = append(c.slept, d)
+}
+
+func fakeRateLimiter() (*RateLimiter, *fakeClock) {
+	clock := &fakeClock{time: time.Date(2017, 5, 8, 0, 0, 0, 0, time.UTC)}
+	limiter := NewRateLimiter()
+	limiter.now = clock.now
+	limiter.sleep = clock.sleep
+	return limiter, clock
+}
+
+func Test_RateLimiter_MinuteBucket(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	limiter, clock := fakeRateLimiter()
+	limiter.MinuteLimit = 2
+
+	limiter.Wait("t-1")()
+	limiter.Wait("t-1")()
+	a.Empty(clock.slept)
+	a.Equal(0, limiter.Quota("t-1").MinuteRemaining)
+	a.Equal(4998, limiter.Quota("t-1").DayRemaining)
+
+	//the third call has to wait for half a minute for the bucket to earn another token
+	limiter.Wait("t-1")()
+	a.Equal([]time.Duration{30 * time.Second}, clock.slept)
+
+	//other tenants have buckets of their own
+	limiter.Wait("t-2")()
+	a.Len(clock.slept, 1)
+	a.Equal(1, limiter.Quota("t-2").MinuteRemaining)
+}
+
+func Test_RateLimiter_Update(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	limiter, clock := fakeRateLimiter()
+
+	response := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+	response.Header.Set("X-MinLimit-Remaining", "10")
+	response.Header.Set("X-DayLimit-Remaining", "100")
+	response.Header.Set("X-AppMinLimit-Remaining", "9000")
+	limiter.Update("t-1", response)
+
+	quota := limiter.Quota("t-1")
+	a.Equal(10, quota.MinuteRemaining)
+	a.Equal(100, quota.DayRemaining)
+	a.Equal(9000, quota.AppMinuteRemaining)
+	a.Equal(clock.now(), quota.UpdatedAt)
+	a.True(quota.RetryAfter.IsZero())
+	a.Equal(-1, limiter.Quota("t-2").AppMinuteRemaining)
+
+	response = &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	response.Header.Set("Retry-After", "20")
+	response.Header.Set("X-Rate-Limit-Problem", "minute")
+	limiter.Update("t-1", response)
+
+	quota = limiter.Quota("t-1")
+	a.Equal(0, quota.MinuteRemaining)
+	a.Equal(clock.now().Add(20*time.Second), quota.RetryAfter)
+
+	limiter.Wait("t-1")()
+	a.Equal([]time.Duration{20 * time.Second}, clock.slept)
+}
+
+func Test_RateLimiter_ConcurrentLimit(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	limiter, clock := fakeRateLimiter()
+	limiter.ConcurrentLimit = 1
+
+	release := limiter.Wait("t-1")
+	done := make(chan bool)
+	go func() {
+		limiter.Wait("t-1")()
+		done <- true
+	}()
+
+	select {
+	case <-done:
+		a.Fail("the second call should wait for the first to finish")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	release()
+	<-done
+	a.NotEmpty(clock.slept)
+}
+
+func Test_Find_RetriesRateLimitedRequests(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := NewOAuth2("CLIENT", "SECRET", "/foo")
+		provider.Endpoints = mockEndpoints(ts)
+		limiter, clock := fakeRateLimiter()
+		provider.RateLimiter = limiter
+		session := testOAuth2Session().WithTenant("retry-tenant")
+
+		response, err := provider.Find(session, "Limited", nil, nil)
+		a.NoError(err)
+		a.Equal(`{"Status":"OK"}`, string(response))
+		a.Equal([]time.Duration{7 * time.Second}, clock.slept)
+
+		//the limiter never counts more calls remaining than it has earned itself since the 429
+		quota := limiter.Quota("retry-tenant")
+		a.Equal(6, quota.MinuteRemaining)
+		a.Equal(4320, quota.DayRemaining)
+		a.Equal(9876, quota.AppMinuteRemaining)
+	})
+}
+
+func Test_Upload_RetriesRateLimitedRequestsOnlyIfTheBodyCanBeResent(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := NewOAuth2("CLIENT", "SECRET", "/foo")
+		provider.Endpoints = mockEndpoints(ts)
+		limiter, clock := fakeRateLimiter()
+		provider.RateLimiter = limiter
+
+		//a strings.Reader can be read again so the whole body is sent with the retry
+		response, err := provider.Upload(testOAuth2Session().WithTenant("resend-tenant"), "Limited", "text/plain", nil, strings.NewReader("receipt"))
+		a.NoError(err)
+		a.Equal(`{"Status":"OK","Body":"receipt"}`, string(response))
+		a.Equal([]time.Duration{7 * time.Second}, clock.slept)
+
+		//any other reader was used up by the first attempt, so the 429 is returned rather than
+		//sending the request again without its body
+		body := io.MultiReader(strings.NewReader("receipt"))
+		_, err = provider.Upload(testOAuth2Session().WithTenant("no-resend-tenant"), "Limited", "text/plain", nil, body)
+		apiError, ok := AsAPIError(err)
+		a.True(ok)
+		a.True(apiError.IsRateLimited())
+		a.Len(clock.slept, 1)
+	})
+}
+
+func Test_Find_ReturnsRateLimitErrorWhenRetriesRunOut(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := NewOAuth2("CLIENT", "SECRET", "/foo")
+		provider.Endpoints = mockEndpoints(ts)
+		limiter, _ := fakeRateLimiter()
+		limiter.MaxRetries = 0
+		provider.RateLimiter = limiter
+
+		_, err := provider.Find(testOAuth2Session().WithTenant("no-retry-tenant"), "Limited", nil, nil)
+		apiError, ok := AsAPIError(err)
+		a.True(ok)
+		a.True(apiError.IsRateLimited())
+		a.Equal(7*time.Second, apiError.RetryAfter)
+		a.Equal("minute", apiError.RateLimitProblem)
+	})
+}
@@ -0,0 +1,178 @@
+package xerogolang
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+const (
+	//idempotencyKeyHeader lets Xero recognise a PUT or POST it has already processed so it is safe to send again
+	idempotencyKeyHeader = "Idempotency-Key"
+)
+
+//RetryAttempt describes an attempt that failed and is about to be retried
+type RetryAttempt struct {
+	// The attempt that failed, starting at 1
+	Attempt int
+
+	// The method and URL of the request
+	Method string
+	URL    string
+
+	// The status code of the failed response, or 0 if no response was received
+	StatusCode int
+
+	// The error that occurred sending the request, if there was one
+	Err error
+
+	// How long until the request is sent again
+	Backoff time.Duration
+}
+
+//RetryPolicy decides which failed requests are sent again and how long to wait in between.
+//Only safe methods (GET, HEAD and OPTIONS) are retried unless the request carries an
+//Idempotency-Key header, in which case PUT, POST and DELETE are retried too
+type RetryPolicy struct {
+	// The most attempts made at a request, including the first
+	MaxAttempts int
+
+	// The wait before the first retry. It doubles with each attempt up to MaxBackoff
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// The fraction of each wait that is randomised, between 0 and 1, so retries from many clients spread out
+	Jitter float64
+
+	// Reports whether a response with this status code should be retried
+	RetryableStatus func(statusCode int) bool
+
+	// Reports whether an error sending the request should be retried
+	RetryableError func(err error) bool
+
+	// Called before each retry so attempts can be logged
+	OnRetry func(attempt RetryAttempt)
+
+	sleep func(time.Duration)
+}
+
+//DefaultRetryPolicy makes up to 3 attempts at a request, backing off from half a second, when Xero
+//responds with a 5xx or the connection fails
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:     3,
+		MinBackoff:      500 * time.Millisecond,
+		MaxBackoff:      10 * time.Second,
+		Jitter:          0.5,
+		RetryableStatus: IsRetryableStatus,
+		RetryableError:  IsRetryableError,
+	}
+}
+
+//IsRetryableStatus reports whether a status code is a transient server error, including the
+//503 returned while an organisation is offline
+func IsRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+//IsRetryableError reports whether an error sending a request is likely to be transient, such as a
+//timeout or the connection being reset
+func IsRetryableError(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+	var netError net.Error
+	return errors.As(err, &netError) && netError.Timeout()
+}
+
+//NewIdempotencyKey generates a random key to send in the Idempotency-Key header of a PUT or POST
+//so that it can be retried safely:
+//
+//	headers := map[string]string{"Idempotency-Key": xerogolang.NewIdempotencyKey()}
+//	response, err := provider.Create(session, "Invoices", headers, body)
+func NewIdempotencyKey() string {
+	return newRandomID()
+}
+
+//retryable reports whether the request may be sent more than once
+func retryable(request *http.Request) bool {
+	switch request.Method {
+	case "GET", "HEAD", "OPTIONS":
+		return true
+	}
+	return request.Header.Get(idempotencyKeyHeader) != ""
+}
+
+//resendable reports whether the request has no body or a body that can be read again from the start
+func resendable(request *http.Request) bool {
+	return request.Body == nil || request.Body == http.NoBody || request.GetBody != nil
+}
+
+//next decides whether to retry after an attempt and how long to wait first. A nil policy never retries
+func (r *RetryPolicy) next(request *http.Request, attempt int, response *http.Response, err error) (time.Duration, bool) {
+	if r == nil || attempt >= r.MaxAttempts || !retryable(request) {
+		return 0, false
+	}
+	if !resendable(request) {
+		return 0, false
+	}
+
+	switch {
+	case err != nil:
+		if r.RetryableError == nil || !r.RetryableError(err) {
+			return 0, false
+		}
+	case r.RetryableStatus == nil || !r.RetryableStatus(response.StatusCode):
+		return 0, false
+	}
+
+	backoff := r.backoff(attempt)
+	if r.OnRetry != nil {
+		retryAttempt := RetryAttempt{
+			Attempt: attempt,
+			Method:  request.Method,
+			URL:     request.URL.String(),
+			Err:     err,
+			Backoff: backoff,
+		}
+		if response != nil {
+			retryAttempt.StatusCode = response.StatusCode
+		}
+		r.OnRetry(retryAttempt)
+	}
+	return backoff, true
+}
+
+//backoff doubles the wait with each attempt and randomises part of it
+func (r *RetryPolicy) backoff(attempt int) time.Duration {
+	backoff := float64(r.MinBackoff) * math.Pow(2, float64(attempt-1))
+	if r.MaxBackoff > 0 && backoff > float64(r.MaxBackoff) {
+		backoff = float64(r.MaxBackoff)
+	}
+	if r.Jitter > 0 {
+		backoff = backoff * (1 - r.Jitter*rand.Float64())
+	}
+	return time.Duration(backoff)
+}
+
+//pause waits for d or until the context is done
+func (r *RetryPolicy) pause(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	if r != nil && r.sleep != nil {
+		r.sleep(d)
+		return ctx.Err()
+	}
+	return sleepCtx(ctx, d)
+}
@@ -0,0 +1,138 @@
+package xerogolang
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//fakeRetryPolicy records the backoffs instead of sleeping through them
+func fakeRetryPolicy() (*RetryPolicy, *[]time.Duration) {
+	var slept []time.Duration
+	policy := DefaultRetryPolicy()
+	policy.Jitter = 0
+	policy.sleep = func(d time.Duration) {
+		slept = append(slept, d)
+	}
+	return policy, &slept
+}
+
+func Test_RetryPolicy_Backoff(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	policy := &RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	a.Equal(time.Second, policy.backoff(1))
+	a.Equal(2*time.Second, policy.backoff(2))
+	a.Equal(4*time.Second, policy.backoff(3))
+	a.Equal(5*time.Second, policy.backoff(4))
+
+	//jitter only ever shortens the wait
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		backoff := policy.backoff(2)
+		a.True(backoff > time.Second && backoff <= 2*time.Second, backoff)
+	}
+}
+
+func Test_RetryPolicy_OnlyRetriesIdempotentRequests(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	policy, _ := fakeRetryPolicy()
+	unavailable := &http.Response{StatusCode: http.StatusServiceUnavailable}
+
+	get, _ := http.NewRequest("GET", "https://api.xero.com/api.xro/2.0/Invoices", nil)
+	_, retry := policy.next(get, 1, unavailable, nil)
+	a.True(retry)
+	_, retry = policy.next(get, 3, unavailable, nil)
+	a.False(retry, "the third attempt is the last")
+	_, retry = policy.next(get, 1, &http.Response{StatusCode: http.StatusBadRequest}, nil)
+	a.False(retry)
+
+	put, _ := http.NewRequest("PUT", "https://api.xero.com/api.xro/2.0/Invoices", strings.NewReader("{}"))
+	_, retry = policy.next(put, 1, unavailable, nil)
+	a.False(retry)
+	put.Header.Set("Idempotency-Key", NewIdempotencyKey())
+	_, retry = policy.next(put, 1, unavailable, nil)
+	a.True(retry)
+
+	var nilPolicy *RetryPolicy
+	_, retry = nilPolicy.next(get, 1, unavailable, nil)
+	a.False(retry)
+}
+
+func Test_IsRetryableError(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	a.True(IsRetryableError(syscall.ECONNRESET))
+	a.True(IsRetryableError(io.ErrUnexpectedEOF))
+	a.False(IsRetryableError(errors.New("Missing authorization code")))
+}
+
+func Test_NewIdempotencyKey(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	key := NewIdempotencyKey()
+	a.Len(key, 36)
+	a.NotEqual(key, NewIdempotencyKey())
+}
+
+func Test_Find_RetriesUnavailableRequests(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := NewOAuth2("CLIENT", "SECRET", "/foo")
+		provider.Endpoints = mockEndpoints(ts)
+		policy, slept := fakeRetryPolicy()
+		var attempts []RetryAttempt
+		policy.OnRetry = func(attempt RetryAttempt) {
+			attempts = append(attempts, attempt)
+		}
+		provider.RetryPolicy = policy
+
+		response, err := provider.Find(testOAuth2Session().WithTenant("unavailable-get"), "Unavailable", nil, nil)
+		a.NoError(err)
+		a.Contains(string(response), `"Calls":3`)
+		a.Equal([]time.Duration{500 * time.Millisecond, time.Second}, *slept)
+		a.Len(attempts, 2)
+		a.Equal(1, attempts[0].Attempt)
+		a.Equal("GET", attempts[0].Method)
+		a.Equal(http.StatusServiceUnavailable, attempts[0].StatusCode)
+	})
+}
+
+func Test_Create_RetriesOnlyWithIdempotencyKey(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := NewOAuth2("CLIENT", "SECRET", "/foo")
+		provider.Endpoints = mockEndpoints(ts)
+		policy, _ := fakeRetryPolicy()
+		provider.RetryPolicy = policy
+
+		_, err := provider.Create(testOAuth2Session().WithTenant("unavailable-put"), "Unavailable", nil, []byte(`{"Invoices":[]}`))
+		apiError, ok := AsAPIError(err)
+		a.True(ok)
+		a.True(apiError.IsServerError())
+		a.Equal("The Organisation is offline", apiError.Message)
+
+		//the body is sent again in full on each retry
+		headers := map[string]string{"Idempotency-Key": NewIdempotencyKey()}
+		response, err := provider.Create(testOAuth2Session().WithTenant("unavailable-put-idempotent"), "Unavailable", headers, []byte(`{"Invoices":[]}`))
+		a.NoError(err)
+		a.Contains(string(response), `"Calls":3`)
+		a.Contains(string(response), `Invoices`)
+	})
+}
@@ -10,14 +10,20 @@ import (
 
 	"github.com/markbates/goth"
 	"github.com/mrjones/oauth"
+	"golang.org/x/oauth2"
 )
 
 // Session stores data during the auth process with Xero.
+// OAuth 1.0a sessions use AccessToken and RequestToken, OAuth 2.0 sessions use OAuth2Token.
 type Session struct {
 	AuthURL            string
 	AccessToken        *oauth.AccessToken
 	RequestToken       *oauth.RequestToken
 	AccessTokenExpires time.Time
+	OAuth2Token        *oauth2.Token `json:",omitempty"`
+	CodeVerifier       string        `json:",omitempty"`
+	//TenantID is sent as the Xero-Tenant-Id header to choose which connected organisation a request targets
+	TenantID string `json:",omitempty"`
 }
 
 // GetAuthURL will return the URL set by calling the `BeginAuth` function on the Xero provider.
@@ -41,6 +47,14 @@ func (s *Session) Authorize(provider goth.Provider, params goth.Params) (string,
 
 		return p.ClientKey, nil
 	}
+	if p.Method == oauth2Method {
+		err := p.exchangeOAuth2Code(s, params.Get("code"))
+		if err != nil {
+			return "", err
+		}
+
+		return s.OAuth2Token.AccessToken, nil
+	}
 	if s.RequestToken == nil {
 		return "", fmt.Errorf("Missing Request Token")
 	}
@@ -55,6 +69,20 @@ func (s *Session) Authorize(provider goth.Provider, params goth.Params) (string,
 	return accessToken.Token, nil
 }
 
+// SelectTenant makes every following request made with the session target the given tenant.
+func (s *Session) SelectTenant(tenantID string) {
+	s.TenantID = tenantID
+}
+
+// WithTenant returns a copy of the session that targets the given tenant, so one authorised
+// session can drive many organisations. The copy shares the OAuth 2.0 token with the original
+// so a refresh made through either is seen by both.
+func (s *Session) WithTenant(tenantID string) *Session {
+	tenantSession := *s
+	tenantSession.TenantID = tenantID
+	return &tenantSession
+}
+
 // Marshal the session into a string
 func (s Session) Marshal() string {
 	b, _ := json.Marshal(s)
@@ -2,17 +2,20 @@ package xerogolang
 
 import (
 	"bytes"
+	"context"
 	"crypto/x509"
 	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"crypto"
@@ -46,9 +49,31 @@ type Provider struct {
 	Method          string
 	UserAgentString string
 	PrivateKey      string
-	debug           bool
-	consumer        *oauth.Consumer
-	providerName    string
+	//Scopes are the OAuth 2.0 scopes requested when Method is oauth2
+	Scopes []string
+	//RateLimiter delays calls that would exceed Xero's limits and retries calls that receive a 429.
+	//Set it to nil to send every call straight away
+	RateLimiter *RateLimiter
+	//RetryPolicy sends requests that fail with a 5xx or a connection error again.
+	//Set it to nil to never retry
+	RetryPolicy *RetryPolicy
+	//Endpoints are the base URLs requests are sent to. Any left empty use Xero's own
+	Endpoints    Endpoints
+	middleware   []Middleware
+	debug        bool
+	consumer     *oauth.Consumer
+	providerName string
+	tokenMutex   sync.RWMutex
+}
+
+//serviceProvider is the set of OAuth 1.0a URLs used by the consumer
+func (p *Provider) serviceProvider(authURL string) oauth.ServiceProvider {
+	endpoints := p.endpoints()
+	return oauth.ServiceProvider{
+		RequestTokenUrl:   endpoints.RequestToken,
+		AuthorizeTokenUrl: authURL,
+		AccessTokenUrl:    endpoints.AccessToken,
+	}
 }
 
 //newPublicConsumer creates a consumer capable of communicating with a Public application: https://developer.xero.com/documentation/auth-and-limits/public-applications
@@ -60,20 +85,14 @@ func (p *Provider) newPublicConsumer(authURL string) *oauth.Consumer {
 		c = oauth.NewCustomHttpClientConsumer(
 			p.ClientKey,
 			p.Secret,
-			oauth.ServiceProvider{
-				RequestTokenUrl:   requestURL,
-				AuthorizeTokenUrl: authURL,
-				AccessTokenUrl:    tokenURL},
+			p.serviceProvider(authURL),
 			p.HTTPClient,
 		)
 	} else {
 		c = oauth.NewConsumer(
 			p.ClientKey,
 			p.Secret,
-			oauth.ServiceProvider{
-				RequestTokenUrl:   requestURL,
-				AuthorizeTokenUrl: authURL,
-				AccessTokenUrl:    tokenURL},
+			p.serviceProvider(authURL),
 		)
 	}
 
@@ -98,20 +117,14 @@ func (p *Provider) newPrivateOrPartnerConsumer(authURL string) *oauth.Consumer {
 			p.ClientKey,
 			privateKey,
 			crypto.SHA1,
-			oauth.ServiceProvider{
-				RequestTokenUrl:   requestURL,
-				AuthorizeTokenUrl: authURL,
-				AccessTokenUrl:    tokenURL},
+			p.serviceProvider(authURL),
 			p.HTTPClient,
 		)
 	} else {
 		c = oauth.NewRSAConsumer(
 			p.ClientKey,
 			privateKey,
-			oauth.ServiceProvider{
-				RequestTokenUrl:   requestURL,
-				AuthorizeTokenUrl: authURL,
-				AccessTokenUrl:    tokenURL},
+			p.serviceProvider(authURL),
 		)
 	}
 
@@ -129,12 +142,15 @@ func New(clientKey, secret, callbackURL string) *Provider {
 		Secret:      secret,
 		CallbackURL: callbackURL,
 		//Method determines how you will connect to Xero.
-		//Options are public, private, and partner
+		//Options are public, private, partner and oauth2
 		//Use public if this is your first time.
 		//More details here: https://developer.xero.com/documentation/getting-started/api-application-types
 		Method:          os.Getenv("XERO_METHOD"),
 		PrivateKey:      helpers.ReadPrivateKeyFromPath(privateKeyFilePath),
 		UserAgentString: userAgentString,
+		RateLimiter:     NewRateLimiter(),
+		RetryPolicy:     DefaultRetryPolicy(),
+		Endpoints:       DefaultEndpoints(),
 		providerName:    "xero",
 	}
 	return p
@@ -150,6 +166,9 @@ func NewCustomHTTPClient(clientKey, secret, callbackURL string, httpClient *http
 		Method:          os.Getenv("XERO_METHOD"),
 		PrivateKey:      helpers.ReadPrivateKeyFromPath(privateKeyFilePath),
 		UserAgentString: userAgentString,
+		RateLimiter:     NewRateLimiter(),
+		RetryPolicy:     DefaultRetryPolicy(),
+		Endpoints:       DefaultEndpoints(),
 		providerName:    "xero",
 		HTTPClient:      httpClient,
 	}
@@ -177,8 +196,13 @@ func (p *Provider) Debug(debug bool) {
 }
 
 // BeginAuth asks Xero for an authentication end-point and a request token for a session.
-// Xero does not support the "state" variable.
+// Xero does not support the "state" variable when using OAuth 1.0a.
+// When using oauth2 the session holds the PKCE verifier needed to complete the flow.
 func (p *Provider) BeginAuth(state string) (goth.Session, error) {
+	if p.Method == oauth2Method {
+		return p.beginOAuth2(state), nil
+	}
+
 	if p.consumer == nil {
 		p.initConsumer()
 	}
@@ -189,7 +213,7 @@ func (p *Provider) BeginAuth(state string) (goth.Session, error) {
 			Secret: p.Secret,
 		}
 		privateSession := &Session{
-			AuthURL:            authorizeURL,
+			AuthURL:            p.endpoints().Authorize,
 			RequestToken:       nil,
 			AccessToken:        accessToken,
 			AccessTokenExpires: time.Now().UTC().Add(87600 * time.Hour),
@@ -207,15 +231,31 @@ func (p *Provider) BeginAuth(state string) (goth.Session, error) {
 	return session, nil
 }
 
-//processRequest processes a request prior to it being sent to the API
+//processRequest processes a request prior to it being sent to the API.
+//Waiting on the rate limiter or between retries stops as soon as the request's context is done
 func (p *Provider) processRequest(request *http.Request, session goth.Session, additionalHeaders map[string]string) ([]byte, error) {
-	sess := session.(*Session)
+	response, err := p.processRequestStream(request, session, additionalHeaders)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
 
-	if p.consumer == nil {
-		p.initConsumer()
+	responseBytes, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read response: %s", err.Error())
 	}
+	if responseBytes == nil {
+		return nil, fmt.Errorf("Received no response: %s", err.Error())
+	}
+	return responseBytes, nil
+}
 
-	if sess.AccessToken == nil {
+//processRequestStream sends a request in the same way as processRequest but returns the response
+//without reading it so that large or binary bodies can be streamed. The caller must close the body
+func (p *Provider) processRequestStream(request *http.Request, session goth.Session, additionalHeaders map[string]string) (*http.Response, error) {
+	sess := session.(*Session)
+
+	if sess.OAuth2Token == nil && sess.AccessToken == nil {
 		// data is not yet retrieved since accessToken is still empty
 		return nil, fmt.Errorf("%s cannot process request without accessToken", p.providerName)
 	}
@@ -224,60 +264,122 @@ func (p *Provider) processRequest(request *http.Request, session goth.Session, a
 	for key, value := range additionalHeaders {
 		request.Header.Add(key, value)
 	}
+	//a Xero-Tenant-Id passed in additionalHeaders takes precedence over the session's tenant
+	if sess.TenantID != "" && request.Header.Get(tenantHeader) == "" {
+		request.Header.Set(tenantHeader, sess.TenantID)
+	}
+	tenantID := request.Header.Get(tenantHeader)
 
 	var err error
 	var response *http.Response
 
-	if p.HTTPClient == nil {
-
-		client, _ := p.consumer.MakeHttpClient(sess.AccessToken)
+	rateLimitRetries := 0
+	for attempt := 1; ; {
+		response, err = p.sendLimited(request, sess, tenantID)
+
+		var backoff time.Duration
+		if err == nil && p.RateLimiter != nil && p.RateLimiter.shouldRetry(request, response, rateLimitRetries) {
+			//the rate limiter holds the call back until the Retry-After has passed
+			rateLimitRetries++
+		} else if delay, retry := p.RetryPolicy.next(request, attempt, response, err); retry {
+			attempt++
+			backoff = delay
+		} else {
+			break
+		}
+		if response != nil {
+			response.Body.Close()
+		}
+		err = p.RetryPolicy.pause(request.Context(), backoff)
+		if err != nil {
+			return nil, err
+		}
 
-		response, err = client.Do(request)
+		if request.GetBody != nil {
+			request.Body, err = request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	} else {
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		defer response.Body.Close()
+		return nil, newAPIError(response)
+	}
 
-		transport, _ := p.consumer.MakeRoundTripper(sess.AccessToken)
+	return response, nil
+}
 
-		response, err = transport.RoundTrip(request)
+//sendLimited sends a request once the rate limiter allows it and records the limits Xero reports back
+func (p *Provider) sendLimited(request *http.Request, sess *Session, tenantID string) (*http.Response, error) {
+	if p.RateLimiter == nil {
+		return p.send(request, sess)
 	}
 
+	release, err := p.RateLimiter.WaitCtx(request.Context(), tenantID)
+	if err != nil {
+		return nil, err
+	}
+	response, err := p.send(request, sess)
+	release()
 	if err != nil {
 		return nil, err
 	}
+	p.RateLimiter.Update(tenantID, response)
+	return response, nil
+}
 
-	defer response.Body.Close()
+//send signs a request with the session's credentials and sends it to the API
+func (p *Provider) send(request *http.Request, sess *Session) (*http.Response, error) {
+	if sess.OAuth2Token != nil {
+		token, err := p.oauth2Token(request.Context(), sess)
+		if err != nil {
+			return nil, err
+		}
 
-	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf(
-			"%d error trying to find information.\n\nResponse:\n%s",
-			response.StatusCode,
-			helpers.ReaderToString(response.Body),
-		)
+		token.SetAuthHeader(request)
+
+		return p.transport(p.Client().Do).RoundTrip(request)
 	}
 
-	responseBytes, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		return nil, fmt.Errorf("Could not read response: %s", err.Error())
+	if p.consumer == nil {
+		p.initConsumer()
 	}
-	if responseBytes == nil {
-		return nil, fmt.Errorf("Received no response: %s", err.Error())
+
+	if p.HTTPClient == nil {
+
+		client, _ := p.consumer.MakeHttpClient(sess.AccessToken)
+
+		return client.Do(request)
 	}
-	return responseBytes, nil
+
+	transport, _ := p.consumer.MakeRoundTripper(sess.AccessToken)
+
+	return transport.RoundTrip(request)
 }
 
 //Find retrieves the requested data from an endpoint to be unmarshaled into the appropriate data type
 func (p *Provider) Find(session goth.Session, endpoint string, additionalHeaders map[string]string, querystringParameters map[string]string) ([]byte, error) {
-	var querystring string
-	if querystringParameters != nil {
-		for key, value := range querystringParameters {
-			escapedValue := url.QueryEscape(value)
-			querystring = querystring + "&" + key + "=" + escapedValue
-		}
-		querystring = strings.TrimPrefix(querystring, "&")
-		querystring = "?" + querystring
-	}
+	return p.FindCtx(context.Background(), session, endpoint, additionalHeaders, querystringParameters)
+}
 
-	request, err := http.NewRequest("GET", endpointProfile+endpoint+querystring, nil)
+//FindCtx is Find with a context that can cancel the request or set its deadline
+func (p *Provider) FindCtx(ctx context.Context, session goth.Session, endpoint string, additionalHeaders map[string]string, querystringParameters map[string]string) ([]byte, error) {
+	return p.FindWithEndpointCtx(ctx, session, p.AccountingEndpoint(), endpoint, additionalHeaders, querystringParameters)
+}
+
+//Find retrieves the requested data from an endpoint to be unmarshaled into the appropriate data type
+func (p *Provider) FindWithEndpoint(session goth.Session, ep string, endpoint string, additionalHeaders map[string]string, querystringParameters map[string]string) ([]byte, error) {
+	return p.FindWithEndpointCtx(context.Background(), session, ep, endpoint, additionalHeaders, querystringParameters)
+}
+
+//FindWithEndpointCtx is FindWithEndpoint with a context that can cancel the request or set its deadline
+func (p *Provider) FindWithEndpointCtx(ctx context.Context, session goth.Session, ep string, endpoint string, additionalHeaders map[string]string, querystringParameters map[string]string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, "GET", ep+endpoint+buildQuerystring(querystringParameters), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -285,8 +387,8 @@ func (p *Provider) Find(session goth.Session, endpoint string, additionalHeaders
 	return p.processRequest(request, session, additionalHeaders)
 }
 
-//Find retrieves the requested data from an endpoint to be unmarshaled into the appropriate data type
-func (p *Provider) FindWithEndpoint(session goth.Session, ep string, endpoint string, additionalHeaders map[string]string, querystringParameters map[string]string) ([]byte, error) {
+//buildQuerystring escapes the querystring parameters and joins them into a querystring starting with ?
+func buildQuerystring(querystringParameters map[string]string) string {
 	var querystring string
 	if querystringParameters != nil {
 		for key, value := range querystringParameters {
@@ -296,20 +398,49 @@ func (p *Provider) FindWithEndpoint(session goth.Session, ep string, endpoint st
 		querystring = strings.TrimPrefix(querystring, "&")
 		querystring = "?" + querystring
 	}
+	return querystring
+}
+
+//Download gets a file such as an attachment from an endpoint. Unlike Find the response is not read into
+//memory, so the caller reads the file from the returned body and must close it
+func (p *Provider) Download(session goth.Session, endpoint string, additionalHeaders map[string]string, querystringParameters map[string]string) (io.ReadCloser, error) {
+	return p.DownloadCtx(context.Background(), session, endpoint, additionalHeaders, querystringParameters)
+}
 
-	request, err := http.NewRequest("GET", ep+endpoint+querystring, nil)
+//DownloadCtx is Download with a context that can cancel the request or set its deadline
+func (p *Provider) DownloadCtx(ctx context.Context, session goth.Session, endpoint string, additionalHeaders map[string]string, querystringParameters map[string]string) (io.ReadCloser, error) {
+	request, err := http.NewRequestWithContext(ctx, "GET", p.AccountingEndpoint()+endpoint+buildQuerystring(querystringParameters), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	return p.processRequest(request, session, additionalHeaders)
+	response, err := p.processRequestStream(request, session, additionalHeaders)
+	if err != nil {
+		return nil, err
+	}
+	return response.Body, nil
 }
 
 //Create sends data to an endpoint and returns a response to be unmarshaled into the appropriate data type
 func (p *Provider) Create(session goth.Session, endpoint string, additionalHeaders map[string]string, body []byte) ([]byte, error) {
+	return p.CreateCtx(context.Background(), session, endpoint, additionalHeaders, body)
+}
+
+//CreateCtx is Create with a context that can cancel the request or set its deadline
+func (p *Provider) CreateCtx(ctx context.Context, session goth.Session, endpoint string, additionalHeaders map[string]string, body []byte) ([]byte, error) {
+	return p.CreateWithEndpointCtx(ctx, session, p.AccountingEndpoint(), endpoint, additionalHeaders, body)
+}
+
+//CreateWithEndpoint is Create for an API other than Accounting, such as p.PayrollEndpoint()
+func (p *Provider) CreateWithEndpoint(session goth.Session, ep string, endpoint string, additionalHeaders map[string]string, body []byte) ([]byte, error) {
+	return p.CreateWithEndpointCtx(context.Background(), session, ep, endpoint, additionalHeaders, body)
+}
+
+//CreateWithEndpointCtx is CreateWithEndpoint with a context that can cancel the request or set its deadline
+func (p *Provider) CreateWithEndpointCtx(ctx context.Context, session goth.Session, ep string, endpoint string, additionalHeaders map[string]string, body []byte) ([]byte, error) {
 	bodyReader := bytes.NewReader(body)
 
-	request, err := http.NewRequest("PUT", endpointProfile+endpoint, bodyReader)
+	request, err := http.NewRequestWithContext(ctx, "PUT", ep+endpoint, bodyReader)
 	if err != nil {
 		return nil, err
 	}
@@ -319,19 +450,66 @@ func (p *Provider) Create(session goth.Session, endpoint string, additionalHeade
 
 //Update sends data to an endpoint and returns a response to be unmarshaled into the appropriate data type
 func (p *Provider) Update(session goth.Session, endpoint string, additionalHeaders map[string]string, body []byte) ([]byte, error) {
+	return p.UpdateCtx(context.Background(), session, endpoint, additionalHeaders, body)
+}
+
+//UpdateCtx is Update with a context that can cancel the request or set its deadline
+func (p *Provider) UpdateCtx(ctx context.Context, session goth.Session, endpoint string, additionalHeaders map[string]string, body []byte) ([]byte, error) {
+	return p.UpdateWithEndpointCtx(ctx, session, p.AccountingEndpoint(), endpoint, additionalHeaders, body)
+}
+
+//UpdateWithEndpoint is Update for an API other than Accounting, such as p.PayrollEndpoint()
+func (p *Provider) UpdateWithEndpoint(session goth.Session, ep string, endpoint string, additionalHeaders map[string]string, body []byte) ([]byte, error) {
+	return p.UpdateWithEndpointCtx(context.Background(), session, ep, endpoint, additionalHeaders, body)
+}
+
+//UpdateWithEndpointCtx is UpdateWithEndpoint with a context that can cancel the request or set its deadline
+func (p *Provider) UpdateWithEndpointCtx(ctx context.Context, session goth.Session, ep string, endpoint string, additionalHeaders map[string]string, body []byte) ([]byte, error) {
 	bodyReader := bytes.NewReader(body)
 
-	request, err := http.NewRequest("POST", endpointProfile+endpoint, bodyReader)
+	request, err := http.NewRequestWithContext(ctx, "POST", ep+endpoint, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.processRequest(request, session, additionalHeaders)
+}
+
+//Upload sends a file such as an attachment to an endpoint as it is, rather than as XML, with the
+//given content type. The upload can only be retried if body is a *bytes.Buffer, *bytes.Reader or *strings.Reader
+func (p *Provider) Upload(session goth.Session, endpoint string, contentType string, additionalHeaders map[string]string, body io.Reader) ([]byte, error) {
+	return p.UploadCtx(context.Background(), session, endpoint, contentType, additionalHeaders, body)
+}
+
+//UploadCtx is Upload with a context that can cancel the request or set its deadline
+func (p *Provider) UploadCtx(ctx context.Context, session goth.Session, endpoint string, contentType string, additionalHeaders map[string]string, body io.Reader) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, "POST", p.AccountingEndpoint()+endpoint, body)
 	if err != nil {
 		return nil, err
 	}
+	request.Header.Set("Content-Type", contentType)
 
 	return p.processRequest(request, session, additionalHeaders)
 }
 
 //Remove deletes the specified data from an endpoint
 func (p *Provider) Remove(session goth.Session, endpoint string, additionalHeaders map[string]string) ([]byte, error) {
-	request, err := http.NewRequest("DELETE", endpointProfile+endpoint, nil)
+	return p.RemoveCtx(context.Background(), session, endpoint, additionalHeaders)
+}
+
+//RemoveCtx is Remove with a context that can cancel the request or set its deadline
+func (p *Provider) RemoveCtx(ctx context.Context, session goth.Session, endpoint string, additionalHeaders map[string]string) ([]byte, error) {
+	return p.RemoveWithEndpointCtx(ctx, session, p.AccountingEndpoint(), endpoint, additionalHeaders)
+}
+
+//RemoveWithEndpoint is Remove for an API other than Accounting, such as p.PayrollEndpoint()
+func (p *Provider) RemoveWithEndpoint(session goth.Session, ep string, endpoint string, additionalHeaders map[string]string) ([]byte, error) {
+	return p.RemoveWithEndpointCtx(context.Background(), session, ep, endpoint, additionalHeaders)
+}
+
+//RemoveWithEndpointCtx is RemoveWithEndpoint with a context that can cancel the request or set its deadline
+func (p *Provider) RemoveWithEndpointCtx(ctx context.Context, session goth.Session, ep string, endpoint string, additionalHeaders map[string]string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, "DELETE", ep+endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -372,6 +550,17 @@ func (p *Provider) FetchUser(session goth.Session) (goth.User, error) {
 	additionalHeaders := map[string]string{
 		"Accept": "application/json",
 	}
+	//OAuth 2.0 requests must target a tenant so default to the first one the user connected
+	if sess.OAuth2Token != nil && sess.TenantID == "" {
+		connections, err := p.FindConnections(sess)
+		if err != nil {
+			return user, err
+		}
+		if len(connections) == 0 {
+			return user, errors.New("no tenants have been connected to this session")
+		}
+		sess.TenantID = connections[0].TenantID
+	}
 	responseBytes, err := p.Find(sess, "Organisation", additionalHeaders, nil)
 	if err != nil {
 		return user, err
@@ -388,8 +577,13 @@ func (p *Provider) FetchUser(session goth.Session) (goth.User, error) {
 	user.Description = organisationCollection.Organisations[0].OrganisationType
 	user.UserID = organisationCollection.Organisations[0].ShortCode
 
-	user.AccessToken = sess.AccessToken.Token
-	user.AccessTokenSecret = sess.AccessToken.Secret
+	if sess.OAuth2Token != nil {
+		user.AccessToken = sess.OAuth2Token.AccessToken
+		user.RefreshToken = sess.OAuth2Token.RefreshToken
+	} else {
+		user.AccessToken = sess.AccessToken.Token
+		user.AccessTokenSecret = sess.AccessToken.Secret
+	}
 	user.ExpiresAt = sess.AccessTokenExpires
 	user.Email = p.Method
 	return user, err
@@ -412,16 +606,25 @@ func (p *Provider) RefreshOAuth1Token(session *Session) error {
 	return nil
 }
 
-//RefreshToken refresh token is not provided by the Xero Public or Private Application -
-//only the Partner Application and you must use RefreshOAuth1Token instead
+//RefreshToken exchanges an OAuth 2.0 refresh token for a new token.
+//Refresh tokens are not provided by Xero Public or Private OAuth 1.0a Applications and
+//Partner Applications must use RefreshOAuth1Token instead
 func (p *Provider) RefreshToken(refreshToken string) (*oauth2.Token, error) {
-	return nil, errors.New("Refresh token is only provided by Xero for Partner Applications")
+	return p.refreshToken(context.Background(), refreshToken)
 }
 
-//RefreshTokenAvailable refresh token is not provided by the Xero Public or Private Application -
-//only the Partner Application and you must use RefreshOAuth1Token instead
+func (p *Provider) refreshToken(ctx context.Context, refreshToken string) (*oauth2.Token, error) {
+	if p.Method != oauth2Method {
+		return nil, errors.New("Refresh token is only provided by Xero for OAuth 2.0 Applications")
+	}
+	tokenSource := p.oauth2Config().TokenSource(p.oauth2Context(ctx), &oauth2.Token{RefreshToken: refreshToken})
+	return tokenSource.Token()
+}
+
+//RefreshTokenAvailable refresh token is only available to OAuth 2.0 Applications -
+//Partner Applications must use RefreshOAuth1Token instead
 func (p *Provider) RefreshTokenAvailable() bool {
-	return false
+	return p.Method == oauth2Method
 }
 
 //GetSessionFromStore returns a session for a given a request and a response
@@ -446,12 +649,22 @@ func (p *Provider) GetSessionFromStore(request *http.Request, response http.Resp
 			err = sessionMarshalled.Save(request, response)
 			return session, err
 		}
+		if sess.OAuth2Token != nil {
+			err = p.RefreshOAuth2Token(sess)
+			if err != nil {
+				return nil, err
+			}
+			sessionMarshalled.Values["xero"] = sess.Marshal()
+			err = sessionMarshalled.Save(request, response)
+			return session, err
+		}
 		return nil, errors.New("access token has expired - please reconnect")
 	}
 	return session, err
 }
 
 func (p *Provider) initConsumer() {
+	authorizeURL := p.endpoints().Authorize
 	switch p.Method {
 	case "private":
 		p.consumer = p.newPrivateOrPartnerConsumer(authorizeURL)
@@ -462,4 +675,6 @@ func (p *Provider) initConsumer() {
 	default:
 		p.consumer = p.newPublicConsumer(authorizeURL)
 	}
+	//the consumer sends requests once it has signed them so the middleware wraps its client
+	p.consumer.HttpClient = &middlewareClient{provider: p, client: p.consumer.HttpClient}
 }
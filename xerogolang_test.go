@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/gorilla/pat"
@@ -38,6 +41,7 @@ func Test_BeginAuth(t *testing.T) {
 
 	mockXero(func(ts *httptest.Server) {
 		provider := xeroProvider()
+		provider.Endpoints = mockEndpoints(ts)
 		session, err := provider.BeginAuth("state")
 		if err != nil {
 			a.Error(err, nil)
@@ -56,6 +60,7 @@ func Test_FetchUser(t *testing.T) {
 
 	mockXero(func(ts *httptest.Server) {
 		provider := xeroProvider()
+		provider.Endpoints = mockEndpoints(ts)
 		session := Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
 
 		user, err := provider.FetchUser(&session)
@@ -95,6 +100,7 @@ func Test_Find(t *testing.T) {
 
 	mockXero(func(ts *httptest.Server) {
 		provider := xeroProvider()
+		provider.Endpoints = mockEndpoints(ts)
 		session := Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
 
 		additionalHeaders := map[string]string{
@@ -127,6 +133,7 @@ func Test_Create(t *testing.T) {
 
 	mockXero(func(ts *httptest.Server) {
 		provider := xeroProvider()
+		provider.Endpoints = mockEndpoints(ts)
 		session := Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
 
 		additionalHeaders := map[string]string{
@@ -176,6 +183,7 @@ func Test_Update(t *testing.T) {
 
 	mockXero(func(ts *httptest.Server) {
 		provider := xeroProvider()
+		provider.Endpoints = mockEndpoints(ts)
 		session := Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
 
 		additionalHeaders := map[string]string{
@@ -224,6 +232,7 @@ func Test_Remove(t *testing.T) {
 
 	mockXero(func(ts *httptest.Server) {
 		provider := xeroProvider()
+		provider.Endpoints = mockEndpoints(ts)
 		session := Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
 
 		additionalHeaders := map[string]string{
@@ -250,10 +259,97 @@ func Test_Remove(t *testing.T) {
 
 }
 
+func Test_Upload(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := xeroProvider()
+		provider.Endpoints = mockEndpoints(ts)
+		session := Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
+
+		additionalHeaders := map[string]string{
+			"Accept": "application/json",
+		}
+
+		//the file is sent as it is rather than as XML
+		response, err := provider.Upload(&session, "Invoices/111-111/Attachments/receipt.png?IncludeOnline=true", "image/png", additionalHeaders, strings.NewReader("PNG IMAGE"))
+		a.NoError(err)
+
+		var upload map[string]interface{}
+		a.NoError(json.Unmarshal(response, &upload))
+		a.Equal("receipt.png", upload["FileName"])
+		a.Equal("image/png", upload["MimeType"])
+		a.Equal("PNG IMAGE", upload["Content"])
+		a.Equal("true", upload["IncludeOnline"])
+	})
+}
+
+func Test_Download(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := xeroProvider()
+		provider.Endpoints = mockEndpoints(ts)
+		session := Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
+
+		additionalHeaders := map[string]string{
+			"Accept": "application/pdf",
+		}
+
+		body, err := provider.Download(&session, "Invoices/111-111/Attachments/invoice.pdf", additionalHeaders, nil)
+		a.NoError(err)
+		defer body.Close()
+
+		contents, err := ioutil.ReadAll(body)
+		a.NoError(err)
+		a.Equal("%PDF-1.4 invoice.pdf", string(contents))
+
+		_, err = provider.Download(&session, "Invoices/111-111/Attachments/missing.pdf", additionalHeaders, nil)
+		apiError, ok := AsAPIError(err)
+		a.True(ok)
+		a.True(apiError.IsNotFound())
+	})
+}
+
+func Test_Update_NoContent(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	mockXero(func(ts *httptest.Server) {
+		provider := xeroProvider()
+		provider.Endpoints = mockEndpoints(ts)
+		session := Session{AccessToken: &oauth.AccessToken{Token: "TOKEN", Secret: "SECRET"}}
+
+		//actions such as emailing an invoice have no body and no response
+		response, err := provider.Update(&session, "Invoices/111-111/Email", nil, nil)
+		a.NoError(err)
+		a.Empty(response)
+	})
+}
+
 func xeroProvider() *Provider {
 	return New(os.Getenv("XERO_KEY"), os.Getenv("XERO_SECRET"), "/foo")
 }
 
+//mockEndpoints points every API and OAuth URL at the mock Xero server ts
+func mockEndpoints(ts *httptest.Server) Endpoints {
+	return Endpoints{
+		Accounting:      ts.URL + "/api.xro/2.0/",
+		Payroll:         ts.URL + "/payroll.xro/1.0/",
+		PayrollV2:       ts.URL + "/payroll.xro/2.0/",
+		Assets:          ts.URL + "/assets.xro/1.0/",
+		Connections:     ts.URL + "/connections",
+		RequestToken:    ts.URL + "/oauth/RequestToken",
+		Authorize:       ts.URL + "/oauth/Authorize",
+		AccessToken:     ts.URL + "/oauth/AccessToken",
+		OAuth2Authorize: ts.URL + "/identity/connect/authorize",
+		OAuth2Token:     ts.URL + "/connect/token",
+	}
+}
+
+//mockXero runs a mock Xero server for f. Providers only use it once their Endpoints are set with mockEndpoints
 func mockXero(f func(*httptest.Server)) {
 	p := pat.New()
 	p.Get("/oauth/RequestToken", func(res http.ResponseWriter, req *http.Request) {
@@ -265,6 +361,113 @@ func mockXero(f func(*httptest.Server)) {
 	p.Get("/oauth/AccessToken", func(res http.ResponseWriter, req *http.Request) {
 		fmt.Fprint(res, "oauth_token=TOKEN&oauth_token_secret=SECRET")
 	})
+	p.Post("/connect/token", func(res http.ResponseWriter, req *http.Request) {
+		req.ParseForm()
+		token := map[string]interface{}{
+			"access_token":  "ACCESSTOKEN",
+			"refresh_token": "REFRESHTOKEN",
+			"token_type":    "Bearer",
+			"expires_in":    1800,
+		}
+		if req.Form.Get("grant_type") == "refresh_token" {
+			token["access_token"] = "REFRESHED-" + req.Form.Get("refresh_token")
+			token["refresh_token"] = "ROTATED-" + req.Form.Get("refresh_token")
+		} else if req.Form.Get("code") != "CODE" || req.Form.Get("code_verifier") == "" {
+			res.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(res, `{"error":"invalid_grant"}`)
+			return
+		}
+
+		res.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(res).Encode(token)
+	})
+	p.Get("/connections", func(res http.ResponseWriter, req *http.Request) {
+		connections := []Connection{
+			{ID: "c-1", TenantID: "t-1", TenantType: "ORGANISATION", TenantName: "Vanderlay Industries"},
+			{ID: "c-2", TenantID: "t-2", TenantType: "ORGANISATION", TenantName: "Kramerica Industries"},
+		}
+		json.NewEncoder(res).Encode(connections)
+	})
+	p.Delete("/connections/{ID}", func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusNoContent)
+	})
+	p.Get("/api.xro/2.0/Echo", func(res http.ResponseWriter, req *http.Request) {
+		json.NewEncoder(res).Encode(req.Header)
+	})
+	p.Get("/api.xro/2.0/Invoices/{ID}/Attachments/{FileName}", func(res http.ResponseWriter, req *http.Request) {
+		fileName := req.URL.Query().Get(":FileName")
+		if fileName == "missing.pdf" {
+			res.WriteHeader(http.StatusNotFound)
+			return
+		}
+		res.Header().Set("Content-Type", req.Header.Get("Accept"))
+		fmt.Fprint(res, "%PDF-1.4 "+fileName)
+	})
+	p.Post("/api.xro/2.0/Invoices/{ID}/Attachments/{FileName}", func(res http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		json.NewEncoder(res).Encode(map[string]string{
+			"FileName":      req.URL.Query().Get(":FileName"),
+			"MimeType":      req.Header.Get("Content-Type"),
+			"Content":       string(body),
+			"IncludeOnline": req.URL.Query().Get("IncludeOnline"),
+		})
+	})
+	p.Post("/api.xro/2.0/Invoices/{ID}/Email", func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusNoContent)
+	})
+	p.Put("/api.xro/2.0/Invoices", func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(res, `{"ErrorNumber":10,"Type":"ValidationException","Message":"A validation exception occurred","Elements":[{"Type":"ACCREC","ValidationErrors":[{"Message":"Email address must be valid."}]}]}`)
+	})
+	//Limited responds with a 429 to the first call for each tenant and method
+	limitedTenants := map[string]bool{}
+	limitedMutex := sync.Mutex{}
+	limited := func(res http.ResponseWriter, req *http.Request) {
+		key := req.Method + " " + req.Header.Get("Xero-Tenant-Id")
+		limitedMutex.Lock()
+		seen := limitedTenants[key]
+		limitedTenants[key] = true
+		limitedMutex.Unlock()
+
+		res.Header().Set("X-DayLimit-Remaining", "4321")
+		res.Header().Set("X-AppMinLimit-Remaining", "9876")
+		if !seen {
+			res.Header().Set("Retry-After", "7")
+			res.Header().Set("X-Rate-Limit-Problem", "minute")
+			res.Header().Set("X-MinLimit-Remaining", "0")
+			res.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		res.Header().Set("X-MinLimit-Remaining", "42")
+		body, _ := ioutil.ReadAll(req.Body)
+		if len(body) > 0 {
+			fmt.Fprintf(res, `{"Status":"OK","Body":%q}`, body)
+			return
+		}
+		fmt.Fprint(res, `{"Status":"OK"}`)
+	}
+	p.Get("/api.xro/2.0/Limited", limited)
+	p.Post("/api.xro/2.0/Limited", limited)
+	//Unavailable responds with a 503 to the first two calls for each tenant and method
+	unavailableCalls := map[string]int{}
+	unavailableMutex := sync.Mutex{}
+	unavailable := func(res http.ResponseWriter, req *http.Request) {
+		key := req.Method + " " + req.Header.Get("Xero-Tenant-Id")
+		unavailableMutex.Lock()
+		unavailableCalls[key]++
+		calls := unavailableCalls[key]
+		unavailableMutex.Unlock()
+
+		if calls <= 2 {
+			res.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(res, "The Organisation is offline")
+			return
+		}
+		body, _ := ioutil.ReadAll(req.Body)
+		fmt.Fprintf(res, `{"Status":"OK","Calls":%d,"Body":%q}`, calls, body)
+	}
+	p.Get("/api.xro/2.0/Unavailable", unavailable)
+	p.Put("/api.xro/2.0/Unavailable", unavailable)
 	p.Get("/api.xro/2.0/Organisation", func(res http.ResponseWriter, req *http.Request) {
 		apiResponse := OrganisationCollection{
 			Organisations: []Organisation{
@@ -342,22 +545,7 @@ func mockXero(f func(*httptest.Server)) {
 	ts := httptest.NewServer(p)
 	defer ts.Close()
 
-	originalRequestURL := requestURL
-	originalEndpointProfile := endpointProfile
-	originalAuthorizeURL := authorizeURL
-	originalAccessTokenURL := tokenURL
-
-	requestURL = ts.URL + "/oauth/RequestToken"
-	endpointProfile = ts.URL + "/api.xro/2.0/"
-	authorizeURL = ts.URL + "/oauth/Authorize"
-	tokenURL = ts.URL + "/oauth/AccessToken"
-
 	f(ts)
-
-	requestURL = originalRequestURL
-	endpointProfile = originalEndpointProfile
-	authorizeURL = originalAuthorizeURL
-	tokenURL = originalAccessTokenURL
 }
 
 //Test is a tracking category -  we're just testing how the API responds here